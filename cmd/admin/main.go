@@ -3,30 +3,37 @@
 package main
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
 	"image"
-	"image/png"
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 	"unsafe"
 
+	"github.com/KernTom/scoreboard-manager/internal/config"
 	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/fonts"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/logo"
 	"github.com/KernTom/scoreboard-manager/internal/models"
+	"github.com/KernTom/scoreboard-manager/internal/render"
+	"github.com/KernTom/scoreboard-manager/internal/standings"
+	"github.com/KernTom/scoreboard-manager/internal/tournament"
+	"github.com/KernTom/scoreboard-manager/internal/ui"
 	"github.com/lxn/walk"
 	. "github.com/lxn/walk/declarative"
 	"github.com/lxn/win"
 	"golang.org/x/image/draw"
 )
 
-const defaultFontFamily = "DS-Digital"
-const defaultClockSize = 32
-const defaultScoreSize = 36
-const defaultPeriodSize = 24
+// cfg ist die Konfiguration aus scoreboard.toml bzw. die eingebauten Vorgaben
+var cfg *config.Config
 
 var (
 	iconNew    *walk.Bitmap
@@ -38,6 +45,9 @@ var (
 var (
 	binder       *walk.DataBinder
 	teamNameEdit *walk.LineEdit
+	shortEdit    *walk.LineEdit
+	abbrEdit     *walk.LineEdit
+	altNamesEdit *walk.LineEdit
 	sportCombo   *walk.ComboBox
 	logoPreview  *walk.ImageView
 	logoPath     string
@@ -62,9 +72,20 @@ var (
 	showClockCB        *walk.CheckBox
 
 	sports         = []string{"American Football", "Fußball"}
-	gameclockModes = []string{"Aufwärts (MM:SS)", "Aufwärts (Fußball-Minuten)", "Abwärts (MM:SS)"}
+	gameclockModes = []string{models.GameclockUpMMSS, models.GameclockUpMinutes, models.GameclockDownMMSS}
 )
 
+// gameclockModeLabels liefert die Anzeige zu gameclockModes; gespeichert
+// wird weiter der Wert, die Auswahl läuft über den Index
+func gameclockModeLabels() []string {
+	keys := []string{"admin.gameclock.up_mmss", "admin.gameclock.up_minutes", "admin.gameclock.down_mmss"}
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		labels[i] = i18n.T(k)
+	}
+	return labels
+}
+
 var previewWindow *walk.MainWindow
 var previewContent *walk.Composite
 var previewOpen bool
@@ -87,6 +108,20 @@ var periodFontColorPreview *walk.Composite
 var backgroundFontColorPreview *walk.Composite
 var extraTimeFontColor walk.Color
 var extraTimeFontColorPreview *walk.Composite
+var separatorFontColor walk.Color
+var separatorColorPreview *walk.Composite
+
+// Schriftauswahl je Element; die Familie darf eine Ausweichkette sein
+var (
+	clockFontCombo     *walk.ComboBox
+	periodFontCombo    *walk.ComboBox
+	scoreFontCombo     *walk.ComboBox
+	separatorFontCombo *walk.ComboBox
+	clockSizeEdit      *walk.NumberEdit
+	periodSizeEdit     *walk.NumberEdit
+	scoreSizeEdit      *walk.NumberEdit
+	separatorSizeEdit  *walk.NumberEdit
+)
 
 type StringListModel struct {
 	walk.ListModelBase
@@ -112,11 +147,17 @@ func (m *StringListModel) Value(index int) interface{} {
 	return m.Items[index] // <- wichtig: ein string, kein anderes Objekt!
 }
 
+var sportsModel = &StringListModel{Items: []string{"American Football", "Fußball"}}
+var currentLogoData []byte
+var currentLogoOriginal []byte
+var currentLogoHash, currentLogoOriginalHash string // Logo des gewählten Teams, ohne neue Datei
+
+// Teamfarben "#RRGGBB" des Formulars, leer = keine
 var (
-	sportsModel         = &StringListModel{Items: []string{"American Football", "Fußball"}}
-	gameclockModesModel = &StringListModel{Items: []string{"Aufwärts (MM:SS)", "Aufwärts (Fußball-Minuten)", "Abwärts (MM:SS)"}}
+	teamPrimaryColor, teamSecondaryColor     string
+	teamPrimaryPreview, teamSecondaryPreview *walk.Composite
+	currentLogoImage                         image.Image
 )
-var currentLogoData []byte
 var templateActionButtons *walk.Composite
 var templateSaveButtons *walk.Composite
 var (
@@ -134,6 +175,44 @@ var (
 	matchTable       *walk.TableView
 	matchModel       *MatchTableModel
 	matchFilterCombo *walk.ComboBox
+	competitionEdit  *walk.LineEdit
+	matchTeams       []*models.Team
+	scoreHomeEdit    *walk.NumberEdit
+	scoreAwayEdit    *walk.NumberEdit
+	matchStatusCombo *walk.ComboBox
+)
+
+var (
+	tournamentTeamList        *walk.ListBox
+	tournamentModeCombo       *walk.ComboBox
+	tournamentCompetitionEdit *walk.LineEdit
+	tournamentStartEdit       *walk.DateEdit
+	tournamentGroupsEdit      *walk.NumberEdit
+	tournamentSlotEdit        *walk.NumberEdit
+	tournamentFieldsEdit      *walk.NumberEdit
+	tournamentVenueCombo      *walk.ComboBox
+)
+
+var (
+	venues             []*models.Venue
+	allFields          []*models.Field
+	venueTable         *walk.TableView
+	venueModel         = &ui.VenueTableModel{}
+	venueNameEdit      *walk.LineEdit
+	venueAddressEdit   *walk.LineEdit
+	fieldTable         *walk.TableView
+	fieldModel         = &ui.FieldTableModel{TemplateName: templateName}
+	fieldNameEdit      *walk.LineEdit
+	fieldTemplateCombo *walk.ComboBox
+	matchFieldCombo    *walk.ComboBox
+)
+
+var (
+	standingsTable            *walk.TableView
+	standingsModel            = &ui.StandingsTableModel{}
+	standingsCompetitionCombo *walk.ComboBox
+	standingsSportCombo       *walk.ComboBox
+	standingsWindow           *walk.MainWindow
 )
 
 type MatchTableModel struct {
@@ -159,6 +238,13 @@ func (m *MatchTableModel) Value(row, col int) interface{} {
 		return team.Team2.Name
 	case 4:
 		return team.GameTime.Format("2006-01-02 15:04:05")
+	case 5:
+		return team.Status
+	case 6:
+		if team.HasResult() {
+			return fmt.Sprintf("%d : %d", *team.ScoreHome, *team.ScoreAway)
+		}
+		return ""
 	default:
 		return ""
 	}
@@ -179,7 +265,7 @@ func (m *MatchTableModel) Sort(col int, order walk.SortOrder) error {
 }
 
 func (m *MatchTableModel) ApplyFilter(sportart string) {
-	if sportart == "" || sportart == "Alle" {
+	if sportart == "" || sportart == i18n.T("admin.all") {
 		m.Filtered = m.Matches
 	} else {
 		m.Filtered = []*models.Match{}
@@ -240,7 +326,7 @@ func (m *TeamTableModel) Sort(col int, order walk.SortOrder) error {
 }
 
 func (m *TeamTableModel) ApplyFilter(sportart string) {
-	if sportart == "" || sportart == "Alle" {
+	if sportart == "" || sportart == i18n.T("admin.all") {
 		m.Filtered = m.Teams
 	} else {
 		m.Filtered = []*models.Team{}
@@ -288,10 +374,19 @@ func (m *TemplateTableModel) Sort(col int, order walk.SortOrder) error {
 	return nil
 }
 
+// besideExe liefert relative Verzeichnisse aus der Konfiguration neben der exe
+func besideExe(dir string) string {
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	executablePath, _ := os.Executable()
+	return filepath.Join(filepath.Dir(executablePath), dir)
+}
+
 func loadIcons() error {
 
-	executablePath, _ := os.Executable()
-	iconPath := filepath.Join(filepath.Dir(executablePath), "icons", "new.bmp")
+	iconsDir := besideExe(cfg.IconsDir)
+	iconPath := filepath.Join(iconsDir, "new.bmp")
 
 	var err error
 
@@ -299,22 +394,22 @@ func loadIcons() error {
 	if err != nil {
 		return err
 	}
-	iconPath = filepath.Join(filepath.Dir(executablePath), "icons", "edit.bmp")
+	iconPath = filepath.Join(iconsDir, "edit.bmp")
 	iconEdit, err = walk.NewBitmapFromFile(iconPath)
 	if err != nil {
 		return err
 	}
-	iconPath = filepath.Join(filepath.Dir(executablePath), "icons", "save.bmp")
+	iconPath = filepath.Join(iconsDir, "save.bmp")
 	iconSave, err = walk.NewBitmapFromFile(iconPath)
 	if err != nil {
 		return err
 	}
-	iconPath = filepath.Join(filepath.Dir(executablePath), "icons", "trash.bmp")
+	iconPath = filepath.Join(iconsDir, "trash.bmp")
 	iconDelete, err = walk.NewBitmapFromFile(iconPath)
 	if err != nil {
 		return err
 	}
-	iconPath = filepath.Join(filepath.Dir(executablePath), "icons", "cancel.bmp")
+	iconPath = filepath.Join(iconsDir, "cancel.bmp")
 	iconCancel, err = walk.NewBitmapFromFile(iconPath)
 	if err != nil {
 		return err
@@ -337,13 +432,23 @@ func updatePreviewButtonState() {
 	}
 }
 func main() {
-	if err := database.InitDatabase(); err != nil {
-		log.Fatal("Datenbank konnte nicht initialisiert werden:", err)
+	var err error
+	if cfg, err = config.Load(); err != nil {
+		log.Fatal(err)
+	}
+	cfg.Apply()
+
+	if err := database.Open(cfg.Database); err != nil {
+		log.Fatal(i18n.T("log.db_failed", err))
 	}
 	defer database.Close()
 
 	if err := loadIcons(); err != nil {
-		log.Fatal("Icons konnten nicht geladen werden:", err)
+		log.Fatal(i18n.T("admin.log.icons_failed", err))
+	}
+
+	if err := loadFonts(); err != nil {
+		log.Print(i18n.T("log.fonts_failed", err))
 	}
 
 	teamModel = &TeamTableModel{
@@ -362,13 +467,13 @@ func main() {
 
 	sportNames, err := loadSports()
 	if err != nil {
-		log.Fatal("Sportarten konnten nicht geladen werden:", err)
+		log.Fatal(i18n.T("admin.log.sports_failed", err))
 	}
 
 	sportsModel = &StringListModel{Items: sportNames}
 	teamNames, err := loadTeams()
 	if err != nil {
-		log.Fatal("Sportarten konnten nicht geladen werden:", err)
+		log.Fatal(i18n.T("admin.log.sports_failed", err))
 	}
 
 	teamListModel := &StringListModel{Items: teamNames}
@@ -377,7 +482,7 @@ func main() {
 	var tabs *walk.TabWidget
 	err = MainWindow{
 		AssignTo: &mw,
-		Title:    "Scoreboard Admin",
+		Title:    i18n.T("admin.app"),
 		MinSize:  Size{Width: 600, Height: 500},
 		Layout:   VBox{MarginsZero: true},
 		Children: []Widget{
@@ -386,7 +491,7 @@ func main() {
 				StretchFactor: 1,
 				Pages: []TabPage{
 					{
-						Title:  "Templates",
+						Title:  i18n.T("admin.tab.templates"),
 						Layout: VBox{},
 						Children: []Widget{
 							Composite{
@@ -395,41 +500,41 @@ func main() {
 								Layout:   VBox{},
 								Children: []Widget{
 									GroupBox{
-										Title:  "Template Verwaltung",
+										Title:  i18n.T("admin.group.template_admin"),
 										Layout: Grid{Columns: 4},
 										Children: []Widget{
-											Label{Text: "Name:"},
+											Label{Text: i18n.T("admin.label.name")},
 											LineEdit{AssignTo: &templateNameEdit},
-											Label{Text: "Passwort:"},
+											Label{Text: i18n.T("admin.label.password")},
 											LineEdit{AssignTo: &passwordEdit, PasswordMode: true, OnEditingFinished: checkPassword},
-											Label{Text: "Breite:"},
+											Label{Text: i18n.T("admin.label.width")},
 											NumberEdit{AssignTo: &widthEdit, Enabled: false},
-											Label{Text: "Höhe:"},
+											Label{Text: i18n.T("admin.label.height")},
 											NumberEdit{AssignTo: &heightEdit, Enabled: false},
-											Label{Text: "X:"},
+											Label{Text: i18n.T("admin.label.x")},
 											NumberEdit{AssignTo: &xEdit, Enabled: false},
-											Label{Text: "Y:"},
+											Label{Text: i18n.T("admin.label.y")},
 											NumberEdit{AssignTo: &yEdit, Enabled: false},
 										},
 									},
 									GroupBox{
-										Title:  "Sportart- und Periodeneinstellungen",
+										Title:  i18n.T("admin.group.sport_periods"),
 										Layout: Grid{Columns: 4},
 										Children: []Widget{
-											Label{Text: "Sportart:"},
+											Label{Text: i18n.T("admin.label.sport")},
 											ComboBox{
 												AssignTo: &sportSelect,
 												Model:    sportsModel,
 												Editable: false,
 											},
 
-											Label{Text: "Perioden-Label:"},
+											Label{Text: i18n.T("admin.label.period_label")},
 											LineEdit{
 												AssignTo: &periodLabelEdit,
 												Text:     "Halbzeit", // Default Wert
 											},
 
-											Label{Text: "Anzahl Perioden:"},
+											Label{Text: i18n.T("admin.label.periods")},
 											NumberEdit{
 												AssignTo: &periodsCountEdit,
 												Value:    float64(2), // <-- HIER float64 setzen
@@ -438,7 +543,7 @@ func main() {
 												Decimals: 0,
 											},
 
-											Label{Text: "Periodendauer (Minuten):"},
+											Label{Text: i18n.T("admin.label.period_duration")},
 											NumberEdit{
 												AssignTo: &periodDurationEdit,
 												Value:    float64(45),
@@ -449,15 +554,15 @@ func main() {
 										},
 									},
 									GroupBox{
-										Title:  "Farbeinstellungen",
+										Title:  i18n.T("admin.group.colors"),
 										Layout: Grid{Columns: 6},
 										Children: []Widget{
-											Label{Text: "Uhrfarbe:"},
+											Label{Text: i18n.T("admin.label.clock_color")},
 											Composite{
 												Layout: HBox{},
 												Children: []Widget{
 													PushButton{
-														Text: "Farbe wählen",
+														Text: i18n.T("admin.button.choose_color"),
 														OnClicked: func() {
 															color, ok := pickColor(nil)
 															if ok {
@@ -484,12 +589,12 @@ func main() {
 													},
 												},
 											},
-											Label{Text: "Scorefarbe:"},
+											Label{Text: i18n.T("admin.label.score_color")},
 											Composite{
 												Layout: HBox{},
 												Children: []Widget{
 													PushButton{
-														Text: "Farbe wählen",
+														Text: i18n.T("admin.button.choose_color"),
 														OnClicked: func() {
 															color, ok := pickColor(nil)
 															if ok {
@@ -512,12 +617,12 @@ func main() {
 												},
 											},
 
-											Label{Text: "Periodenfarbe:"},
+											Label{Text: i18n.T("admin.label.period_color")},
 											Composite{
 												Layout: HBox{},
 												Children: []Widget{
 													PushButton{
-														Text: "Farbe wählen",
+														Text: i18n.T("admin.button.choose_color"),
 														OnClicked: func() {
 															color, ok := pickColor(nil)
 															if ok {
@@ -540,12 +645,12 @@ func main() {
 												},
 											},
 
-											Label{Text: "Hintergrundfarbe:"},
+											Label{Text: i18n.T("admin.label.background_color")},
 											Composite{
 												Layout: HBox{},
 												Children: []Widget{
 													PushButton{
-														Text: "Farbe wählen",
+														Text: i18n.T("admin.button.choose_color"),
 														OnClicked: func() {
 															color, ok := pickColor(nil)
 															if ok {
@@ -568,12 +673,40 @@ func main() {
 												},
 											},
 
-											Label{Text: "Overtime Farbe:"},
+											Label{Text: i18n.T("admin.label.separator_color")},
 											Composite{
 												Layout: HBox{},
 												Children: []Widget{
 													PushButton{
-														Text: "Farbe wählen",
+														Text: i18n.T("admin.button.choose_color"),
+														OnClicked: func() {
+															color, ok := pickColor(nil)
+															if ok {
+																separatorFontColor = color
+																if separatorColorPreview != nil {
+																	brush, _ := walk.NewSolidColorBrush(color)
+																	separatorColorPreview.SetBackground(brush)
+																}
+															}
+														},
+													},
+													Composite{
+														AssignTo: &separatorColorPreview,
+														MinSize:  Size{Width: 20, Height: 20},
+														MaxSize:  Size{Width: 20, Height: 20},
+														Border:   true,
+														Layout:   VBox{},
+														Children: []Widget{},
+													},
+												},
+											},
+
+											Label{Text: i18n.T("admin.label.overtime_color")},
+											Composite{
+												Layout: HBox{},
+												Children: []Widget{
+													PushButton{
+														Text: i18n.T("admin.button.choose_color"),
 														OnClicked: func() {
 															color, ok := pickColor(nil)
 															if ok {
@@ -598,22 +731,84 @@ func main() {
 										},
 									},
 									GroupBox{
-										Title:  "Anzeigeoptionen",
+										Title:  i18n.T("admin.group.fonts"),
+										Layout: Grid{Columns: 3},
+										Children: []Widget{
+											Label{Text: i18n.T("admin.label.clock")},
+											ComboBox{
+												AssignTo: &clockFontCombo,
+												Model:    render.Families(nil),
+												Editable: true,
+											},
+											NumberEdit{
+												AssignTo: &clockSizeEdit,
+												Value:    float64(32),
+												MinValue: float64(1),
+												MaxValue: float64(500),
+												Decimals: 0,
+												Suffix:   " px",
+											},
+											Label{Text: i18n.T("admin.label.period")},
+											ComboBox{
+												AssignTo: &periodFontCombo,
+												Model:    render.Families(nil),
+												Editable: true,
+											},
+											NumberEdit{
+												AssignTo: &periodSizeEdit,
+												Value:    float64(20),
+												MinValue: float64(1),
+												MaxValue: float64(500),
+												Decimals: 0,
+												Suffix:   " px",
+											},
+											Label{Text: i18n.T("admin.label.score")},
+											ComboBox{
+												AssignTo: &scoreFontCombo,
+												Model:    render.Families(nil),
+												Editable: true,
+											},
+											NumberEdit{
+												AssignTo: &scoreSizeEdit,
+												Value:    float64(32),
+												MinValue: float64(1),
+												MaxValue: float64(500),
+												Decimals: 0,
+												Suffix:   " px",
+											},
+											Label{Text: i18n.T("admin.label.separator")},
+											ComboBox{
+												AssignTo: &separatorFontCombo,
+												Model:    render.Families(nil),
+												Editable: true,
+											},
+											NumberEdit{
+												AssignTo: &separatorSizeEdit,
+												Value:    float64(28),
+												MinValue: float64(1),
+												MaxValue: float64(500),
+												Decimals: 0,
+												Suffix:   " px",
+											},
+										},
+									},
+									GroupBox{
+										Title:  i18n.T("admin.group.display"),
 										Layout: Grid{Columns: 2},
 										Children: []Widget{
-											Label{Text: "Gameclock-Modus:"},
+											Label{Text: i18n.T("admin.label.gameclock_mode")},
 											ComboBox{
 												AssignTo: &gameclockModeCombo,
-												Model:    []string{"Aufwärts (MM:SS)", "Aufwärts (Fußball-Minuten)", "Abwärts (MM:SS)"},
+												Model:    gameclockModeLabels(),
 												Editable: false,
 											},
 
-											Label{Text: "Periodenanzeige:"},
+											Label{Text: i18n.T("admin.label.show_period")},
 											CheckBox{
 												AssignTo: &showPeriodCB,
 												Checked:  true,
 											},
-											Label{Text: "Gameclock-Anzeige:"},
+											Label{Text: i18n.T("admin.label.show_gameclock")},
 											CheckBox{
 												AssignTo: &showGameclockCB,
 												Checked:  true,
@@ -627,7 +822,7 @@ func main() {
 													}
 												},
 											},
-											Label{Text: "Echte Uhrzeit statt Gameclock:"},
+											Label{Text: i18n.T("admin.label.show_clock")},
 											CheckBox{
 												AssignTo: &showClockCB,
 												Enabled:  false, // Nur aktivierbar, wenn Gameclock deaktiviert
@@ -639,8 +834,8 @@ func main() {
 							TableView{
 								AssignTo: &templateTable,
 								Columns: []TableViewColumn{
-									{Title: "Name", Width: 150},
-									{Title: "Sportart", Width: 100},
+									{Title: i18n.T("admin.col.name"), Width: 150},
+									{Title: i18n.T("admin.col.sport"), Width: 100},
 								},
 								Model:            templateModel,
 								CheckBoxes:       false,
@@ -654,7 +849,7 @@ func main() {
 								Layout:   HBox{},
 								Children: []Widget{
 									PushButton{
-										Text:  "Neu",
+										Text:  i18n.T("admin.button.new"),
 										Image: iconNew,
 										OnClicked: func() {
 											resetTemplateForm()
@@ -662,14 +857,14 @@ func main() {
 										},
 									},
 									PushButton{
-										Text:  "Bearbeiten",
+										Text:  i18n.T("admin.button.edit"),
 										Image: iconEdit,
 										OnClicked: func() {
 											editSelectedTemplate()
 										},
 									},
 									PushButton{
-										Text:  "Löschen",
+										Text:  i18n.T("admin.button.delete"),
 										Image: iconDelete,
 										OnClicked: func() {
 											deleteSelectedTemplate()
@@ -683,14 +878,14 @@ func main() {
 								Layout:   HBox{},
 								Children: []Widget{
 									PushButton{
-										Text:  "Speichern",
+										Text:  i18n.T("admin.button.save"),
 										Image: iconSave,
 										OnClicked: func() {
 											saveTemplate()
 										},
 									},
 									PushButton{
-										Text:  "Abbrechen",
+										Text:  i18n.T("admin.button.cancel"),
 										Image: iconCancel,
 										OnClicked: func() {
 											resetTemplateForm()
@@ -698,7 +893,7 @@ func main() {
 										},
 									},
 									PushButton{
-										Text: "Vorschau",
+										Text: i18n.T("admin.button.preview"),
 										OnClicked: func() {
 											if !previewOpen {
 												openPreviewWindow()
@@ -709,7 +904,7 @@ func main() {
 									},
 									PushButton{
 										AssignTo: &refreshButton,
-										Text:     "Refresh",
+										Text:     i18n.T("admin.button.refresh"),
 										Enabled:  false, // Start deaktiviert
 										OnClicked: func() {
 											refreshPreviewWindow()
@@ -720,42 +915,99 @@ func main() {
 						},
 					},
 					{
-						Title:  "Teams",
+						Title:  i18n.T("admin.tab.teams"),
 						Layout: VBox{},
 						Children: []Widget{
 							GroupBox{
-								Title:  "Teamdaten",
+								Title:  i18n.T("admin.group.team_data"),
 								Layout: Grid{Columns: 2},
 								Children: []Widget{
-									Label{Text: "Teamname:"},
+									Label{Text: i18n.T("admin.label.team_name")},
 									LineEdit{AssignTo: &teamNameEdit},
 
-									Label{Text: "Sportart:"},
+									Label{Text: i18n.T("admin.label.short_name")},
+									LineEdit{AssignTo: &shortEdit, ToolTipText: i18n.T("admin.tip.short_name")},
+
+									Label{Text: i18n.T("admin.label.abbreviation")},
+									LineEdit{AssignTo: &abbrEdit, MaxLength: models.AbbreviationLength, CaseMode: CaseModeUpper},
+
+									Label{Text: i18n.T("admin.label.alt_names")},
+									LineEdit{AssignTo: &altNamesEdit, ToolTipText: i18n.T("admin.tip.alt_names")},
+
+									Label{Text: i18n.T("admin.label.sport")},
 									ComboBox{
 										AssignTo: &sportCombo,
 										Model:    sportsModel,
 										Editable: false,
 									},
 
-									Label{Text: "Logo:"},
+									Label{Text: i18n.T("admin.label.logo")},
 									PushButton{
-										Text:  "Logo wählen...",
+										Text:  i18n.T("admin.button.choose_logo"),
 										Image: iconEdit,
 										OnClicked: func() {
 											chooseLogo()
 										},
 									},
 
-									Label{Text: "Vorschau:"},
+									Label{Text: i18n.T("admin.label.preview")},
 									ImageView{
 										AssignTo: &logoPreview,
 										MinSize:  Size{Width: 70, Height: 70},
 										MaxSize:  Size{Width: 70, Height: 70},
 									},
 
+									Label{Text: i18n.T("admin.label.team_colors")},
+									Composite{
+										Layout: HBox{MarginsZero: true},
+										Children: []Widget{
+											PushButton{
+												Text: i18n.T("admin.button.primary_color"),
+												OnClicked: func() {
+													if color, ok := pickColor(nil); ok {
+														setTeamColors(colorToHex(color), teamSecondaryColor)
+													}
+												},
+											},
+											Composite{
+												AssignTo: &teamPrimaryPreview,
+												MinSize:  Size{Width: 20, Height: 20},
+												MaxSize:  Size{Width: 20, Height: 20},
+												Border:   true,
+												Layout:   VBox{},
+											},
+											PushButton{
+												Text: i18n.T("admin.button.secondary_color"),
+												OnClicked: func() {
+													if color, ok := pickColor(nil); ok {
+														setTeamColors(teamPrimaryColor, colorToHex(color))
+													}
+												},
+											},
+											Composite{
+												AssignTo: &teamSecondaryPreview,
+												MinSize:  Size{Width: 20, Height: 20},
+												MaxSize:  Size{Width: 20, Height: 20},
+												Border:   true,
+												Layout:   VBox{},
+											},
+											PushButton{
+												Text: i18n.T("admin.button.from_logo"),
+												OnClicked: func() {
+													if currentLogoImage == nil {
+														walk.MsgBox(nil, i18n.T("admin.title.notice"), i18n.T("admin.msg.no_logo"), walk.MsgBoxIconInformation)
+														return
+													}
+													setTeamColors(logo.Colors(currentLogoImage))
+												},
+											},
+											HSpacer{},
+										},
+									},
+
 									HSpacer{},
 									PushButton{
-										Text:  "Team speichern",
+										Text:  i18n.T("admin.button.save_team"),
 										Image: iconSave,
 										OnClicked: func() {
 											saveTeam()
@@ -768,10 +1020,10 @@ func main() {
 							Composite{
 								Layout: HBox{},
 								Children: []Widget{
-									Label{Text: "Sportart filtern:"},
+									Label{Text: i18n.T("admin.label.sport_filter")},
 									ComboBox{
 										AssignTo: &sportFilterCombo,
-										Model:    []string{"Alle", "American Football", "Fußball"},
+										Model:    []string{i18n.T("admin.all"), "American Football", "Fußball"},
 										OnCurrentIndexChanged: func() {
 											if teamModel != nil {
 												teamModel.ApplyFilter(sportFilterCombo.Text())
@@ -780,7 +1032,7 @@ func main() {
 									},
 									HSpacer{},
 									PushButton{
-										Text:  "Löschen",
+										Text:  i18n.T("admin.button.delete"),
 										Image: iconDelete,
 										OnClicked: func() {
 											deleteSelectedTeam()
@@ -791,9 +1043,9 @@ func main() {
 							TableView{
 								AssignTo: &teamTable,
 								Columns: []TableViewColumn{
-									{Title: "ID", Width: 0, Hidden: true}, // Versteckt
-									{Title: "Teamname", Width: 150},
-									{Title: "Sportart", Width: 100},
+									{Title: i18n.T("admin.col.id"), Width: 0, Hidden: true}, // Versteckt
+									{Title: i18n.T("admin.col.team_name"), Width: 150},
+									{Title: i18n.T("admin.col.sport"), Width: 100},
 								},
 								Model:            teamModel,
 								CheckBoxes:       false,
@@ -808,23 +1060,23 @@ func main() {
 						},
 					},
 					{
-						Title:  "Spiele",
+						Title:  i18n.T("admin.tab.matches"),
 						Layout: VBox{},
 						Children: []Widget{
 							GroupBox{
-								Title:  "Spieldaten",
+								Title:  i18n.T("admin.group.match_data"),
 								Layout: Grid{Columns: 2},
 								Children: []Widget{
-									Label{Text: "Heim Team:"},
+									Label{Text: i18n.T("admin.label.home")},
 									ComboBox{
 										AssignTo: &heimCombo,
 										Model:    teamListModel,
 										Editable: false,
 									},
-									Label{Text: "Sportart filtern:"},
+									Label{Text: i18n.T("admin.label.sport_filter")},
 									ComboBox{
 										AssignTo: &matchSportFilterCombo,
-										Model:    []string{"Alle", "American Football", "Fußball"},
+										Model:    []string{i18n.T("admin.all"), "American Football", "Fußball"},
 										OnCurrentIndexChanged: func() {
 											if matchModel != nil {
 												matchModel.ApplyFilter(matchSportFilterCombo.Text())
@@ -832,19 +1084,50 @@ func main() {
 										},
 									},
 
-									Label{Text: "Gast Team:"},
+									Label{Text: i18n.T("admin.label.away")},
 									ComboBox{
 										AssignTo: &gastCombo,
 										Model:    teamListModel,
 										Editable: false,
 									},
+									Label{Text: i18n.T("admin.label.competition")},
+									LineEdit{AssignTo: &competitionEdit},
+									Label{Text: i18n.T("admin.label.field")},
+									ComboBox{
+										AssignTo: &matchFieldCombo,
+										Editable: false,
+									},
 									PushButton{
-										Text:  "Spiel speichern",
+										Text:  i18n.T("admin.button.save_match"),
 										Image: iconSave,
 										OnClicked: func() {
 											saveMatch()
 										},
 									},
+									HSpacer{},
+									Label{Text: i18n.T("admin.label.score_home")},
+									NumberEdit{AssignTo: &scoreHomeEdit, Decimals: 0},
+									Label{Text: i18n.T("admin.label.score_away")},
+									NumberEdit{AssignTo: &scoreAwayEdit, Decimals: 0},
+									HSpacer{},
+									PushButton{
+										Text: i18n.T("admin.button.save_result"),
+										OnClicked: func() {
+											saveMatchResult()
+										},
+									},
+									Label{Text: i18n.T("admin.label.status")},
+									ComboBox{
+										AssignTo: &matchStatusCombo,
+										Model:    models.MatchStatuses(),
+										Editable: false,
+									},
+									PushButton{
+										Text: i18n.T("admin.button.set_status"),
+										OnClicked: func() {
+											setSelectedMatchStatus()
+										},
+									},
 								},
 							},
 
@@ -854,7 +1137,7 @@ func main() {
 								Children: []Widget{
 									HSpacer{},
 									PushButton{
-										Text:  "Löschen",
+										Text:  i18n.T("admin.button.delete"),
 										Image: iconDelete,
 										OnClicked: func() {
 											deleteSelectedMatch()
@@ -865,11 +1148,13 @@ func main() {
 							TableView{
 								AssignTo: &matchTable,
 								Columns: []TableViewColumn{
-									{Title: "ID", Width: 0, Hidden: true}, // Versteckt
-									{Title: "Heim Team", Width: 150},
-									{Title: "Sportart", Width: 100},
-									{Title: "Gast Team", Width: 150},
-									{Title: "Datum Uhrzeit", Width: 150},
+									{Title: i18n.T("admin.col.id"), Width: 0, Hidden: true}, // Versteckt
+									{Title: i18n.T("admin.col.home"), Width: 150},
+									{Title: i18n.T("admin.col.sport"), Width: 100},
+									{Title: i18n.T("admin.col.away"), Width: 150},
+									{Title: i18n.T("admin.col.datetime"), Width: 150},
+									{Title: i18n.T("admin.col.status"), Width: 80},
+									{Title: i18n.T("admin.col.result"), Width: 70},
 								},
 								Model:            matchModel,
 								CheckBoxes:       false,
@@ -884,10 +1169,207 @@ func main() {
 						},
 					},
 					{
-						Title:  "Livespiel",
+						Title:  i18n.T("admin.tab.tournament"),
 						Layout: VBox{},
 						Children: []Widget{
-							Label{Text: "Hier wird das aktuelle Spiel gesteuert."},
+							GroupBox{
+								Title:  i18n.T("admin.group.tournament_create"),
+								Layout: Grid{Columns: 4},
+								Children: []Widget{
+									Label{Text: i18n.T("admin.label.competition")},
+									LineEdit{AssignTo: &tournamentCompetitionEdit},
+									Label{Text: i18n.T("admin.label.mode")},
+									ComboBox{
+										AssignTo:     &tournamentModeCombo,
+										Model:        []string{i18n.T("admin.mode.round_robin"), i18n.T("admin.mode.knockout"), i18n.T("admin.mode.double_knockout")},
+										CurrentIndex: 0,
+										Editable:     false,
+									},
+									Label{Text: i18n.T("admin.label.start")},
+									DateEdit{
+										AssignTo: &tournamentStartEdit,
+										Format:   "dd.MM.yyyy HH:mm",
+									},
+									Label{Text: i18n.T("admin.label.groups")},
+									NumberEdit{
+										AssignTo: &tournamentGroupsEdit,
+										Value:    float64(1),
+										MinValue: float64(1),
+										MaxValue: float64(8),
+										Decimals: 0,
+									},
+									Label{Text: i18n.T("admin.label.slot")},
+									NumberEdit{
+										AssignTo: &tournamentSlotEdit,
+										Value:    float64(25),
+										MinValue: float64(5),
+										MaxValue: float64(240),
+										Decimals: 0,
+									},
+									Label{Text: i18n.T("admin.label.fields")},
+									NumberEdit{
+										AssignTo: &tournamentFieldsEdit,
+										Value:    float64(2),
+										MinValue: float64(1),
+										MaxValue: float64(16),
+										Decimals: 0,
+									},
+									Label{Text: i18n.T("admin.label.venue")},
+									ComboBox{
+										AssignTo: &tournamentVenueCombo,
+										Editable: false,
+									},
+								},
+							},
+							Label{Text: i18n.T("admin.label.seeding")},
+							ListBox{
+								AssignTo:       &tournamentTeamList,
+								Model:          teamListModel,
+								MultiSelection: true,
+							},
+							Composite{
+								Layout: HBox{},
+								Children: []Widget{
+									HSpacer{},
+									PushButton{
+										Text:  i18n.T("admin.button.create_plan"),
+										Image: iconSave,
+										OnClicked: func() {
+											generateTournament()
+										},
+									},
+								},
+							},
+						},
+					},
+					{
+						Title:  i18n.T("admin.tab.venues"),
+						Layout: VBox{},
+						Children: []Widget{
+							GroupBox{
+								Title:  i18n.T("admin.group.venue"),
+								Layout: Grid{Columns: 4},
+								Children: []Widget{
+									Label{Text: i18n.T("admin.label.name")},
+									LineEdit{AssignTo: &venueNameEdit},
+									Label{Text: i18n.T("admin.label.address")},
+									LineEdit{AssignTo: &venueAddressEdit},
+									PushButton{
+										Text:  i18n.T("admin.button.save_venue"),
+										Image: iconSave,
+										OnClicked: func() {
+											saveVenue()
+										},
+									},
+									PushButton{
+										Text:  i18n.T("admin.button.delete_venue"),
+										Image: iconDelete,
+										OnClicked: func() {
+											deleteSelectedVenue()
+										},
+									},
+								},
+							},
+							TableView{
+								AssignTo: &venueTable,
+								Columns: []TableViewColumn{
+									{Title: i18n.T("admin.col.venue"), Width: 150},
+									{Title: i18n.T("admin.col.address"), Width: 200},
+									{Title: i18n.T("admin.col.fields"), Width: 60},
+								},
+								Model:            venueModel,
+								AlternatingRowBG: true,
+								OnCurrentIndexChanged: func() {
+									loadVenue(venueModel.GetVenue(venueTable.CurrentIndex()))
+								},
+							},
+							GroupBox{
+								Title:  i18n.T("admin.group.venue_fields"),
+								Layout: Grid{Columns: 4},
+								Children: []Widget{
+									Label{Text: i18n.T("admin.label.field_name")},
+									LineEdit{AssignTo: &fieldNameEdit},
+									Label{Text: i18n.T("admin.label.default_template")},
+									ComboBox{
+										AssignTo: &fieldTemplateCombo,
+										Editable: false,
+									},
+									PushButton{
+										Text:  i18n.T("admin.button.save_field"),
+										Image: iconSave,
+										OnClicked: func() {
+											saveField()
+										},
+									},
+									PushButton{
+										Text:  i18n.T("admin.button.delete_field"),
+										Image: iconDelete,
+										OnClicked: func() {
+											deleteSelectedField()
+										},
+									},
+								},
+							},
+							TableView{
+								AssignTo: &fieldTable,
+								Columns: []TableViewColumn{
+									{Title: i18n.T("admin.col.field"), Width: 150},
+									{Title: i18n.T("admin.col.template"), Width: 150},
+								},
+								Model:            fieldModel,
+								AlternatingRowBG: true,
+								OnCurrentIndexChanged: func() {
+									loadField(fieldModel.GetField(fieldTable.CurrentIndex()))
+								},
+							},
+						},
+					},
+					{
+						Title:  i18n.T("admin.tab.standings"),
+						Layout: VBox{},
+						Children: []Widget{
+							Composite{
+								Layout: HBox{},
+								Children: []Widget{
+									Label{Text: i18n.T("admin.label.competition")},
+									ComboBox{
+										AssignTo: &standingsCompetitionCombo,
+										Editable: false,
+									},
+									Label{Text: i18n.T("admin.label.sport")},
+									ComboBox{
+										AssignTo: &standingsSportCombo,
+										Model:    sportsModel,
+										Editable: false,
+									},
+									PushButton{
+										Text: i18n.T("admin.button.calculate"),
+										OnClicked: func() {
+											reloadStandings()
+										},
+									},
+									HSpacer{},
+									PushButton{
+										Text: i18n.T("admin.button.fullscreen"),
+										OnClicked: func() {
+											openStandingsWindow()
+										},
+									},
+								},
+							},
+							TableView{
+								AssignTo:         &standingsTable,
+								Columns:          standingsColumns(),
+								Model:            standingsModel,
+								AlternatingRowBG: true,
+							},
+						},
+					},
+					{
+						Title:  i18n.T("admin.tab.live"),
+						Layout: VBox{},
+						Children: []Widget{
+							Label{Text: i18n.T("admin.live_hint")},
 						},
 					},
 				},
@@ -901,6 +1383,8 @@ func main() {
 	mw.Show()
 	reloadTeams()
 	reloadTemplates()
+	reloadCompetitions()
+	reloadVenues()
 
 	sportSelect.SetCurrentIndex(0)
 	sportCombo.SetCurrentIndex(0)
@@ -919,14 +1403,49 @@ func checkPassword() {
 
 func loadTeam(team *models.Team) {
 	teamNameEdit.SetText(team.Name)
+	shortEdit.SetText(team.ShortName)
+	abbrEdit.SetText(team.Abbreviation)
+	altNamesEdit.SetText(models.FormatAltNames(team.AltNames))
 	sportCombo.SetText(team.Sportart)
-	currentLogoData = team.LogoData
-	setLogoFromData(team.LogoData)
+	currentLogoData, currentLogoOriginal = nil, nil
+	currentLogoHash, currentLogoOriginalHash = team.LogoHash, team.LogoOriginalHash
+	setLogoImage(logo.Image(team.LogoHash))
+	setTeamColors(team.PrimaryColor, team.SecondaryColor)
+}
+
+// setTeamColors übernimmt die Teamfarben ins Formular
+func setTeamColors(primary, secondary string) {
+	teamPrimaryColor, teamSecondaryColor = primary, secondary
+	for _, p := range []struct {
+		preview *walk.Composite
+		hex     string
+	}{{teamPrimaryPreview, primary}, {teamSecondaryPreview, secondary}} {
+		if p.preview == nil {
+			continue
+		}
+		var brush walk.Brush
+		if color, err := parseHexColor(p.hex); p.hex != "" && err == nil {
+			brush, _ = walk.NewSolidColorBrush(color)
+		}
+		p.preview.SetBackground(brush)
+	}
 }
 
 func loadMatch(team *models.Match) {
 	//teamNameEdit.SetText(team.Name)
 	sportCombo.SetText(team.Sportart)
+	competitionEdit.SetText(team.Competition)
+	matchStatusCombo.SetText(team.Status)
+	matchFieldCombo.SetCurrentIndex(0)
+	for i, f := range allFields {
+		if f.ID == team.FieldID {
+			matchFieldCombo.SetCurrentIndex(i + 1)
+		}
+	}
+	if team.HasResult() {
+		scoreHomeEdit.SetValue(float64(*team.ScoreHome))
+		scoreAwayEdit.SetValue(float64(*team.ScoreAway))
+	}
 	//currentLogoData = team.LogoData
 	//setLogoFromData(team.LogoData)
 }
@@ -934,7 +1453,7 @@ func loadMatch(team *models.Match) {
 func deleteSelectedTeam() {
 	index := teamTable.CurrentIndex()
 	if index < 0 || index >= len(teamModel.Filtered) {
-		walk.MsgBox(nil, "Hinweis", "Bitte ein Team auswählen.", walk.MsgBoxIconInformation)
+		walk.MsgBox(nil, i18n.T("admin.title.notice"), i18n.T("admin.msg.select_team"), walk.MsgBoxIconInformation)
 		return
 	}
 
@@ -948,37 +1467,37 @@ func deleteSelectedTeam() {
 	case "Fußball":
 		expectedPassword = "Alzstadion"
 	default:
-		walk.MsgBox(nil, "Fehler", "Unbekannte Sportart, kann nicht gelöscht werden.", walk.MsgBoxIconError)
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.unknown_sport_delete"), walk.MsgBoxIconError)
 		return
 	}
 
 	// Passwortabfrage
-	input, ok := askPassword("Bitte Passwort eingeben:")
+	input, ok := askPassword(i18n.T("admin.prompt.password"))
 	if !ok {
 		return // Abgebrochen
 	}
 
 	if trim(input) != expectedPassword {
-		walk.MsgBox(nil, "Fehler", "Falsches Passwort ("+input+") für Sportart "+team.Sportart+". Team wird nicht gelöscht.", walk.MsgBoxIconError)
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.wrong_password_team", input, team.Sportart), walk.MsgBoxIconError)
 		return
 	}
 
 	// Wirklich löschen
 	if err := database.DeleteTeam(team.ID); err != nil {
-		walk.MsgBox(nil, "Fehler", "Fehler beim Löschen des Teams: "+err.Error(), walk.MsgBoxIconError)
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.team_delete_failed", err), walk.MsgBoxIconError)
 		return
 	}
 
 	// Neu laden
 	reloadTeams()
 
-	walk.MsgBox(nil, "Erfolg", "Team wurde erfolgreich gelöscht.", walk.MsgBoxIconInformation)
+	walk.MsgBox(nil, i18n.T("admin.title.success"), i18n.T("admin.msg.team_deleted"), walk.MsgBoxIconInformation)
 }
 
 func deleteSelectedMatch() {
 	index := matchTable.CurrentIndex()
 	if index < 0 || index >= len(matchModel.Filtered) {
-		walk.MsgBox(nil, "Hinweis", "Bitte ein Team auswählen.", walk.MsgBoxIconInformation)
+		walk.MsgBox(nil, i18n.T("admin.title.notice"), i18n.T("admin.msg.select_match"), walk.MsgBoxIconInformation)
 		return
 	}
 
@@ -992,31 +1511,31 @@ func deleteSelectedMatch() {
 	case "Fußball":
 		expectedPassword = "Alzstadion"
 	default:
-		walk.MsgBox(nil, "Fehler", "Unbekannte Sportart, kann nicht gelöscht werden.", walk.MsgBoxIconError)
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.unknown_sport_delete"), walk.MsgBoxIconError)
 		return
 	}
 
 	// Passwortabfrage
-	input, ok := askPassword("Bitte Passwort eingeben:")
+	input, ok := askPassword(i18n.T("admin.prompt.password"))
 	if !ok {
 		return // Abgebrochen
 	}
 
 	if trim(input) != expectedPassword {
-		walk.MsgBox(nil, "Fehler", "Falsches Passwort ("+input+") für Sportart "+team.Sportart+". Team wird nicht gelöscht.", walk.MsgBoxIconError)
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.wrong_password_match", input, team.Sportart), walk.MsgBoxIconError)
 		return
 	}
 
 	// Wirklich löschen
 	if err := database.DeleteMatch(team.ID); err != nil {
-		walk.MsgBox(nil, "Fehler", "Fehler beim Löschen des Spiels: "+err.Error(), walk.MsgBoxIconError)
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.match_delete_failed", err), walk.MsgBoxIconError)
 		return
 	}
 
 	// Neu laden
 	reloadMatches()
 
-	walk.MsgBox(nil, "Erfolg", "Team wurde erfolgreich gelöscht.", walk.MsgBoxIconInformation)
+	walk.MsgBox(nil, i18n.T("admin.title.success"), i18n.T("admin.msg.match_deleted"), walk.MsgBoxIconInformation)
 }
 
 func trim(s string) string {
@@ -1026,7 +1545,7 @@ func trim(s string) string {
 func reloadTeams() {
 	teams, err := database.LoadTeams()
 	if err != nil {
-		walk.MsgBox(nil, "Fehler", "Konnte Teams nicht laden: "+err.Error(), walk.MsgBoxIconError)
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.load_teams_failed", err), walk.MsgBoxIconError)
 		return
 	}
 	teamModel.Teams = teams
@@ -1037,7 +1556,7 @@ func reloadTeams() {
 func reloadMatches() {
 	matches, err := database.LoadMatches()
 	if err != nil {
-		walk.MsgBox(nil, "Fehler", "Konnte Spiele nicht laden: "+err.Error(), walk.MsgBoxIconError)
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.load_matches_failed", err), walk.MsgBoxIconError)
 		return
 	}
 	matchModel.Matches = matches
@@ -1046,13 +1565,27 @@ func reloadMatches() {
 }
 
 func askPassword(prompt string) (string, bool) {
+	return askText(i18n.T("admin.title.enter_password"), prompt, true)
+}
+
+// askReason fragt die Begründung für ein protokolliertes Überschreiben ab
+func askReason(prompt string) (string, bool) {
+	reason, ok := askText(i18n.T("admin.title.enter_reason"), prompt, false)
+	if ok && reason == "" {
+		walk.MsgBox(nil, i18n.T("admin.title.notice"), i18n.T("admin.msg.reason_required"), walk.MsgBoxIconInformation)
+		return "", false
+	}
+	return reason, ok
+}
+
+func askText(title, prompt string, password bool) (string, bool) {
 	var input string
 	var in *walk.LineEdit
 	var dlg *walk.Dialog
 
 	err := Dialog{
 		AssignTo: &dlg,
-		Title:    "Passwort eingeben",
+		Title:    title,
 		MinSize:  Size{Width: 300, Height: 120},
 		Layout:   VBox{},
 		Children: []Widget{
@@ -1061,21 +1594,21 @@ func askPassword(prompt string) (string, bool) {
 			},
 			LineEdit{
 				AssignTo:     &in,
-				PasswordMode: true,
+				PasswordMode: password,
 			},
 			Composite{
 				Layout: HBox{},
 				Children: []Widget{
 					HSpacer{},
 					PushButton{
-						Text: "OK",
+						Text: i18n.T("admin.button.ok"),
 						OnClicked: func() {
 							input = strings.TrimSpace(in.Text()) // Text sofort sichern
 							dlg.Accept()
 						},
 					},
 					PushButton{
-						Text: "Abbrechen",
+						Text: i18n.T("admin.button.cancel"),
 						OnClicked: func() {
 							dlg.Cancel()
 						},
@@ -1097,38 +1630,29 @@ func askPassword(prompt string) (string, bool) {
 
 func chooseLogo() {
 	dlg := new(walk.FileDialog)
-	dlg.Filter = "PNG Bilder (*.png)|*.png"
+	patterns := "*" + strings.Join(logo.Extensions(), ";*")
+	dlg.Filter = i18n.T("admin.images", patterns) + "|" + patterns
 
 	if ok, _ := dlg.ShowOpen(nil); ok {
 		// Datei öffnen
 		data, err := os.ReadFile(dlg.FilePath)
 		if err != nil {
-			walk.MsgBox(nil, "Fehler", "Bild konnte nicht geladen werden.", walk.MsgBoxIconError)
+			walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.image_failed"), walk.MsgBoxIconError)
 			return
 		}
 
-		// PNG dekodieren
-		src, err := png.Decode(bytes.NewReader(data))
-		if err != nil {
-			walk.MsgBox(nil, "Fehler", "Bild ist kein gültiges PNG.", walk.MsgBoxIconError)
-			return
-		}
-
-		// Neues 70x70 NRGBA-Bild
-		dst := image.NewNRGBA(image.Rect(0, 0, 70, 70))
-		draw.CatmullRom.Scale(dst, dst.Bounds(), src, src.Bounds(), draw.Over, nil)
-
-		// Bitmap für die Vorschau
-		bmp, err := walk.NewBitmapFromImage(dst)
+		// prüfen, Ränder kürzen und auf Standardgröße bringen
+		l, err := logo.Ingest(data)
 		if err != nil {
-			walk.MsgBox(nil, "Fehler", "Vorschau konnte nicht erstellt werden.", walk.MsgBoxIconError)
+			walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.logo_rejected", err), walk.MsgBoxIconError)
 			return
 		}
 
-		logoPreview.SetImage(bmp)
-
-		// LogoDaten zwischenspeichern
-		currentLogoData = data // <-- neue Variable, siehe unten
+		// LogoDaten zwischenspeichern, Teamfarben aus dem neuen Logo vorschlagen
+		currentLogoData = l.Normalized
+		currentLogoOriginal = l.Original
+		setLogoImage(render.DecodeLogo(l.Normalized))
+		setTeamColors(l.PrimaryColor, l.SecondaryColor)
 	}
 }
 
@@ -1143,20 +1667,34 @@ func saveTeam() {
 		id = int64(teamModel.Filtered[index].ID)
 	}
 
+	altNames, err := models.ParseAltNames(altNamesEdit.Text())
+	if err != nil {
+		walk.MsgBox(nil, i18n.T("admin.title.error"), err.Error(), walk.MsgBoxIconError)
+		return
+	}
+
 	team := &models.Team{
-		ID:       int(id), // falls 0 → wird Insert, sonst Update
-		Name:     teamNameEdit.Text(),
-		Sportart: sportCombo.Text(),
-		LogoData: logoData,
+		ID:               int(id), // falls 0 → wird Insert, sonst Update
+		Name:             teamNameEdit.Text(),
+		ShortName:        shortEdit.Text(),
+		Abbreviation:     abbrEdit.Text(),
+		AltNames:         altNames,
+		Sportart:         sportCombo.Text(),
+		LogoData:         logoData,
+		LogoOriginal:     currentLogoOriginal,
+		LogoHash:         currentLogoHash,
+		LogoOriginalHash: currentLogoOriginalHash,
+		PrimaryColor:     teamPrimaryColor,
+		SecondaryColor:   teamSecondaryColor,
 	}
 
 	if err := database.SaveTeam(team); err != nil {
-		walk.MsgBox(nil, "Fehler", "Team konnte nicht gespeichert werden:\n"+err.Error(), walk.MsgBoxIconError)
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.team_save_failed", err), walk.MsgBoxIconError)
 		log.Printf("Fehler beim Speichern: %+v", err)
 		return
 	}
 
-	walk.MsgBox(nil, "Erfolg", "Team erfolgreich gespeichert.", walk.MsgBoxIconInformation)
+	walk.MsgBox(nil, i18n.T("admin.title.success"), i18n.T("admin.msg.team_saved"), walk.MsgBoxIconInformation)
 
 	// Formular zurücksetzen
 	resetForm()
@@ -1170,56 +1708,360 @@ func saveMatch() {
 		logoData = []byte{}
 	}
 
-	var id int64
+	// Bestehende Spiele als Kopie bearbeiten, damit Turnierdaten erhalten bleiben
+	match := &models.Match{}
 	if index := matchTable.CurrentIndex(); index >= 0 && index < len(matchModel.Filtered) {
-		id = int64(matchModel.Filtered[index].ID)
+		*match = *matchModel.Filtered[index]
+	}
+
+	heim, gast := heimCombo.CurrentIndex(), gastCombo.CurrentIndex()
+	if heim < 0 || heim >= len(matchTeams) || gast < 0 || gast >= len(matchTeams) {
+		walk.MsgBox(nil, i18n.T("admin.title.notice"), i18n.T("admin.msg.select_home_away"), walk.MsgBoxIconInformation)
+		return
 	}
 
-	match := &models.Match{
-		ID:       int(id), // falls 0 → wird Insert, sonst Update
-		Sportart: sportCombo.Text(),
+	template := templateForSport(sportCombo.Text())
+	if template == nil {
+		walk.MsgBox(nil, i18n.T("admin.title.notice"), i18n.T("admin.msg.no_template_for_sport"), walk.MsgBoxIconInformation)
+		return
 	}
 
-	if err := database.SaveMatches(match); err != nil {
-		walk.MsgBox(nil, "Fehler", "Match konnte nicht gespeichert werden:\n"+err.Error(), walk.MsgBoxIconError)
+	// ID 0 → wird Insert, sonst Update
+	match.Sportart = sportCombo.Text()
+	match.Team1 = matchTeams[heim]
+	match.Team2 = matchTeams[gast]
+	match.TemplateSettings = template
+	match.Competition = strings.TrimSpace(competitionEdit.Text())
+	match.FieldID, match.Field = 0, ""
+	if i := matchFieldCombo.CurrentIndex(); i > 0 && i <= len(allFields) {
+		match.FieldID = allFields[i-1].ID
+		match.Field = allFields[i-1].Name
+	}
+
+	err := database.SaveMatches(match)
+	if errors.Is(err, database.ErrMatchFinished) {
+		reason, ok := askReason(i18n.T("admin.prompt.reason_change"))
+		if !ok {
+			return
+		}
+		match.Status = models.MatchFinished
+		err = database.SaveMatchesOverride(match, reason, "admin")
+	}
+	if err != nil {
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.match_save_failed", err), walk.MsgBoxIconError)
 		log.Printf("Fehler beim Speichern: %+v", err)
 		return
 	}
 
-	walk.MsgBox(nil, "Erfolg", "Match erfolgreich gespeichert.", walk.MsgBoxIconInformation)
+	walk.MsgBox(nil, i18n.T("admin.title.success"), i18n.T("admin.msg.match_saved"), walk.MsgBoxIconInformation)
 
 	// Formular zurücksetzen
 	resetMatchForm()
 	matchTable.SetCurrentIndex(-1)
 	reloadMatches()
+	reloadCompetitions()
+}
+
+// saveMatchResult speichert den Endstand des gewählten Spiels; bei
+// Turnierspielen ziehen Sieger und Verlierer automatisch weiter
+func saveMatchResult() {
+	index := matchTable.CurrentIndex()
+	if index < 0 || index >= len(matchModel.Filtered) {
+		walk.MsgBox(nil, i18n.T("admin.title.notice"), i18n.T("admin.msg.select_match"), walk.MsgBoxIconInformation)
+		return
+	}
+	match := matchModel.Filtered[index]
+
+	home, away := int(scoreHomeEdit.Value()), int(scoreAwayEdit.Value())
+
+	var err error
+	if match.Status == models.MatchFinished {
+		reason, ok := askReason(i18n.T("admin.prompt.reason_correct"))
+		if !ok {
+			return
+		}
+		err = tournament.CorrectResult(match, home, away, reason, "admin")
+	} else {
+		err = tournament.FinalizeMatch(match, home, away)
+	}
+	if err != nil {
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.result_save_failed", err), walk.MsgBoxIconError)
+		return
+	}
+
+	reloadMatches()
+	walk.MsgBox(nil, i18n.T("admin.title.success"), i18n.T("admin.msg.result_saved"), walk.MsgBoxIconInformation)
+}
+
+func setSelectedMatchStatus() {
+	index := matchTable.CurrentIndex()
+	if index < 0 || index >= len(matchModel.Filtered) {
+		walk.MsgBox(nil, i18n.T("admin.title.notice"), i18n.T("admin.msg.select_match"), walk.MsgBoxIconInformation)
+		return
+	}
+
+	match := *matchModel.Filtered[index]
+	if err := tournament.SaveMatch(&match, matchStatusCombo.Text(), "", ""); err != nil {
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.status_failed", err), walk.MsgBoxIconError)
+		return
+	}
+	reloadMatches()
+}
+
+func generateTournament() {
+	var teams []*models.Team
+	for _, i := range tournamentTeamList.SelectedIndexes() {
+		if i >= 0 && i < len(matchTeams) {
+			teams = append(teams, matchTeams[i])
+		}
+	}
+	if len(teams) < 2 {
+		walk.MsgBox(nil, i18n.T("admin.title.notice"), i18n.T("admin.msg.select_two_teams"), walk.MsgBoxIconInformation)
+		return
+	}
+
+	sportart := teams[0].Sportart
+	template := templateForSport(sportart)
+	if template == nil {
+		walk.MsgBox(nil, i18n.T("admin.title.notice"), i18n.T("admin.msg.no_template_for_sport"), walk.MsgBoxIconInformation)
+		return
+	}
+
+	// Felder des gewählten Spielorts, sonst nur nummerierte Felder ohne eigene Anzeige
+	var fields []*models.Field
+	if i := tournamentVenueCombo.CurrentIndex(); i > 0 && i <= len(venues) {
+		fields = venues[i-1].Fields
+	} else {
+		for i := 1; i <= int(tournamentFieldsEdit.Value()); i++ {
+			fields = append(fields, &models.Field{Name: i18n.T("admin.field_n", i)})
+		}
+	}
+	if len(fields) == 0 {
+		walk.MsgBox(nil, i18n.T("admin.title.notice"), i18n.T("admin.msg.venue_without_fields"), walk.MsgBoxIconInformation)
+		return
+	}
+
+	opts := tournament.Options{
+		Competition:  strings.TrimSpace(tournamentCompetitionEdit.Text()),
+		Sportart:     sportart,
+		Template:     template,
+		Start:        tournamentStartEdit.Date(),
+		SlotDuration: time.Duration(tournamentSlotEdit.Value()) * time.Minute,
+		Fields:       fields,
+	}
+
+	var plan *tournament.Plan
+	var err error
+	switch tournamentModeCombo.CurrentIndex() {
+	case 1:
+		plan, err = tournament.SingleElimination(teams, opts)
+	case 2:
+		plan, err = tournament.DoubleElimination(teams, opts)
+	default:
+		plan, err = tournament.RoundRobin(teams, int(tournamentGroupsEdit.Value()), opts)
+	}
+	if err == nil {
+		err = plan.Save()
+	}
+	if err != nil {
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.plan_failed", err), walk.MsgBoxIconError)
+		return
+	}
+
+	reloadMatches()
+	reloadCompetitions()
+	walk.MsgBox(nil, i18n.T("admin.title.success"), i18n.T("admin.msg.plan_created", len(plan.Matches)), walk.MsgBoxIconInformation)
+}
+
+// templateForSport liefert das erste Template der Sportart (oder irgendeines als Fallback)
+func templateForSport(sportart string) *models.TemplateSettings {
+	for _, t := range templateModel.Templates {
+		if t.Sportart == sportart {
+			return t
+		}
+	}
+	if len(templateModel.Templates) > 0 {
+		return templateModel.Templates[0]
+	}
+	return nil
 }
 
 func resetForm() {
 	teamNameEdit.SetText("")
+	shortEdit.SetText("")
+	abbrEdit.SetText("")
+	altNamesEdit.SetText("")
 	sportCombo.SetCurrentIndex(0)
 	logoPreview.SetImage(nil)
 	currentLogoData = nil
+	currentLogoOriginal = nil
+	currentLogoHash, currentLogoOriginalHash = "", ""
+	currentLogoImage = nil
+	setTeamColors("", "")
 }
 
 func resetMatchForm() {
 	sportCombo.SetCurrentIndex(0)
+	competitionEdit.SetText("")
+	matchFieldCombo.SetCurrentIndex(0)
 }
 
-func setLogoFromData(data []byte) {
-	if data == nil || len(data) == 0 {
-		logoPreview.SetImage(nil)
+func reloadVenues() {
+	var err error
+	venues, err = database.LoadVenues()
+	if err != nil {
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.load_venues_failed", err), walk.MsgBoxIconError)
 		return
 	}
 
-	img, err := png.Decode(bytes.NewReader(data))
-	if err != nil {
-		log.Printf("Fehler beim Dekodieren des Logos: %v", err)
+	allFields = nil
+	venueNames := []string{i18n.T("admin.no_venue")}
+	fieldNames := []string{i18n.T("admin.no_field")}
+	for _, v := range venues {
+		venueNames = append(venueNames, v.Name)
+		for _, f := range v.Fields {
+			allFields = append(allFields, f)
+			fieldNames = append(fieldNames, v.Name+" / "+f.Name)
+		}
+	}
+
+	venueModel.Venues = venues
+	venueModel.PublishRowsReset()
+	loadVenue(venueModel.GetVenue(venueTable.CurrentIndex()))
+
+	tournamentVenueCombo.SetModel(venueNames)
+	tournamentVenueCombo.SetCurrentIndex(0)
+	matchFieldCombo.SetModel(fieldNames)
+	matchFieldCombo.SetCurrentIndex(0)
+}
+
+func loadVenue(venue *models.Venue) {
+	fieldModel.Fields = nil
+	if venue != nil {
+		venueNameEdit.SetText(venue.Name)
+		venueAddressEdit.SetText(venue.Address)
+		fieldModel.Fields = venue.Fields
+	}
+	fieldModel.PublishRowsReset()
+}
+
+func loadField(field *models.Field) {
+	if field == nil {
+		return
+	}
+	fieldNameEdit.SetText(field.Name)
+	for i, t := range templateModel.Templates {
+		if t.ID == field.TemplateID {
+			fieldTemplateCombo.SetCurrentIndex(i)
+		}
+	}
+}
+
+func saveVenue() {
+	venue := &models.Venue{}
+	if v := venueModel.GetVenue(venueTable.CurrentIndex()); v != nil {
+		venue.ID = v.ID
+	}
+	venue.Name = strings.TrimSpace(venueNameEdit.Text())
+	venue.Address = strings.TrimSpace(venueAddressEdit.Text())
+	if venue.Name == "" {
+		walk.MsgBox(nil, i18n.T("admin.title.notice"), i18n.T("admin.msg.enter_name"), walk.MsgBoxIconInformation)
+		return
+	}
+
+	if err := database.SaveVenue(venue); err != nil {
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.venue_save_failed", err), walk.MsgBoxIconError)
+		return
+	}
+	venueTable.SetCurrentIndex(-1)
+	venueNameEdit.SetText("")
+	venueAddressEdit.SetText("")
+	reloadVenues()
+}
+
+func deleteSelectedVenue() {
+	venue := venueModel.GetVenue(venueTable.CurrentIndex())
+	if venue == nil {
+		walk.MsgBox(nil, i18n.T("admin.title.notice"), i18n.T("admin.msg.select_venue"), walk.MsgBoxIconInformation)
+		return
+	}
+	if walk.MsgBox(nil, i18n.T("admin.title.delete"), i18n.T("admin.msg.venue_delete_confirm", venue.Name), walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) != walk.DlgCmdYes {
+		return
+	}
+	if err := database.DeleteVenue(venue.ID); err != nil {
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.venue_delete_failed", err), walk.MsgBoxIconError)
+		return
+	}
+	reloadVenues()
+}
+
+func saveField() {
+	venue := venueModel.GetVenue(venueTable.CurrentIndex())
+	if venue == nil {
+		walk.MsgBox(nil, i18n.T("admin.title.notice"), i18n.T("admin.msg.select_venue_first"), walk.MsgBoxIconInformation)
+		return
+	}
+
+	field := &models.Field{VenueID: venue.ID}
+	if f := fieldModel.GetField(fieldTable.CurrentIndex()); f != nil {
+		field.ID = f.ID
+	}
+	field.Name = strings.TrimSpace(fieldNameEdit.Text())
+	if i := fieldTemplateCombo.CurrentIndex(); i >= 0 && i < len(templateModel.Templates) {
+		field.TemplateID = templateModel.Templates[i].ID
+	}
+	if field.Name == "" {
+		walk.MsgBox(nil, i18n.T("admin.title.notice"), i18n.T("admin.msg.enter_field_name"), walk.MsgBoxIconInformation)
+		return
+	}
+
+	if err := database.SaveField(field); err != nil {
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.field_save_failed", err), walk.MsgBoxIconError)
+		return
+	}
+	fieldTable.SetCurrentIndex(-1)
+	fieldNameEdit.SetText("")
+	reloadVenues()
+}
+
+func deleteSelectedField() {
+	field := fieldModel.GetField(fieldTable.CurrentIndex())
+	if field == nil {
+		walk.MsgBox(nil, i18n.T("admin.title.notice"), i18n.T("admin.msg.select_field"), walk.MsgBoxIconInformation)
+		return
+	}
+	if err := database.DeleteField(field.ID); err != nil {
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.field_delete_failed", err), walk.MsgBoxIconError)
+		return
+	}
+	reloadVenues()
+}
+
+func templateName(id int) string {
+	for _, t := range templateModel.Templates {
+		if t.ID == id {
+			return t.Name
+		}
+	}
+	return ""
+}
+
+func setLogoImage(img image.Image) {
+	currentLogoImage = img
+	if img == nil {
 		logoPreview.SetImage(nil)
 		return
 	}
 
+	// zentriert in 70x70, Seitenverhältnis bleibt erhalten
+	b := img.Bounds()
+	w, h := logo.Fit(b.Dx(), b.Dy(), 70)
+	if w < 70 && h < 70 {
+		w, h = logo.Fit(b.Dx()*70, b.Dy()*70, 70) // kleine Logos vergrößern
+	}
 	dst := image.NewNRGBA(image.Rect(0, 0, 70, 70))
-	draw.CatmullRom.Scale(dst, dst.Bounds(), img, img.Bounds(), draw.Over, nil)
+	target := image.Rect((70-w)/2, (70-h)/2, (70-w)/2+w, (70-h)/2+h)
+	draw.CatmullRom.Scale(dst, target, img, b, draw.Over, nil)
 
 	bmp, err := walk.NewBitmapFromImage(dst)
 	if err != nil {
@@ -1236,12 +2078,12 @@ func runDisplayWindow() error {
 
 	err := MainWindow{
 		AssignTo: &mw,
-		Title:    "Anzeige",
+		Title:    i18n.T("admin.display_window"),
 		Bounds:   Rectangle{X: 0, Y: 0, Width: 288, Height: 96},
 		Layout:   VBox{},
 		Children: []Widget{
 			Label{
-				Text: "Anzeige aktiv",
+				Text: i18n.T("admin.display_active"),
 			},
 		},
 	}.Create()
@@ -1285,6 +2127,8 @@ func loadTeams() ([]string, error) {
 		return nil, err
 	}
 
+	matchTeams = teams
+
 	var names []string
 	for _, t := range teams {
 		names = append(names, t.Name)
@@ -1292,6 +2136,88 @@ func loadTeams() ([]string, error) {
 	return names, nil
 }
 
+func standingsColumns() []TableViewColumn {
+	return []TableViewColumn{
+		{Title: i18n.T("admin.col.rank"), Width: 50},
+		{Title: i18n.T("admin.col.team"), Width: 200},
+		{Title: i18n.T("admin.col.played"), Width: 40},
+		{Title: i18n.T("admin.col.won"), Width: 40},
+		{Title: i18n.T("admin.col.drawn"), Width: 40},
+		{Title: i18n.T("admin.col.lost"), Width: 40},
+		{Title: i18n.T("admin.col.goals"), Width: 70},
+		{Title: i18n.T("admin.col.diff"), Width: 50},
+		{Title: i18n.T("admin.col.points"), Width: 60},
+	}
+}
+
+func reloadCompetitions() {
+	competitions, err := database.LoadCompetitions()
+	if err != nil {
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.load_competitions_failed", err), walk.MsgBoxIconError)
+		return
+	}
+	standingsCompetitionCombo.SetModel(competitions)
+	if len(competitions) > 0 {
+		standingsCompetitionCombo.SetCurrentIndex(0)
+	}
+}
+
+func reloadStandings() {
+	competition := standingsCompetitionCombo.Text()
+	if competition == "" {
+		walk.MsgBox(nil, i18n.T("admin.title.notice"), i18n.T("admin.msg.select_competition"), walk.MsgBoxIconInformation)
+		return
+	}
+
+	table, err := standings.Load(competition, standingsSportCombo.Text())
+	if err != nil {
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.standings_failed", err), walk.MsgBoxIconError)
+		return
+	}
+	standingsModel.SetTable(table)
+}
+
+// openStandingsWindow zeigt die Tabelle rahmenlos im Vollbild (z.B. in Spielpausen), ESC schließt
+func openStandingsWindow() {
+	if standingsWindow != nil || standingsModel.Table == nil {
+		return
+	}
+
+	closeOnEscape := func(key walk.Key) {
+		if key == walk.KeyEscape && standingsWindow != nil {
+			standingsWindow.Dispose()
+			standingsWindow = nil
+		}
+	}
+
+	err := MainWindow{
+		AssignTo:  &standingsWindow,
+		Title:     standingsModel.Table.Competition,
+		Layout:    VBox{},
+		OnKeyDown: closeOnEscape,
+		Children: []Widget{
+			Label{
+				Text:      standingsModel.Table.Competition,
+				Font:      Font{Family: cfg.Fonts.Family, PointSize: cfg.Fonts.ScoreSize},
+				Alignment: AlignHCenterVCenter,
+			},
+			TableView{
+				Columns:   standingsColumns(),
+				Model:     standingsModel,
+				Font:      Font{PointSize: cfg.Fonts.PeriodSize},
+				OnKeyDown: closeOnEscape,
+			},
+		},
+	}.Create()
+	if err != nil {
+		log.Printf("Fehler bei openStandingsWindow: %v", err)
+		return
+	}
+
+	standingsWindow.SetFullscreen(true)
+	standingsWindow.Show()
+}
+
 func showTemplateForm(t *models.TemplateSettings) {
 	templateForm.SetVisible(true)
 	templateActionButtons.SetVisible(false)
@@ -1308,7 +2234,7 @@ func showTemplateForm(t *models.TemplateSettings) {
 		periodLabelEdit.SetText(t.PeriodLabel)
 		periodsCountEdit.SetValue(float64(t.PeriodsCount))
 		periodDurationEdit.SetValue(float64(t.PeriodDuration))
-		gameclockModeCombo.SetText(t.GameclockMode)
+		gameclockModeCombo.SetCurrentIndex(max(slices.Index(gameclockModes, t.GameclockMode), 0))
 		showPeriodCB.SetChecked(t.ShowPeriod)
 		showGameclockCB.SetChecked(t.ShowGameclock)
 		showClockCB.SetChecked(t.ShowClock)
@@ -1343,6 +2269,30 @@ func showTemplateForm(t *models.TemplateSettings) {
 			extraTimeFontColorPreview.SetBackground(brush)
 			extraTimeFontColor = color
 		}
+		if t.SeparatorFontColor != "" {
+			color, _ := parseHexColor(t.SeparatorFontColor)
+			brush, _ := walk.NewSolidColorBrush(color)
+			separatorColorPreview.SetBackground(brush)
+			separatorFontColor = color
+		}
+
+		// Schriften des Templates anbieten, z.B. aus einem importierten Paket
+		families := render.Families(t)
+		for _, f := range []struct {
+			combo  *walk.ComboBox
+			size   *walk.NumberEdit
+			family string
+			px     int
+		}{
+			{clockFontCombo, clockSizeEdit, t.ClockFontFamily, t.ClockFontSize},
+			{periodFontCombo, periodSizeEdit, t.PeriodFontFamily, t.PeriodFontSize},
+			{scoreFontCombo, scoreSizeEdit, t.ScoreFontFamily, t.ScoreFontSize},
+			{separatorFontCombo, separatorSizeEdit, t.SeparatorFontFamily, t.SeparatorFontSize},
+		} {
+			f.combo.SetModel(families)
+			f.combo.SetText(f.family)
+			f.size.SetValue(float64(f.px))
+		}
 
 		currentTemplateID = t.ID
 	} else {
@@ -1366,49 +2316,89 @@ func editSelectedTemplate() {
 
 	expectedPassword := expectedTemplatePassword(t.Sportart)
 	if expectedPassword == "" {
-		walk.MsgBox(nil, "Fehler", "Unbekannte Sportart, kann nicht bearbeitet werden.", walk.MsgBoxIconError)
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.unknown_sport_edit"), walk.MsgBoxIconError)
 		return
 	}
 
-	input, ok := askPassword("Bitte Passwort für Bearbeiten eingeben:")
+	input, ok := askPassword(i18n.T("admin.prompt.password_edit"))
 	if !ok {
 		return
 	}
 
 	if trim(input) != expectedPassword {
-		walk.MsgBox(nil, "Fehler", "Falsches Passwort für Sportart "+t.Sportart+".", walk.MsgBoxIconError)
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.wrong_password", t.Sportart), walk.MsgBoxIconError)
 		return
 	}
 
-	showTemplateForm(t)
+	// abgeleitete Templates mit den geerbten Werten und Theme-Farben zeigen
+	resolved, err := database.ResolveTemplate(t.ID)
+	if err != nil {
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.template_resolve_failed", err), walk.MsgBoxIconError)
+		return
+	}
+	showTemplateForm(resolved)
 }
 
 func saveTemplate() {
-	t := &models.TemplateSettings{
-		ID:             currentTemplateID,
-		Name:           templateNameEdit.Text(),
-		Width:          int(widthEdit.Value()),
-		Height:         int(heightEdit.Value()),
-		X:              int(xEdit.Value()),
-		Y:              int(yEdit.Value()),
-		Sportart:       sportSelect.Text(),
-		PeriodLabel:    periodLabelEdit.Text(),
-		PeriodsCount:   int(periodsCountEdit.Value()),
-		PeriodDuration: int(periodDurationEdit.Value()),
-		GameclockMode:  gameclockModeCombo.Text(),
-		ShowPeriod:     showPeriodCB.Checked(),
-		ShowGameclock:  showGameclockCB.Checked(),
-		ShowClock:      showClockCB.Checked(),
-
-		ClockFontColor:      clockFontColor,
-		ScoreFontColor:      colorToHex(scoreFontColor),
-		PeriodFontColor:     colorToHex(periodFontColor),
-		BackgroundFontColor: colorToHex(backgroundFontColor),
-		ExtraTimeFontColor:  colorToHex(extraTimeFontColor),
+	t := &models.TemplateSettings{}
+	var shown *models.TemplateSettings
+	if currentTemplateID != 0 {
+		// das Formular zeigt den aufgelösten Stand; nicht geänderte Felder
+		// sollen weiter erben bzw. auf ihre Theme-Variable verweisen
+		var err error
+		if shown, err = database.ResolveTemplate(currentTemplateID); err == nil {
+			edited := *shown
+			t = &edited
+		}
+	}
+	t.ID = currentTemplateID
+	t.Name = templateNameEdit.Text()
+	t.Width = int(widthEdit.Value())
+	t.Height = int(heightEdit.Value())
+	t.X = int(xEdit.Value())
+	t.Y = int(yEdit.Value())
+	t.Sportart = sportSelect.Text()
+	t.PeriodLabel = periodLabelEdit.Text()
+	t.PeriodsCount = int(periodsCountEdit.Value())
+	t.PeriodDuration = int(periodDurationEdit.Value())
+	if i := gameclockModeCombo.CurrentIndex(); i >= 0 {
+		t.GameclockMode = gameclockModes[i]
+	}
+	t.ShowPeriod = showPeriodCB.Checked()
+	t.ShowGameclock = showGameclockCB.Checked()
+	t.ShowClock = showClockCB.Checked()
+	t.ClockFontColor = clockFontColor
+	t.ScoreFontColor = colorToHex(scoreFontColor)
+	t.PeriodFontColor = colorToHex(periodFontColor)
+	t.BackgroundFontColor = colorToHex(backgroundFontColor)
+	t.ExtraTimeFontColor = colorToHex(extraTimeFontColor)
+	t.SeparatorFontColor = colorToHex(separatorFontColor)
+	t.ClockFontFamily = strings.TrimSpace(clockFontCombo.Text())
+	t.ClockFontSize = int(clockSizeEdit.Value())
+	t.PeriodFontFamily = strings.TrimSpace(periodFontCombo.Text())
+	t.PeriodFontSize = int(periodSizeEdit.Value())
+	t.ScoreFontFamily = strings.TrimSpace(scoreFontCombo.Text())
+	t.ScoreFontSize = int(scoreSizeEdit.Value())
+	t.SeparatorFontFamily = strings.TrimSpace(separatorFontCombo.Text())
+	t.SeparatorFontSize = int(separatorSizeEdit.Value())
+
+	if err := render.ValidateFonts(t); err != nil {
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.invalid_font", err), walk.MsgBoxIconError)
+		return
+	}
+
+	if shown != nil {
+		raw, err := database.LoadTemplate(currentTemplateID)
+		if err != nil {
+			walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.template_load_failed", err), walk.MsgBoxIconError)
+			return
+		}
+		raw.ApplyEdits(t, shown)
+		t = raw
 	}
 
 	if err := database.SaveTemplate(t); err != nil {
-		walk.MsgBox(nil, "Fehler", "Template konnte nicht gespeichert werden:\n"+err.Error(), walk.MsgBoxIconError)
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.template_save_failed", err), walk.MsgBoxIconError)
 		return
 	}
 
@@ -1433,22 +2423,41 @@ func resetTemplateForm() {
 	showPeriodCB.SetChecked(false)
 	showGameclockCB.SetChecked(false)
 	showClockCB.SetChecked(false)
+
+	// Vorgaben aus der Konfiguration
+	var def models.TemplateSettings
+	cfg.TemplateDefaults(&def)
+	clockFontCombo.SetText(def.ClockFontFamily)
+	clockSizeEdit.SetValue(float64(def.ClockFontSize))
+	periodFontCombo.SetText(def.PeriodFontFamily)
+	periodSizeEdit.SetValue(float64(def.PeriodFontSize))
+	scoreFontCombo.SetText(def.ScoreFontFamily)
+	scoreSizeEdit.SetValue(float64(def.ScoreFontSize))
+	separatorFontCombo.SetText(def.SeparatorFontFamily)
+	separatorSizeEdit.SetValue(float64(def.SeparatorFontSize))
+	separatorFontColor = walk.RGB(0xFF, 0xFF, 0xFF)
 }
 func reloadTemplates() {
 	templates, err := database.LoadTemplates()
 	if err != nil {
-		walk.MsgBox(nil, "Fehler", "Konnte Templates nicht laden: "+err.Error(), walk.MsgBoxIconError)
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.load_templates_failed", err), walk.MsgBoxIconError)
 		return
 	}
 	templateModel.Templates = templates
 	templateModel.PublishRowsReset()
 	templateTable.SetModel(templateModel)
+
+	var names []string
+	for _, t := range templates {
+		names = append(names, t.Name)
+	}
+	fieldTemplateCombo.SetModel(names)
 }
 
 func deleteSelectedTemplate() {
 	index := templateTable.CurrentIndex()
 	if index < 0 || index >= len(templateModel.Templates) {
-		walk.MsgBox(nil, "Hinweis", "Bitte ein Template auswählen.", walk.MsgBoxIconInformation)
+		walk.MsgBox(nil, i18n.T("admin.title.notice"), i18n.T("admin.msg.select_template"), walk.MsgBoxIconInformation)
 		return
 	}
 
@@ -1456,27 +2465,27 @@ func deleteSelectedTemplate() {
 
 	expectedPassword := expectedTemplatePassword(t.Sportart)
 	if expectedPassword == "" {
-		walk.MsgBox(nil, "Fehler", "Unbekannte Sportart, kann nicht gelöscht werden.", walk.MsgBoxIconError)
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.unknown_sport_delete"), walk.MsgBoxIconError)
 		return
 	}
 
-	input, ok := askPassword("Bitte Passwort für Löschen eingeben:")
+	input, ok := askPassword(i18n.T("admin.prompt.password_delete"))
 	if !ok {
 		return
 	}
 
 	if trim(input) != expectedPassword {
-		walk.MsgBox(nil, "Fehler", "Falsches Passwort für Sportart "+t.Sportart+".", walk.MsgBoxIconError)
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.wrong_password", t.Sportart), walk.MsgBoxIconError)
 		return
 	}
 
 	if err := database.DeleteTemplate(t.ID); err != nil {
-		walk.MsgBox(nil, "Fehler", "Template konnte nicht gelöscht werden: "+err.Error(), walk.MsgBoxIconError)
+		walk.MsgBox(nil, i18n.T("admin.title.error"), i18n.T("admin.msg.template_delete_failed", err), walk.MsgBoxIconError)
 		return
 	}
 
 	reloadTemplates()
-	walk.MsgBox(nil, "Erfolg", "Template erfolgreich gelöscht.", walk.MsgBoxIconInformation)
+	walk.MsgBox(nil, i18n.T("admin.title.success"), i18n.T("admin.msg.template_deleted"), walk.MsgBoxIconInformation)
 }
 
 func expectedTemplatePassword(sportart string) string {
@@ -1499,10 +2508,12 @@ func openPreviewWindow() {
 		return
 	}
 	t := templateModel.Templates[index]
+	if resolved, err := database.ResolveTemplate(t.ID); err == nil {
+		t = resolved
+	}
 
 	// Dummy-Logo laden
-	executablePath, _ := os.Executable()
-	dummyPath := filepath.Join(filepath.Dir(executablePath), "icons", "logo.png")
+	dummyPath := filepath.Join(besideExe(cfg.IconsDir), "logo.png")
 
 	dummyImage, err := walk.NewBitmapFromFile(dummyPath)
 	if err != nil {
@@ -1512,7 +2523,7 @@ func openPreviewWindow() {
 
 	err = MainWindow{
 		AssignTo: &previewWindow,
-		Title:    "Scoreboard Vorschau",
+		Title:    i18n.T("admin.preview_window"),
 		Bounds: Rectangle{
 			X:      t.X,
 			Y:      t.Y,
@@ -1526,7 +2537,7 @@ func openPreviewWindow() {
 				Children: []Widget{
 					Label{
 						Text:      "00:00",
-						Font:      Font{Family: defaultFontFamily, PointSize: defaultClockSize},
+						Font:      Font{Family: previewFamily(t.ClockFontFamily), PointSize: pointSize(t.ClockFontSize)},
 						Alignment: AlignHCenterVCenter,
 						TextColor: mustParseColor(t.ClockFontColor),
 					},
@@ -1539,7 +2550,7 @@ func openPreviewWindow() {
 							},
 							Label{
 								Text:      "7 : 3",
-								Font:      Font{Family: defaultFontFamily, PointSize: defaultScoreSize},
+								Font:      Font{Family: previewFamily(t.ScoreFontFamily), PointSize: pointSize(t.ScoreFontSize)},
 								Alignment: AlignHCenterVCenter,
 								TextColor: mustParseColor(t.ScoreFontColor),
 							},
@@ -1551,7 +2562,7 @@ func openPreviewWindow() {
 					},
 					Label{
 						Text:      t.PeriodLabel,
-						Font:      Font{Family: defaultFontFamily, PointSize: defaultPeriodSize},
+						Font:      Font{Family: previewFamily(t.PeriodFontFamily), PointSize: pointSize(t.PeriodFontSize)},
 						Alignment: AlignHCenterVCenter,
 						TextColor: mustParseColor(t.PeriodFontColor),
 					},
@@ -1608,7 +2619,7 @@ func closePreviewWindow() {
 func parseHexColor(s string) (walk.Color, error) {
 	s = strings.TrimPrefix(s, "#")
 	if len(s) != 6 {
-		return 0, fmt.Errorf("ungültiger Farbcode: %s", s)
+		return 0, i18n.Errorf("error.color", s)
 	}
 	var rgb uint64
 	rgb, err := strconv.ParseUint(s, 16, 32)
@@ -1617,6 +2628,50 @@ func parseHexColor(s string) (walk.Color, error) {
 	}
 	return walk.RGB(byte(rgb>>16), byte((rgb>>8)&0xFF), byte(rgb&0xFF)), nil
 }
+
+// previewFamily liefert die erste Familie der Ausweichkette, die das
+// Schriftregister kennt, unter ihrem Windows-Namen (siehe loadFonts); sonst
+// die erste Familie, für die Windows selbst eine Ersatzschrift sucht
+func previewFamily(spec string) string {
+	families := render.SplitFamilies(spec)
+	for _, f := range families {
+		if font, ok := fonts.Lookup(f); ok {
+			return font.TableFamily()
+		}
+	}
+	if len(families) > 0 {
+		return families[0]
+	}
+	return cfg.Fonts.Family
+}
+
+// loadFonts lädt das Schriftregister (Schriftverzeichnis der Konfiguration und
+// Datenbank) und meldet die Schriften nur für diesen Prozess bei Windows an,
+// damit die Vorschau wie Overlay und PNG-Renderer aussieht
+func loadFonts() error {
+	if err := fonts.LoadDir(besideExe(cfg.FontsDir)); err != nil {
+		return err
+	}
+	if err := fonts.LoadDatabase(); err != nil {
+		return err
+	}
+	for _, f := range fonts.All() {
+		var n uint32
+		if win.AddFontMemResourceEx(uintptr(unsafe.Pointer(&f.Data[0])), uint32(len(f.Data)), nil, &n) == 0 {
+			log.Print(i18n.T("admin.log.font_register", f.File))
+		}
+	}
+	return nil
+}
+
+// pointSize rechnet die Pixelgröße des Templates in Punkt um (96 DPI)
+func pointSize(px int) int {
+	if pt := px * 3 / 4; pt > 0 {
+		return pt
+	}
+	return 1
+}
+
 func refreshPreviewWindow() {
 	if previewWindow == nil || previewContent == nil {
 		return
@@ -1627,6 +2682,9 @@ func refreshPreviewWindow() {
 		return
 	}
 	t := templateModel.Templates[index]
+	if resolved, err := database.ResolveTemplate(t.ID); err == nil {
+		t = resolved
+	}
 
 	log.Printf("refreshPreviewWindow: Template: %+v", t)
 
@@ -1634,7 +2692,7 @@ func refreshPreviewWindow() {
 	if t.ShowGameclock || t.ShowClock {
 		lblClock.SetVisible(true)
 		lblClock.SetText("00:00")
-		fontClock, _ := walk.NewFont(defaultFontFamily, defaultClockSize, 0)
+		fontClock, _ := walk.NewFont(previewFamily(t.ClockFontFamily), pointSize(t.ClockFontSize), 0)
 		lblClock.SetFont(fontClock)
 		if color, err := parseHexColor(t.ClockFontColor); err == nil {
 			lblClock.SetTextColor(color)
@@ -1648,7 +2706,7 @@ func refreshPreviewWindow() {
 	lblScore.SetText("7 : 3")
 	lblGuest.SetText("⚽")
 
-	fontScore, _ := walk.NewFont(defaultFontFamily, defaultScoreSize, 0)
+	fontScore, _ := walk.NewFont(previewFamily(t.ScoreFontFamily), pointSize(t.ScoreFontSize), 0)
 	lblScore.SetFont(fontScore)
 	if color, err := parseHexColor(t.ScoreFontColor); err == nil {
 		lblScore.SetTextColor(color)
@@ -1658,7 +2716,7 @@ func refreshPreviewWindow() {
 	if t.ShowPeriod {
 		lblPeriod.SetVisible(true)
 		lblPeriod.SetText(t.PeriodLabel)
-		fontPeriod, _ := walk.NewFont(defaultFontFamily, defaultPeriodSize, 0)
+		fontPeriod, _ := walk.NewFont(previewFamily(t.PeriodFontFamily), pointSize(t.PeriodFontSize), 0)
 		lblPeriod.SetFont(fontPeriod)
 		if color, err := parseHexColor(t.PeriodFontColor); err == nil {
 			lblPeriod.SetTextColor(color)
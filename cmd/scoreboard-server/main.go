@@ -0,0 +1,154 @@
+// cmd/scoreboard-server/main.go
+//
+// Headless-Server ohne GUI: Live-Spiele, REST-API und Overlay für Browserquellen.
+// Nutzt dieselbe SQLite-Datenbank wie die Admin-Oberfläche. Vorgaben der
+// Flags kommen aus scoreboard.toml bzw. SCOREBOARD_*-Umgebungsvariablen.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/api"
+	"github.com/KernTom/scoreboard-manager/internal/config"
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/fonts"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/live"
+	"github.com/KernTom/scoreboard-manager/internal/overlay"
+)
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	cfg.Apply()
+
+	dbPath := flag.String("db", cfg.Database, i18n.T("flag.db"))
+	addr := flag.String("addr", cfg.Server.Addr, i18n.T("server.flag.addr"))
+	tlsCert := flag.String("tls-cert", cfg.Server.TLSCert, i18n.T("server.flag.tls_cert"))
+	tlsKey := flag.String("tls-key", cfg.Server.TLSKey, i18n.T("server.flag.tls_key"))
+	fontsDir := flag.String("fonts-dir", cfg.FontsDir, i18n.T("server.flag.fonts_dir"))
+
+	video := videoOptions{}
+	flag.IntVar(&video.field, "video-field", -1, i18n.T("server.flag.video_field"))
+	flag.IntVar(&video.fps, "video-fps", 25, i18n.T("server.flag.video_fps"))
+	flag.StringVar(&video.format, "video-format", "raw", i18n.T("server.flag.video_format"))
+	flag.StringVar(&video.out, "video-out", "-", i18n.T("server.flag.video_out"))
+	videoSize := flag.String("video-size", "1920x1080", i18n.T("server.flag.video_size"))
+	flag.BoolVar(&video.transparentBoard, "video-transparent-board", false, i18n.T("server.flag.video_transparent"))
+	flag.Parse()
+
+	if (*tlsCert == "") != (*tlsKey == "") {
+		log.Fatal(i18n.T("server.tls_pair"))
+	}
+	if video.width, video.height, err = parseSize(*videoSize); err != nil {
+		log.Fatal(err)
+	}
+	if video.format == "png" && video.out == "-" {
+		log.Fatal(i18n.T("server.png_dir"))
+	}
+
+	if err := database.Open(*dbPath); err != nil {
+		log.Fatal(i18n.T("log.db_failed", err))
+	}
+	defer database.Close()
+
+	// Datenbank nach dem Verzeichnis: gleichnamige Dateien aus der Datenbank gewinnen
+	if err := fonts.LoadDir(*fontsDir); err != nil {
+		log.Fatal(i18n.T("log.fonts_failed", err))
+	}
+	if err := fonts.LoadDatabase(); err != nil {
+		log.Fatal(i18n.T("log.fonts_failed", err))
+	}
+
+	manager := live.NewManager()
+	if err := api.Resume(manager); err != nil {
+		log.Print(i18n.T("server.resume_failed", err))
+	}
+
+	mux := http.NewServeMux()
+	api.New(manager).Register(mux)
+	overlay.New(manager).Register(mux)
+
+	// baseCtx endet mit Beginn von Shutdown; das beendet die Overlay-Streams,
+	// auf die Shutdown sonst warten würde
+	baseCtx, cancelBase := context.WithCancel(context.Background())
+	defer cancelBase()
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return baseCtx },
+	}
+	srv.RegisterOnShutdown(cancelBase)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Print(i18n.T("server.listening", *addr))
+		if *tlsCert != "" {
+			errCh <- srv.ListenAndServeTLS(*tlsCert, *tlsKey)
+		} else {
+			errCh <- srv.ListenAndServe()
+		}
+	}()
+
+	videoDone := make(chan struct{})
+	if video.field >= 0 {
+		go func() {
+			defer close(videoDone)
+			log.Print(i18n.T("server.video", video.field, video.format, video.fps, video.width, video.height))
+			if err := runVideo(ctx, manager, video); err != nil {
+				log.Print(i18n.T("server.video_ended", err))
+			}
+		}()
+	} else {
+		close(videoDone)
+	}
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(i18n.T("server.failed", err))
+		}
+	case <-ctx.Done():
+	}
+
+	log.Println(i18n.T("server.stopping"))
+	stop()
+
+	// Zuerst keine Anfragen mehr annehmen und laufende abwarten, damit nach
+	// dem Sichern kein Live-Spiel mehr geändert werden kann
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Print(i18n.T("server.shutdown_failed", err))
+	}
+
+	select {
+	case <-videoDone:
+	case <-time.After(5 * time.Second):
+		log.Println(i18n.T("server.video_timeout"))
+	}
+
+	engines := manager.StopAll()
+	for _, e := range engines {
+		if err := api.Checkpoint(e); err != nil {
+			log.Print(i18n.T("server.checkpoint_failed", e.Match().ID, err))
+		}
+	}
+	log.Print(i18n.T("server.checkpointed", len(engines)))
+}
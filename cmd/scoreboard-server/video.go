@@ -0,0 +1,109 @@
+// cmd/scoreboard-server/video.go
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"log"
+	"os"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/live"
+	"github.com/KernTom/scoreboard-manager/internal/logo"
+	"github.com/KernTom/scoreboard-manager/internal/render"
+)
+
+// videoOptions steuert die Videoausgabe eines Feldes (-video-*)
+type videoOptions struct {
+	field            int
+	fps              int
+	format           string // "raw" oder "png"
+	out              string // "-" für stdout, Datei/Named Pipe bzw. Verzeichnis bei png
+	width, height    int
+	transparentBoard bool
+}
+
+func parseSize(s string) (int, int, error) {
+	var w, h int
+	if _, err := fmt.Sscanf(s, "%dx%d", &w, &h); err != nil || w <= 0 || h <= 0 {
+		return 0, 0, i18n.Errorf("error.video.size", s)
+	}
+	return w, h, nil
+}
+
+func openSink(opts videoOptions) (render.Sink, error) {
+	switch opts.format {
+	case "png":
+		return render.NewPNGSequence(opts.out)
+	case "raw":
+		var w io.WriteCloser = os.Stdout
+		if opts.out != "-" {
+			// O_WRONLY ohne O_CREATE|O_TRUNC funktioniert auch mit Named Pipes (mkfifo)
+			f, err := os.OpenFile(opts.out, os.O_WRONLY|os.O_CREATE, 0o644)
+			if err != nil {
+				return nil, err
+			}
+			w = f
+		}
+		return render.NewRawStream(w), nil
+	}
+	return nil, i18n.Errorf("error.video.format", opts.format)
+}
+
+// runVideo rendert das Live-Spiel des Feldes in fester Bildgröße. Ohne
+// laufendes Spiel werden leere, transparente Bilder ausgegeben, damit der
+// Datenstrom nicht abreißt.
+func runVideo(ctx context.Context, manager *live.Manager, opts videoOptions) error {
+	sink, err := openSink(opts)
+	if err != nil {
+		return err
+	}
+	defer sink.Close()
+
+	canvas := image.NewRGBA(image.Rect(0, 0, opts.width, opts.height))
+	var logosFor int
+	var homeLogo, awayLogo image.Image
+	var homeHash, awayHash string
+	var lastErr string
+
+	next := func() (*image.RGBA, error) {
+		e, ok := manager.Get(opts.field)
+		if !ok {
+			clear(canvas.Pix)
+			return canvas, nil
+		}
+
+		m := e.Match()
+		// Logos nur bei Spielwechsel neu laden
+		if logosFor != m.ID {
+			logosFor = m.ID
+			homeLogo, awayLogo, homeHash, awayHash = nil, nil, "", ""
+			if t, err := database.LoadTeam(m.Team1.ID); err == nil {
+				homeLogo, homeHash = logo.Image(t.LogoHash), t.LogoHash
+			}
+			if t, err := database.LoadTeam(m.Team2.ID); err == nil {
+				awayLogo, awayHash = logo.Image(t.LogoHash), t.LogoHash
+			}
+		}
+
+		f := render.Frame{State: e.Snapshot(), HomeLogo: homeLogo, AwayLogo: awayLogo,
+			HomeLogoHash: homeHash, AwayLogoHash: awayHash, Transparent: opts.transparentBoard}
+		if err := render.Compose(canvas, e.Template(), f); err != nil {
+			// ein fehlerhaftes Template darf den Datenstrom nicht beenden
+			if err.Error() != lastErr {
+				lastErr = err.Error()
+				log.Print(i18n.T("server.video_error", opts.field, err))
+			}
+			clear(canvas.Pix)
+			return canvas, nil
+		}
+		lastErr = ""
+		return canvas, nil
+	}
+
+	return render.Run(ctx, opts.fps, 0, next, sink)
+}
@@ -0,0 +1,73 @@
+// cmd/scoreboard/config.go
+
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/KernTom/scoreboard-manager/internal/config"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+)
+
+var configActions = map[string]func(args []string) error{
+	"show":  configShow,
+	"check": configCheck,
+	"env":   configEnv,
+}
+
+// configShow gibt die wirksame Konfiguration aus, als Vorlage für eine
+// eigene scoreboard.toml geeignet
+func configShow(args []string) error {
+	fs := newFlagSet("config show")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if jsonOut {
+		return printJSON(os.Stdout, cfg)
+	}
+	if cfg.Path != "" {
+		fmt.Println(i18n.T("cli.config.file", cfg.Path))
+	} else {
+		fmt.Println(i18n.T("cli.config.no_file", strings.Join(config.SearchPath(), ", ")))
+	}
+	return cfg.Write(os.Stdout)
+}
+
+// configCheck prüft die gefundene oder eine andere Konfigurationsdatei
+func configCheck(args []string) error {
+	fs := newFlagSet("config check")
+	in := fs.String("i", "", i18n.T("cli.flag.check_file"))
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	c := cfg
+	if *in != "" {
+		var err error
+		if c, err = config.LoadFile(*in); err != nil {
+			return err
+		}
+	}
+	file := cmp.Or(c.Path, i18n.T("cli.config.defaults"))
+	return printResult(map[string]string{"File": c.Path}, "cli.msg.ok", file)
+}
+
+// configEnv listet die Umgebungsvariablen, die Werte der Datei überschreiben
+func configEnv(args []string) error {
+	fs := newFlagSet("config env")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	vars := map[string]string{}
+	var rows [][]string
+	for _, name := range config.EnvVars() {
+		v, ok := os.LookupEnv(name)
+		if ok {
+			vars[name] = v
+		}
+		rows = append(rows, []string{name, cmp.Or(v, "-")})
+	}
+	return printTable(vars, []string{"cli.col.variable", "cli.col.value"}, rows)
+}
@@ -0,0 +1,225 @@
+// cmd/scoreboard/db.go
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/dump"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+var dbActions = map[string]func(args []string) error{
+	"backup":    dbBackup,
+	"snapshot":  dbSnapshot,
+	"snapshots": dbSnapshots,
+	"check":     dbCheck,
+	"restore":   dbRestore,
+	"export":    dbExport,
+	"import":    dbImport,
+}
+
+// dbBackup schreibt eine Kopie der Datenbank, auch während der Server läuft
+func dbBackup(args []string) error {
+	fs := newFlagSet("db backup")
+	out := fs.String("o", "", i18n.T("cli.flag.backup_out"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(fs, "o"); err != nil {
+		return err
+	}
+	if err := database.Backup(*out); err != nil {
+		return err
+	}
+	return printResult(map[string]string{"Backup": *out}, "cli.msg.backup", *out)
+}
+
+// dbSnapshot legt einen Snapshot im Verzeichnis backups an
+func dbSnapshot(args []string) error {
+	fs := newFlagSet("db snapshot")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	s, err := database.CreateSnapshot(database.SnapshotManual)
+	if err != nil {
+		return err
+	}
+	return printResult(s, "cli.msg.snapshot", s.Path)
+}
+
+// dbSnapshots listet die Snapshots, neueste zuerst
+func dbSnapshots(args []string) error {
+	fs := newFlagSet("db snapshots")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	snaps, err := database.ListSnapshots(dbPath)
+	if err != nil {
+		return err
+	}
+	if snaps == nil {
+		snaps = []*database.Snapshot{}
+	}
+	var rows [][]string
+	for _, s := range snaps {
+		rows = append(rows, []string{s.Created.Format("2006-01-02 15:04:05"), s.Reason, strconv.FormatInt(s.Size/1024, 10), s.Path})
+	}
+	return printTable(snaps, []string{"cli.col.time", "cli.col.reason", "cli.col.kb", "cli.col.file"}, rows)
+}
+
+// dbCheck prüft die Datenbank oder eine Sicherung mit PRAGMA integrity_check,
+// ohne sie zu öffnen und zu migrieren
+func dbCheck(args []string) error {
+	fs := newFlagSet("db check")
+	in := fs.String("i", "", i18n.T("cli.flag.check_backup"))
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	file := dbPath
+	if *in != "" {
+		file = *in
+	}
+	problems, err := database.CheckFile(file)
+	if err != nil {
+		return err
+	}
+	if jsonOut {
+		if err := printJSON(os.Stdout, map[string]any{"File": file, "Problems": problems}); err != nil {
+			return err
+		}
+	} else {
+		for _, p := range problems {
+			fmt.Println("! " + p)
+		}
+	}
+	if len(problems) > 0 {
+		return i18n.Errorf("error.wrap.named", file, database.ErrCorrupt)
+	}
+	if !jsonOut {
+		fmt.Println(i18n.T("cli.msg.ok", file))
+	}
+	return nil
+}
+
+// dbRestore spielt eine Sicherung zurück; der bisherige Stand bleibt als
+// Snapshot erhalten. Funktioniert auch, wenn die Datenbank beschädigt ist.
+func dbRestore(args []string) error {
+	fs := newFlagSet("db restore")
+	in := fs.String("i", "", i18n.T("cli.flag.restore_in"))
+	latest := fs.Bool("latest", false, i18n.T("cli.flag.latest"))
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if (*in == "") == !*latest {
+		return i18n.Errorf("error.cli.restore_source")
+	}
+	src := *in
+	if *latest {
+		snaps, err := database.ListSnapshots(dbPath)
+		if err != nil {
+			return err
+		}
+		if len(snaps) == 0 {
+			return i18n.Errorf("error.cli.no_snapshots")
+		}
+		src = snaps[0].Path
+	}
+	saved, err := database.Restore(dbPath, src)
+	if err != nil {
+		return err
+	}
+	if saved != nil {
+		return printResult(map[string]string{"Restored": src, "Previous": saved.Path},
+			"cli.msg.restored_previous", src, saved.Path)
+	}
+	return printResult(map[string]string{"Restored": src}, "cli.msg.restored", src)
+}
+
+// dbExport schreibt den gesamten Datenbestand als JSON, ohne -o nach stdout
+func dbExport(args []string) error {
+	fs := newFlagSet("db export")
+	out := fs.String("o", "", i18n.T("cli.flag.export_out"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	d, err := dump.Export()
+	if err != nil {
+		return err
+	}
+	if *out == "" || *out == "-" {
+		return d.Write(os.Stdout)
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := d.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, i18n.T("cli.msg.exported", len(d.Sports), len(d.Teams), len(d.Templates), len(d.Matches), *out))
+	return nil
+}
+
+// dbImport führt eine Datei aus db export mit der Datenbank zusammen
+func dbImport(args []string) error {
+	fs := newFlagSet("db import")
+	in := fs.String("i", "", i18n.T("cli.flag.import_in"))
+	conflict := fs.String("conflict", models.ConflictSkip,
+		i18n.T("cli.flag.conflict", strings.Join(models.ConflictPolicies(), ", ")))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(fs, "i"); err != nil {
+		return err
+	}
+	var data []byte
+	var err error
+	if *in == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(*in)
+	}
+	if err != nil {
+		return err
+	}
+	d, err := dump.Decode(data)
+	if err != nil {
+		return err
+	}
+	report, err := dump.Import(d, *conflict)
+	if err != nil {
+		return err
+	}
+
+	var rows [][]string
+	for _, kind := range dump.Kinds() {
+		row := []string{dump.KindName(kind)}
+		for _, action := range []string{dump.ActionCreated, dump.ActionSkipped, dump.ActionReplaced, dump.ActionRenamed} {
+			row = append(row, strconv.Itoa(report.Count(kind, action)))
+		}
+		rows = append(rows, row)
+	}
+	if err := printTable(report, []string{"cli.col.kind", "cli.col.created", "cli.col.skipped", "cli.col.replaced", "cli.col.renamed"}, rows); err != nil {
+		return err
+	}
+	if !jsonOut {
+		for _, e := range report.Entries {
+			if e.Action == dump.ActionRenamed {
+				fmt.Println(i18n.T("cli.msg.renamed", dump.KindName(e.Kind), e.Name))
+			}
+		}
+		fmt.Println(i18n.T("cli.msg.import_snapshot", report.Snapshot))
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+// cmd/scoreboard/fixtures.go
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/KernTom/scoreboard-manager/internal/fixtures"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+var fixtureActions = map[string]func(args []string) error{
+	"import": fixturesImport,
+}
+
+// fixtureResult ist die Ausgabe von "fixtures import -json"
+type fixtureResult struct {
+	Plan    *fixtures.Plan
+	Applied bool
+}
+
+// fixturesImport liest einen Spielplan aus CSV, XLSX oder iCalendar. Ohne
+// -dry-run werden neue Teams und Spiele gespeichert und Spiele mit bekannter
+// UID verschoben; Zeilen mit Fehlern brechen den Import ab, außer mit -skip-invalid.
+func fixturesImport(args []string) error {
+	fs := newFlagSet("fixtures import")
+	in := fs.String("i", "", i18n.T("cli.flag.fixtures_in"))
+	sheet := fs.String("sheet", "", i18n.T("cli.flag.sheet"))
+	mapping := fs.String("map", "", i18n.T("cli.flag.map", strings.Join(fixtures.Columns(), ", ")))
+	sport := fs.String("sport", "", i18n.T("cli.flag.fixtures_sport"))
+	competition := fs.String("competition", "", i18n.T("cli.flag.fixtures_competition"))
+	createTeams := fs.Bool("create-teams", false, i18n.T("cli.flag.create_teams"))
+	threshold := fs.Float64("threshold", fixtures.DefaultThreshold, i18n.T("cli.flag.threshold"))
+	dryRun := fs.Bool("dry-run", false, i18n.T("cli.flag.dry_run"))
+	skipInvalid := fs.Bool("skip-invalid", false, i18n.T("cli.flag.skip_invalid"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(fs, "i"); err != nil {
+		return err
+	}
+	if *threshold <= 0 || *threshold > 1 {
+		return i18n.Errorf("error.cli.threshold")
+	}
+
+	opts := fixtures.Options{Sport: *sport, Competition: *competition, CreateTeams: *createTeams, Threshold: *threshold}
+	var err error
+	if opts.Mapping, err = fixtures.ParseMapping(*mapping); err != nil {
+		return err
+	}
+	rows, err := fixtures.ReadFile(*in, *sheet)
+	if err != nil {
+		return err
+	}
+	plan, err := fixtures.Build(rows, opts)
+	if err != nil {
+		return err
+	}
+
+	apply := !*dryRun && (len(plan.Errors) == 0 || *skipInvalid)
+	if apply {
+		if err := fixtures.Apply(plan); err != nil {
+			return err
+		}
+	}
+	if jsonOut {
+		if err := printJSON(os.Stdout, fixtureResult{plan, apply}); err != nil {
+			return err
+		}
+	} else {
+		printPlan(plan, apply, *dryRun)
+	}
+	if len(plan.Errors) > 0 && !*skipInvalid && !*dryRun {
+		return i18n.Errorf("error.cli.invalid_rows", len(plan.Errors))
+	}
+	return nil
+}
+
+// printPlan gibt den Plan als Diff aus: + neu, ~ unscharf zugeordnet bzw.
+// verschoben, x abgesagt, = schon vorhanden, ! Fehler
+func printPlan(plan *fixtures.Plan, applied, dryRun bool) {
+	for _, t := range plan.Teams {
+		switch {
+		case t.Created && t.Similar != "":
+			fmt.Println(i18n.T("cli.plan.team_similar", t.Input, t.Sport, t.Similar, t.Score*100))
+		case t.Created:
+			fmt.Println(i18n.T("cli.plan.team_new", t.Input, t.Sport))
+		case t.Score < 1:
+			fmt.Println(i18n.T("cli.plan.team_fuzzy", t.Input, t.Team.Name, t.Score*100))
+		}
+	}
+	for _, f := range plan.Fixtures {
+		m := f.Match
+		mark, note := "+", ""
+		switch {
+		case f.Cancelled && f.Previous != nil:
+			mark, note = "x", " "+i18n.T("cli.plan.cancelled", f.Previous.ID)
+		case f.Cancelled && m.ID == 0:
+			mark, note = "x", " "+i18n.T("cli.plan.cancelled_unknown")
+		case f.Existing:
+			mark, note = "=", " "+i18n.T("cli.plan.existing")
+		case f.Previous != nil:
+			mark, note = "~", " "+i18n.T("cli.plan.moved", f.Previous.ID, describeMatch(f.Previous))
+		}
+		line := i18n.T("cli.plan.match", mark, f.Line, m.GameTime.Local().Format(timeLayout), m.Team1.Name, m.Team2.Name)
+		if m.Competition != "" {
+			line += "  [" + m.Competition + "]"
+		}
+		if m.Field != "" {
+			line += "  " + m.Field
+		}
+		fmt.Println(line + note)
+		for _, w := range f.Warnings {
+			fmt.Println(i18n.T("cli.plan.warning", w))
+		}
+	}
+	for _, e := range plan.Errors {
+		fmt.Println("! " + e.Error())
+	}
+
+	existing := len(plan.Fixtures) - plan.NewMatches() - plan.MovedMatches()
+	switch {
+	case applied:
+		fmt.Println(i18n.T("cli.plan.applied", plan.NewTeams(), plan.NewMatches(), plan.MovedMatches(), existing, len(plan.Errors)))
+	default:
+		prefix := i18n.T("cli.plan.not_saved")
+		if dryRun {
+			prefix = i18n.T("cli.plan.dry_run")
+		}
+		fmt.Println(i18n.T("cli.plan.pending",
+			prefix, plan.NewTeams(), plan.NewMatches(), plan.MovedMatches(), existing, len(plan.Errors)))
+	}
+}
+
+// describeMatch fasst Anstoß, Teams und Feld für die Diff-Ausgabe zusammen
+func describeMatch(m *models.Match) string {
+	s := fmt.Sprintf("%s  %s – %s", m.GameTime.Local().Format(timeLayout), m.Team1.Name, m.Team2.Name)
+	if m.Field != "" {
+		s += "  " + m.Field
+	}
+	return s
+}
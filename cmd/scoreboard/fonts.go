@@ -0,0 +1,91 @@
+// cmd/scoreboard/fonts.go
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/fonts"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+var fontActions = map[string]func(args []string) error{
+	"list":   fontsList,
+	"add":    fontsAdd,
+	"delete": fontsDelete,
+}
+
+// fontRecord ist eine Zeile von "fonts list -json", ohne die Datei selbst
+type fontRecord struct {
+	Family string
+	File   string
+	Source string
+	Bytes  int
+}
+
+// fontsList zeigt das Schriftregister, wie es Renderer und Overlay sehen
+func fontsList(args []string) error {
+	fs := newFlagSet("fonts list")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	records := []fontRecord{}
+	var rows [][]string
+	for _, f := range fonts.All() {
+		records = append(records, fontRecord{f.Family, f.File, f.Source, len(f.Data)})
+		rows = append(rows, []string{f.Family, f.File, f.Source, strconv.Itoa(len(f.Data))})
+	}
+	return printTable(records, []string{"cli.col.family", "cli.col.file", "cli.col.source", "cli.col.bytes"}, rows)
+}
+
+// fontsAdd speichert eine TTF/OTF-Datei in der Datenbank
+func fontsAdd(args []string) error {
+	fs := newFlagSet("fonts add")
+	in := fs.String("i", "", i18n.T("cli.flag.font_file"))
+	name := fs.String("name", "", i18n.T("cli.flag.font_name"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(fs, "i"); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(*in)
+	if err != nil {
+		return err
+	}
+	f := &models.Font{Name: *name, Data: data}
+	if f.Name == "" {
+		f.Name = filepath.Base(*in)
+	}
+	if err := fonts.Validate(f); err != nil {
+		return err
+	}
+	if err := database.SaveFont(f); err != nil {
+		return err
+	}
+	added, err := fonts.Add(f.Name, f.Data, fonts.SourceDatabase)
+	if err != nil {
+		return err
+	}
+	rec := fontRecord{added.Family, added.File, added.Source, len(added.Data)}
+	return printResult(rec, "cli.msg.font_saved", f.Name, added.Family)
+}
+
+func fontsDelete(args []string) error {
+	fs := newFlagSet("fonts delete")
+	name := fs.String("name", "", i18n.T("cli.flag.font_delete"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(fs, "name"); err != nil {
+		return err
+	}
+	if err := database.DeleteFont(*name); err != nil {
+		return err
+	}
+	return printResult(map[string]any{"File": *name}, "cli.msg.font_deleted", *name)
+}
@@ -0,0 +1,166 @@
+// cmd/scoreboard/main.go
+//
+// Kommandozeilen-Werkzeug zur Datenpflege ohne GUI, z.B. um eine neue
+// Saison per Skript anzulegen:
+//
+//	scoreboard teams import -db season.db -i teams.json
+//	scoreboard matches list -competition "Liga 2026" -json
+//	scoreboard fixtures import -i spielplan.xlsx -sport Fußball -dry-run
+//	scoreboard matches export -competition "Liga 2026" -o liga.ics
+//	scoreboard db backup -o sicherung.db
+//	scoreboard db import -i verein.json -conflict rename
+//
+// Vorgaben wie Datenbank und Schriftverzeichnis kommen aus scoreboard.toml
+// (siehe scoreboard config show), Flags haben Vorrang.
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/KernTom/scoreboard-manager/internal/config"
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/fonts"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+)
+
+// Gemeinsame Flags aller Aktionen, siehe newFlagSet
+var (
+	dbPath   string
+	fontsDir string
+	jsonOut  bool
+)
+
+// cfg ist die geladene Konfiguration, die Vorgaben der Flags
+var cfg *config.Config
+
+// errUsage signalisiert falsche Aufrufe; die Hilfe wurde dann bereits ausgegeben
+var errUsage = i18n.Errorf("error.cli.usage")
+
+type resource struct {
+	name    string
+	actions map[string]func(args []string) error
+}
+
+var resources = []resource{
+	{"teams", teamActions},
+	{"sports", sportActions},
+	{"templates", templateActions},
+	{"matches", matchActions},
+	{"fonts", fontActions},
+	{"fixtures", fixtureActions},
+	{"db", dbActions},
+	{"config", configActions},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, i18n.T("cli.usage.call"))
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, i18n.T("cli.usage.resources"))
+	fmt.Fprintln(os.Stderr, i18n.T("cli.usage.actions", i18n.T("cli.usage.action_order")))
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, i18n.T("cli.usage.help"))
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		if !errors.Is(err, errUsage) && !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, i18n.T("cli.error", err))
+		}
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	// Konfiguration zuerst, damit schon die Hilfe in der eingestellten
+	// Sprache erscheint
+	var err error
+	if cfg, err = config.Load(); err != nil {
+		return err
+	}
+	cfg.Apply()
+	if len(args) < 2 {
+		usage()
+		return errUsage
+	}
+	for _, r := range resources {
+		if r.name != args[0] {
+			continue
+		}
+		action, ok := r.actions[args[1]]
+		if !ok {
+			fmt.Fprintln(os.Stderr, i18n.T("cli.unknown_action", args[1], r.name, i18n.T("cli.usage.action_order")))
+			return errUsage
+		}
+		defer database.Close()
+		return action(args[2:])
+	}
+	usage()
+	return errUsage
+}
+
+// newFlagSet legt die Flags einer Aktion inkl. -db, -fonts-dir und -json an
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("scoreboard "+name, flag.ContinueOnError)
+	fs.StringVar(&dbPath, "db", cfg.Database, i18n.T("flag.db"))
+	fs.StringVar(&fontsDir, "fonts-dir", cfg.FontsDir, i18n.T("flag.fonts_dir"))
+	fs.BoolVar(&jsonOut, "json", false, i18n.T("flag.json"))
+	return fs
+}
+
+// parse liest die Flags, öffnet die Datenbank und lädt das Schriftregister,
+// damit Prüfung und Rendern dieselben Schriften sehen wie der Server
+func parse(fs *flag.FlagSet, args []string) error {
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if err := database.Open(dbPath); err != nil {
+		return err
+	}
+	if err := fonts.LoadDir(fontsDir); err != nil {
+		return err
+	}
+	return fonts.LoadDatabase()
+}
+
+// parseFlags liest nur die Flags; für Aktionen, die auch mit einer
+// beschädigten Datenbank funktionieren müssen
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.unexpected_args", fs.Args()))
+		fs.Usage()
+		return errUsage
+	}
+	return nil
+}
+
+// isSet meldet, ob ein Flag explizit angegeben wurde (für Teil-Updates)
+func isSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+func required(fs *flag.FlagSet, names ...string) error {
+	for _, n := range names {
+		if !isSet(fs, n) {
+			fmt.Fprintln(os.Stderr, i18n.T("cli.flag_required", n))
+			fs.Usage()
+			return errUsage
+		}
+	}
+	return nil
+}
@@ -0,0 +1,474 @@
+// cmd/scoreboard/matches.go
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/ical"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+	"github.com/KernTom/scoreboard-manager/internal/tournament"
+)
+
+var matchActions = map[string]func(args []string) error{
+	"list":   matchesList,
+	"add":    matchesAdd,
+	"update": matchesUpdate,
+	"delete": matchesDelete,
+	"import": matchesImport,
+	"export": matchesExport,
+}
+
+const timeLayout = "2006-01-02 15:04"
+
+// matchRecord ist die portable Darstellung eines Spiels: Teams, Template und
+// Feld werden über Namen referenziert, damit sich Spielpläne zwischen
+// Datenbanken übertragen lassen. Turnierverknüpfungen werden nicht übertragen.
+type matchRecord struct {
+	ID          int
+	Sportart    string
+	Competition string
+	Home        string
+	Away        string
+	Template    string
+	Venue       string
+	Field       string
+	Start       time.Time
+	Status      string
+	ScoreHome   *int
+	ScoreAway   *int
+	Round       int
+	Group       string
+	Bracket     string
+}
+
+func toRecord(m *models.Match, fields map[int]*models.Field) matchRecord {
+	r := matchRecord{
+		ID:          m.ID,
+		Sportart:    m.Sportart,
+		Competition: m.Competition,
+		Home:        m.Team1.Name,
+		Away:        m.Team2.Name,
+		Template:    m.TemplateSettings.Name,
+		Field:       m.Field,
+		Start:       m.GameTime,
+		Status:      m.Status,
+		ScoreHome:   m.ScoreHome,
+		ScoreAway:   m.ScoreAway,
+		Round:       m.Round,
+		Group:       m.Group,
+		Bracket:     m.Bracket,
+	}
+	if f, ok := fields[m.FieldID]; ok {
+		r.Venue = f.VenueName
+		r.Field = f.Name
+	}
+	return r
+}
+
+func fieldsByID() (map[int]*models.Field, error) {
+	fields, err := database.LoadFields(0)
+	if err != nil {
+		return nil, err
+	}
+	byID := map[int]*models.Field{}
+	for _, f := range fields {
+		byID[f.ID] = f
+	}
+	return byID, nil
+}
+
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.ParseInLocation(timeLayout, s, time.Local); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, i18n.Errorf("error.cli.time", s, timeLayout)
+	}
+	return t, nil
+}
+
+// parseScore liest Ergebnisse wie "2:1"
+func parseScore(s string) (int, int, error) {
+	home, away, ok := strings.Cut(s, ":")
+	if ok {
+		h, err1 := strconv.Atoi(strings.TrimSpace(home))
+		a, err2 := strconv.Atoi(strings.TrimSpace(away))
+		if err1 == nil && err2 == nil && h >= 0 && a >= 0 {
+			return h, a, nil
+		}
+	}
+	return 0, 0, i18n.Errorf("error.cli.score", s)
+}
+
+func matchesList(args []string) error {
+	fs := newFlagSet("matches list")
+	competition := fs.String("competition", "", i18n.T("cli.flag.matches_competition"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	var matches []*models.Match
+	var err error
+	if *competition != "" {
+		matches, err = database.LoadMatchesByCompetition(*competition)
+	} else {
+		matches, err = database.LoadMatches()
+	}
+	if err != nil {
+		return err
+	}
+	fields, err := fieldsByID()
+	if err != nil {
+		return err
+	}
+
+	records := []matchRecord{}
+	var rows [][]string
+	for _, m := range matches {
+		r := toRecord(m, fields)
+		records = append(records, r)
+		score := "-:-"
+		if m.HasResult() {
+			score = fmt.Sprintf("%d:%d", *m.ScoreHome, *m.ScoreAway)
+		}
+		rows = append(rows, []string{strconv.Itoa(m.ID), m.GameTime.Local().Format(timeLayout), m.Competition,
+			r.Home, r.Away, score, m.Status, r.Field})
+	}
+	return printTable(records, []string{"cli.col.id", "cli.col.kickoff", "cli.col.competition", "cli.col.home", "cli.col.away", "cli.col.result", "cli.col.status", "cli.col.field"}, rows)
+}
+
+func matchesAdd(args []string) error {
+	fs := newFlagSet("matches add")
+	home := fs.String("home", "", i18n.T("cli.flag.home"))
+	away := fs.String("away", "", i18n.T("cli.flag.away"))
+	sport := fs.String("sport", "", i18n.T("cli.flag.sport"))
+	tpl := fs.String("template", "", i18n.T("cli.flag.match_template"))
+	start := fs.String("start", "", i18n.T("cli.flag.start"))
+	competition := fs.String("competition", "", i18n.T("cli.flag.competition"))
+	field := fs.Int("field", 0, i18n.T("cli.flag.field"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(fs, "home", "away", "sport", "start"); err != nil {
+		return err
+	}
+
+	m := &models.Match{Sportart: *sport, Competition: *competition, TemplateSettings: &models.TemplateSettings{}}
+	var err error
+	if m.Team1, err = findTeam(*home, *sport); err != nil {
+		return err
+	}
+	if m.Team2, err = findTeam(*away, *sport); err != nil {
+		return err
+	}
+	if m.Team1.ID == m.Team2.ID {
+		return i18n.Errorf("error.cli.same_team")
+	}
+	if m.GameTime, err = parseTime(*start); err != nil {
+		return err
+	}
+	if *tpl != "" {
+		if m.TemplateSettings, err = findTemplate(*tpl); err != nil {
+			return err
+		}
+	}
+	if *field != 0 {
+		f, err := database.LoadField(*field)
+		if err != nil {
+			return i18n.Errorf("error.cli.field_not_found", *field, err)
+		}
+		m.FieldID, m.Field = f.ID, f.Name
+	}
+
+	if err := database.SaveMatches(m); err != nil {
+		return err
+	}
+	fields, err := fieldsByID()
+	if err != nil {
+		return err
+	}
+	return printResult(toRecord(m, fields), "cli.msg.match_added", m.ID)
+}
+
+func matchesUpdate(args []string) error {
+	fs := newFlagSet("matches update")
+	id := fs.Int("id", 0, i18n.T("cli.flag.match_id"))
+	home := fs.String("home", "", i18n.T("cli.flag.home"))
+	away := fs.String("away", "", i18n.T("cli.flag.away"))
+	tpl := fs.String("template", "", i18n.T("cli.flag.template_ref"))
+	start := fs.String("start", "", i18n.T("cli.flag.start"))
+	competition := fs.String("competition", "", i18n.T("cli.flag.competition"))
+	field := fs.Int("field", 0, i18n.T("cli.flag.field_update"))
+	score := fs.String("score", "", i18n.T("cli.flag.score"))
+	status := fs.String("status", "", i18n.T("cli.flag.status", strings.Join(models.MatchStatuses(), ", ")))
+	reason := fs.String("reason", "", i18n.T("cli.flag.reason"))
+	actor := fs.String("actor", "cli", i18n.T("cli.flag.actor"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(fs, "id"); err != nil {
+		return err
+	}
+
+	m, err := database.LoadMatch(*id)
+	if err != nil {
+		return i18n.Errorf("error.cli.match_not_found", *id, err)
+	}
+	if isSet(fs, "home") {
+		if m.Team1, err = findTeam(*home, m.Sportart); err != nil {
+			return err
+		}
+	}
+	if isSet(fs, "away") {
+		if m.Team2, err = findTeam(*away, m.Sportart); err != nil {
+			return err
+		}
+	}
+	if isSet(fs, "template") {
+		if m.TemplateSettings, err = findTemplate(*tpl); err != nil {
+			return err
+		}
+	}
+	if isSet(fs, "start") {
+		if m.GameTime, err = parseTime(*start); err != nil {
+			return err
+		}
+	}
+	if isSet(fs, "competition") {
+		m.Competition = *competition
+	}
+	if isSet(fs, "field") {
+		m.FieldID, m.Field = 0, ""
+		if *field != 0 {
+			f, err := database.LoadField(*field)
+			if err != nil {
+				return i18n.Errorf("error.cli.field_not_found", *field, err)
+			}
+			m.FieldID, m.Field = f.ID, f.Name
+		}
+	}
+	if isSet(fs, "score") {
+		h, a, err := parseScore(*score)
+		if err != nil {
+			return err
+		}
+		m.ScoreHome, m.ScoreAway = &h, &a
+	}
+
+	err = tournament.SaveMatch(m, *status, *reason, *actor)
+	if errors.Is(err, database.ErrMatchFinished) {
+		return i18n.Errorf("error.cli.use_reason", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	if m, err = database.LoadMatch(m.ID); err != nil {
+		return err
+	}
+	fields, err := fieldsByID()
+	if err != nil {
+		return err
+	}
+	return printResult(toRecord(m, fields), "cli.msg.match_saved", m.ID)
+}
+
+func matchesDelete(args []string) error {
+	fs := newFlagSet("matches delete")
+	id := fs.Int("id", 0, i18n.T("cli.flag.match_id"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(fs, "id"); err != nil {
+		return err
+	}
+	if _, err := database.LoadMatch(*id); err != nil {
+		return i18n.Errorf("error.cli.match_not_found", *id, err)
+	}
+	if err := database.DeleteMatch(*id); err != nil {
+		return err
+	}
+	return printResult(map[string]int{"Deleted": *id}, "cli.msg.match_deleted", *id)
+}
+
+func matchesExport(args []string) error {
+	fs := newFlagSet("matches export")
+	out := fs.String("o", "", i18n.T("flag.out_stdout"))
+	competition := fs.String("competition", "", i18n.T("cli.flag.matches_competition"))
+	format := fs.String("format", "", i18n.T("cli.flag.match_format"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if *format == "" && strings.EqualFold(filepath.Ext(*out), ".ics") {
+		*format = "ics"
+	}
+	switch *format {
+	case "", "json":
+	case "ics":
+		return exportCalendar(*out, *competition)
+	default:
+		return i18n.Errorf("error.cli.match_format", *format)
+	}
+
+	var matches []*models.Match
+	var err error
+	if *competition != "" {
+		matches, err = database.LoadMatchesByCompetition(*competition)
+	} else {
+		matches, err = database.LoadMatches()
+	}
+	if err != nil {
+		return err
+	}
+	fields, err := fieldsByID()
+	if err != nil {
+		return err
+	}
+	records := []matchRecord{}
+	for _, m := range matches {
+		records = append(records, toRecord(m, fields))
+	}
+	return writeExport(*out, records)
+}
+
+// exportCalendar schreibt den Spielplan als iCalendar; ein erneuter Import
+// mit "fixtures import" erkennt die Spiele an ihrer UID
+func exportCalendar(file, competition string) error {
+	cal, err := ical.Schedule(competition)
+	if err != nil {
+		return err
+	}
+	if file == "" || file == "-" {
+		return ical.Write(os.Stdout, cal)
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err := ical.Write(f, cal); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// matchesImport legt Spiele an bzw. aktualisiert sie, wenn Wettbewerb, Teams
+// und Anstoß übereinstimmen. Teams und Template müssen bereits existieren.
+// Beendete Spiele werden übersprungen, sie lassen sich nur per update -reason ändern.
+func matchesImport(args []string) error {
+	fs := newFlagSet("matches import")
+	in := fs.String("i", "", i18n.T("flag.in_stdin"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	var records []matchRecord
+	if err := readImport(*in, &records); err != nil {
+		return err
+	}
+	existing, err := database.LoadMatches()
+	if err != nil {
+		return err
+	}
+	fields, err := database.LoadFields(0)
+	if err != nil {
+		return err
+	}
+
+	var sum importSummary
+	var errs []error
+	for i, r := range records {
+		m, err := fromRecord(r, fields)
+		if err == nil {
+			if old := findSameMatch(existing, m); old != nil {
+				if old.Status == models.MatchFinished {
+					sum.Skipped++
+					continue
+				}
+				m.ID = old.ID
+			}
+		}
+		created := err == nil && m.ID == 0
+		if err == nil {
+			err = database.SaveMatches(m)
+		}
+		if err == nil && r.Status != "" && r.Status != m.Status {
+			if created {
+				// neue Spiele übernehmen den Status ohne Ablaufprüfung
+				m.Status = r.Status
+				err = database.SaveMatchesOverride(m, "Import", "cli")
+			} else {
+				err = database.SetMatchStatus(m.ID, r.Status)
+			}
+		}
+		if err != nil {
+			sum.Failed++
+			errs = append(errs, i18n.Errorf("error.cli.import_match", i+1, r.Home, r.Away, err))
+			continue
+		}
+		if created {
+			existing = append(existing, m)
+			sum.Created++
+		} else {
+			sum.Updated++
+		}
+	}
+	if err := sum.print(); err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+func fromRecord(r matchRecord, fields []*models.Field) (*models.Match, error) {
+	m := &models.Match{
+		Sportart:         r.Sportart,
+		Competition:      r.Competition,
+		GameTime:         r.Start,
+		ScoreHome:        r.ScoreHome,
+		ScoreAway:        r.ScoreAway,
+		Round:            r.Round,
+		Group:            r.Group,
+		Bracket:          r.Bracket,
+		TemplateSettings: &models.TemplateSettings{},
+	}
+	var err error
+	if m.Team1, err = findTeam(r.Home, r.Sportart); err != nil {
+		return nil, err
+	}
+	if m.Team2, err = findTeam(r.Away, r.Sportart); err != nil {
+		return nil, err
+	}
+	if r.Template != "" {
+		if m.TemplateSettings, err = findTemplate(r.Template); err != nil {
+			return nil, err
+		}
+	}
+	m.Field = r.Field
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, r.Field) && (r.Venue == "" || strings.EqualFold(f.VenueName, r.Venue)) {
+			m.FieldID = f.ID
+			break
+		}
+	}
+	return m, nil
+}
+
+func findSameMatch(matches []*models.Match, m *models.Match) *models.Match {
+	for _, old := range matches {
+		if old.Competition == m.Competition && old.Team1.ID == m.Team1.ID && old.Team2.ID == m.Team2.ID &&
+			old.GameTime.Equal(m.GameTime) {
+			return old
+		}
+	}
+	return nil
+}
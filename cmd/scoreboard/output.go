@@ -0,0 +1,97 @@
+// cmd/scoreboard/output.go
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+)
+
+// printJSON schreibt v eingerückt nach w
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printTable gibt Zeilen als Tabelle aus bzw. v als JSON, wenn -json gesetzt
+// ist; header sind die Schlüssel der Spaltenüberschriften
+func printTable(v any, header []string, rows [][]string) error {
+	if jsonOut {
+		return printJSON(os.Stdout, v)
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for i, h := range header {
+		if i > 0 {
+			fmt.Fprint(tw, "\t")
+		}
+		fmt.Fprint(tw, i18n.T(h))
+	}
+	fmt.Fprintln(tw)
+	for _, r := range rows {
+		fmt.Fprintln(tw, strings.Join(r, "\t"))
+	}
+	return tw.Flush()
+}
+
+// printResult meldet ein gespeichertes Objekt mit dem Text zu key
+func printResult(v any, key string, args ...any) error {
+	if jsonOut {
+		return printJSON(os.Stdout, v)
+	}
+	fmt.Println(i18n.T(key, args...))
+	return nil
+}
+
+// writeExport schreibt den Export nach file bzw. auf stdout
+func writeExport(file string, v any) error {
+	if file == "" || file == "-" {
+		return printJSON(os.Stdout, v)
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err := printJSON(f, v); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// readImport liest eine JSON-Liste aus file bzw. stdin
+func readImport(file string, v any) error {
+	var r io.Reader = os.Stdin
+	if file != "" && file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return i18n.Errorf("error.cli.import_file", err)
+	}
+	return nil
+}
+
+// importSummary meldet das Ergebnis eines Imports
+type importSummary struct {
+	Created int
+	Updated int
+	Skipped int
+	Failed  int
+}
+
+func (s importSummary) print() error {
+	return printResult(s, "cli.msg.import_summary", s.Created, s.Updated, s.Skipped, s.Failed)
+}
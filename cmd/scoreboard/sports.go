@@ -0,0 +1,224 @@
+// cmd/scoreboard/sports.go
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+var sportActions = map[string]func(args []string) error{
+	"list":   sportsList,
+	"add":    sportsAdd,
+	"update": sportsUpdate,
+	"delete": sportsDelete,
+	"import": sportsImport,
+	"export": sportsExport,
+}
+
+func sportsList(args []string) error {
+	fs := newFlagSet("sports list")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	sports, err := database.LoadSports()
+	if err != nil {
+		return err
+	}
+	var rows [][]string
+	for _, s := range sports {
+		rows = append(rows, []string{strconv.Itoa(s.ID), s.Sportart,
+			i18n.T("cli.periods", s.PeriodsCount, s.PeriodDuration, s.PeriodLabel),
+			fmt.Sprintf("%d-%d-%d", s.PointsWin, s.PointsDraw, s.PointsLoss),
+			s.RankingMode, s.TieBreakers})
+	}
+	return printTable(sports, []string{"cli.col.id", "cli.col.sport", "cli.col.periods", "cli.col.points", "cli.col.ranking", "cli.col.tie_breakers"}, rows)
+}
+
+// sportFlags registriert die Felder einer Sportart; die Defaults gelten beim Anlegen
+func sportFlags(fs *flag.FlagSet, s *models.SportartDefinition) {
+	fs.StringVar(&s.Sportart, "name", "", i18n.T("cli.flag.sport_name"))
+	fs.StringVar(&s.PeriodLabel, "label", "Halbzeit", i18n.T("cli.flag.period_label"))
+	fs.IntVar(&s.PeriodsCount, "periods", 2, i18n.T("cli.flag.periods"))
+	fs.IntVar(&s.PeriodDuration, "duration", 45, i18n.T("cli.flag.duration"))
+	fs.StringVar(&s.ClockFormat, "clock-format", "MM:SS", i18n.T("cli.flag.clock_format"))
+	fs.StringVar(&s.ClockDirection, "clock-direction", "Up", i18n.T("cli.flag.clock_direction"))
+	fs.IntVar(&s.PointsWin, "points-win", 3, i18n.T("cli.flag.points_win"))
+	fs.IntVar(&s.PointsDraw, "points-draw", 1, i18n.T("cli.flag.points_draw"))
+	fs.IntVar(&s.PointsLoss, "points-loss", 0, i18n.T("cli.flag.points_loss"))
+	fs.StringVar(&s.RankingMode, "ranking", models.RankingPoints, i18n.T("cli.flag.ranking"))
+	fs.StringVar(&s.TieBreakers, "tiebreakers", models.TieBreakGoalDifference+","+models.TieBreakGoalsFor+","+models.TieBreakHeadToHead,
+		i18n.T("cli.flag.tie_breakers"))
+}
+
+func validateSport(s *models.SportartDefinition) error {
+	if strings.TrimSpace(s.Sportart) == "" {
+		return i18n.Errorf("error.cli.sport_name")
+	}
+	if s.RankingMode != models.RankingPoints && s.RankingMode != models.RankingWinPercentage {
+		return i18n.Errorf("error.cli.ranking", s.RankingMode)
+	}
+	for _, tb := range strings.Split(s.TieBreakers, ",") {
+		switch strings.TrimSpace(tb) {
+		case "", models.TieBreakHeadToHead, models.TieBreakGoalDifference, models.TieBreakGoalsFor:
+		default:
+			return i18n.Errorf("error.cli.tie_breaker", tb)
+		}
+	}
+	return nil
+}
+
+func sportsAdd(args []string) error {
+	fs := newFlagSet("sports add")
+	var s models.SportartDefinition
+	sportFlags(fs, &s)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(fs, "name"); err != nil {
+		return err
+	}
+	if err := validateSport(&s); err != nil {
+		return err
+	}
+	if err := database.SaveSport(&s); err != nil {
+		return err
+	}
+	return printResult(s, "cli.msg.sport_added", s.Sportart)
+}
+
+func sportsUpdate(args []string) error {
+	fs := newFlagSet("sports update")
+	var upd models.SportartDefinition
+	sportFlags(fs, &upd)
+	rename := fs.String("rename", "", i18n.T("cli.flag.sport_rename"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(fs, "name"); err != nil {
+		return err
+	}
+
+	s, err := database.LoadSport(upd.Sportart)
+	if err != nil {
+		return i18n.Errorf("error.cli.sport_not_found", upd.Sportart, err)
+	}
+	// nur explizit gesetzte Flags übernehmen
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "label":
+			s.PeriodLabel = upd.PeriodLabel
+		case "periods":
+			s.PeriodsCount = upd.PeriodsCount
+		case "duration":
+			s.PeriodDuration = upd.PeriodDuration
+		case "clock-format":
+			s.ClockFormat = upd.ClockFormat
+		case "clock-direction":
+			s.ClockDirection = upd.ClockDirection
+		case "points-win":
+			s.PointsWin = upd.PointsWin
+		case "points-draw":
+			s.PointsDraw = upd.PointsDraw
+		case "points-loss":
+			s.PointsLoss = upd.PointsLoss
+		case "ranking":
+			s.RankingMode = upd.RankingMode
+		case "tiebreakers":
+			s.TieBreakers = upd.TieBreakers
+		case "rename":
+			s.Sportart = *rename
+		}
+	})
+	if err := validateSport(s); err != nil {
+		return err
+	}
+	if err := database.SaveSport(s); err != nil {
+		return err
+	}
+	return printResult(s, "cli.msg.sport_saved", s.Sportart)
+}
+
+func sportsDelete(args []string) error {
+	fs := newFlagSet("sports delete")
+	name := fs.String("name", "", i18n.T("cli.flag.sport_name"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(fs, "name"); err != nil {
+		return err
+	}
+	s, err := database.LoadSport(*name)
+	if err != nil {
+		return i18n.Errorf("error.cli.sport_not_found", *name, err)
+	}
+	if err := database.DeleteSport(s.ID); err != nil {
+		return err
+	}
+	return printResult(s, "cli.msg.sport_deleted", s.Sportart)
+}
+
+func sportsExport(args []string) error {
+	fs := newFlagSet("sports export")
+	out := fs.String("o", "", i18n.T("flag.out_stdout"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	sports, err := database.LoadSports()
+	if err != nil {
+		return err
+	}
+	if sports == nil {
+		sports = []*models.SportartDefinition{}
+	}
+	return writeExport(*out, sports)
+}
+
+// sportsImport gleicht über den Namen ab
+func sportsImport(args []string) error {
+	fs := newFlagSet("sports import")
+	in := fs.String("i", "", i18n.T("flag.in_stdin"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	var sports []*models.SportartDefinition
+	if err := readImport(*in, &sports); err != nil {
+		return err
+	}
+
+	var sum importSummary
+	var errs []error
+	for _, s := range sports {
+		s.ID = 0
+		if old, err := database.LoadSport(s.Sportart); err == nil {
+			s.ID = old.ID
+		}
+		created := s.ID == 0
+		err := validateSport(s)
+		if err == nil {
+			err = database.SaveSport(s)
+		}
+		if err != nil {
+			sum.Failed++
+			errs = append(errs, i18n.Errorf("error.wrap.sport", s.Sportart, err))
+			continue
+		}
+		if created {
+			sum.Created++
+		} else {
+			sum.Updated++
+		}
+	}
+	if err := sum.print(); err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,390 @@
+// cmd/scoreboard/teams.go
+
+package main
+
+import (
+	"cmp"
+	"errors"
+	"flag"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/logo"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+var teamActions = map[string]func(args []string) error{
+	"list":   teamsList,
+	"add":    teamsAdd,
+	"update": teamsUpdate,
+	"delete": teamsDelete,
+	"import": teamsImport,
+	"export": teamsExport,
+}
+
+func teamsList(args []string) error {
+	fs := newFlagSet("teams list")
+	sport := fs.String("sport", "", i18n.T("cli.flag.teams_sport"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	teams, err := database.LoadTeams()
+	if err != nil {
+		return err
+	}
+	var list []*models.Team
+	var rows [][]string
+	for _, t := range teams {
+		if *sport != "" && t.Sportart != *sport {
+			continue
+		}
+		list = append(list, t)
+		logoInfo := "-"
+		if t.LogoHash != "" {
+			logoInfo = t.LogoHash[:12]
+		}
+		colors := cmp.Or(t.PrimaryColor, "-") + " " + cmp.Or(t.SecondaryColor, "-")
+		rows = append(rows, []string{strconv.Itoa(t.ID), t.Name, cmp.Or(t.ShortName, "-"), cmp.Or(t.Abbreviation, "-"),
+			t.Sportart, logoInfo, colors})
+	}
+	return printTable(list, []string{"cli.col.id", "cli.col.name", "cli.col.short_name", "cli.col.abbr", "cli.col.sport", "cli.col.logo", "cli.col.colors"}, rows)
+}
+
+func teamsAdd(args []string) error {
+	fs := newFlagSet("teams add")
+	name := fs.String("name", "", i18n.T("cli.flag.team_name"))
+	sport := fs.String("sport", "", i18n.T("cli.flag.sport"))
+	logoFile := fs.String("logo", "", i18n.T("cli.flag.logo", strings.Join(logo.Formats(), ", ")))
+	primary, secondary := colorFlags(fs)
+	short, abbr, altNames := nameFlags(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(fs, "name", "sport"); err != nil {
+		return err
+	}
+
+	t := &models.Team{Name: *name, Sportart: *sport, ShortName: *short, Abbreviation: *abbr}
+	if err := applyAltNames(t, *altNames); err != nil {
+		return err
+	}
+	if *logoFile != "" {
+		if err := readLogo(t, *logoFile); err != nil {
+			return err
+		}
+	}
+	if err := applyColors(fs, t, *primary, *secondary); err != nil {
+		return err
+	}
+	if err := database.SaveTeam(t); err != nil {
+		return err
+	}
+	return printResult(t, "cli.msg.team_added", t.ID)
+}
+
+func teamsUpdate(args []string) error {
+	fs := newFlagSet("teams update")
+	id := fs.Int("id", 0, i18n.T("cli.flag.team_id"))
+	name := fs.String("name", "", i18n.T("cli.flag.team_rename"))
+	sport := fs.String("sport", "", i18n.T("cli.flag.team_sport"))
+	logoFile := fs.String("logo", "", i18n.T("cli.flag.logo_update"))
+	primary, secondary := colorFlags(fs)
+	autoColors := fs.Bool("auto-colors", false, i18n.T("cli.flag.auto_colors"))
+	short, abbr, altNames := nameFlags(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(fs, "id"); err != nil {
+		return err
+	}
+
+	t, err := findTeamByID(*id)
+	if err != nil {
+		return err
+	}
+	if isSet(fs, "name") {
+		t.Name = *name
+	}
+	if isSet(fs, "sport") {
+		t.Sportart = *sport
+	}
+	if isSet(fs, "short-name") {
+		t.ShortName = *short
+	}
+	if isSet(fs, "abbr") {
+		t.Abbreviation = *abbr
+	}
+	if err := applyAltNames(t, *altNames); err != nil {
+		return err
+	}
+	if isSet(fs, "logo") {
+		t.LogoHash, t.LogoOriginalHash = "", ""
+		if *logoFile != "" {
+			if err := readLogo(t, *logoFile); err != nil {
+				return err
+			}
+		}
+	}
+	if *autoColors && !isSet(fs, "logo") {
+		img := logo.Image(t.LogoHash)
+		if img == nil {
+			return i18n.Errorf("error.cli.team_no_logo", t.ID)
+		}
+		t.PrimaryColor, t.SecondaryColor = logo.Colors(img)
+	}
+	if err := applyColors(fs, t, *primary, *secondary); err != nil {
+		return err
+	}
+	if err := database.SaveTeam(t); err != nil {
+		return err
+	}
+	return printResult(t, "cli.msg.team_saved", t.ID)
+}
+
+func teamsDelete(args []string) error {
+	fs := newFlagSet("teams delete")
+	id := fs.Int("id", 0, i18n.T("cli.flag.team_id"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(fs, "id"); err != nil {
+		return err
+	}
+	if _, err := findTeamByID(*id); err != nil {
+		return err
+	}
+	if err := database.DeleteTeam(*id); err != nil {
+		return err
+	}
+	return printResult(map[string]int{"Deleted": *id}, "cli.msg.team_deleted", *id)
+}
+
+func teamsExport(args []string) error {
+	fs := newFlagSet("teams export")
+	out := fs.String("o", "", i18n.T("flag.out_stdout"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	teams, err := database.LoadTeams()
+	if err != nil {
+		return err
+	}
+	if teams == nil {
+		teams = []*models.Team{}
+	}
+	// Exporte sind eigenständig: Logos werden mitgeschrieben
+	for _, t := range teams {
+		if t.LogoHash != "" {
+			if t.LogoData, err = database.LoadLogo(t.LogoHash); err != nil {
+				return i18n.Errorf("error.wrap.team", t.Name, err)
+			}
+		}
+		if t.LogoOriginalHash != "" {
+			if t.LogoOriginal, err = database.LoadLogo(t.LogoOriginalHash); err != nil {
+				return i18n.Errorf("error.wrap.team", t.Name, err)
+			}
+		}
+	}
+	return writeExport(*out, teams)
+}
+
+// teamsImport gleicht über Name und Sportart ab: vorhandene Teams werden
+// aktualisiert, neue angelegt. IDs aus der Datei werden ignoriert.
+func teamsImport(args []string) error {
+	fs := newFlagSet("teams import")
+	in := fs.String("i", "", i18n.T("flag.in_stdin"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	var teams []*models.Team
+	if err := readImport(*in, &teams); err != nil {
+		return err
+	}
+	existing, err := database.LoadTeams()
+	if err != nil {
+		return err
+	}
+	byKey := map[string]*models.Team{}
+	for _, t := range existing {
+		byKey[teamKey(t)] = t
+	}
+
+	var sum importSummary
+	var errs []error
+	for _, t := range teams {
+		if strings.TrimSpace(t.Name) == "" {
+			sum.Failed++
+			errs = append(errs, i18n.Errorf("error.cli.team_unnamed"))
+			continue
+		}
+		// Logos neu aufbereiten: ältere Exporte enthalten nur LogoData;
+		// ohne Daten bleibt ein angegebener Hash, er muss dann existieren
+		src := t.LogoOriginal
+		if len(src) == 0 {
+			src = t.LogoData
+		}
+		if len(src) > 0 {
+			// Farben aus der Datei haben Vorrang vor den ermittelten
+			primary, secondary := t.PrimaryColor, t.SecondaryColor
+			if err := setLogo(t, src); err != nil {
+				sum.Failed++
+				errs = append(errs, i18n.Errorf("error.wrap.team", t.Name, err))
+				continue
+			}
+			if primary != "" || secondary != "" {
+				t.PrimaryColor, t.SecondaryColor = primary, secondary
+			}
+		}
+		if err := checkTeamColors(t); err != nil {
+			sum.Failed++
+			errs = append(errs, i18n.Errorf("error.wrap.team", t.Name, err))
+			continue
+		}
+		t.ID = 0
+		if old, ok := byKey[teamKey(t)]; ok {
+			t.ID = old.ID
+		}
+		created := t.ID == 0
+		if err := database.SaveTeam(t); err != nil {
+			sum.Failed++
+			errs = append(errs, i18n.Errorf("error.wrap.team", t.Name, err))
+			continue
+		}
+		byKey[teamKey(t)] = t
+		if created {
+			sum.Created++
+		} else {
+			sum.Updated++
+		}
+	}
+	if err := sum.print(); err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+// readLogo liest eine Logodatei ein, siehe package logo
+func readLogo(t *models.Team, file string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	if err := setLogo(t, data); err != nil {
+		return i18n.Errorf("error.wrap.named", file, err)
+	}
+	return nil
+}
+
+func setLogo(t *models.Team, data []byte) error {
+	l, err := logo.Ingest(data)
+	if err != nil {
+		return err
+	}
+	t.LogoData, t.LogoOriginal = l.Normalized, l.Original
+	t.LogoHash, t.LogoOriginalHash = "", ""
+	t.PrimaryColor, t.SecondaryColor = l.PrimaryColor, l.SecondaryColor
+	return nil
+}
+
+// nameFlags registriert Kurzname, Kürzel und Namen je Wettbewerb
+func nameFlags(fs *flag.FlagSet) (short, abbr, altNames *string) {
+	short = fs.String("short-name", "", i18n.T("cli.flag.short_name"))
+	abbr = fs.String("abbr", "", i18n.T("cli.flag.abbr", models.AbbreviationLength))
+	altNames = fs.String("alt-names", "", i18n.T("cli.flag.alt_names"))
+	return short, abbr, altNames
+}
+
+// applyAltNames übernimmt -alt-names in die Namen je Wettbewerb
+func applyAltNames(t *models.Team, spec string) error {
+	names, err := models.ParseAltNames(spec)
+	if err != nil {
+		return err
+	}
+	if t.AltNames == nil {
+		t.AltNames = map[string]string{}
+	}
+	for competition, name := range names {
+		if name == "" {
+			delete(t.AltNames, competition)
+		} else {
+			t.AltNames[competition] = name
+		}
+	}
+	return nil
+}
+
+// colorFlags registriert die Teamfarben; ohne Angabe werden sie beim
+// Hochladen eines Logos daraus ermittelt
+func colorFlags(fs *flag.FlagSet) (primary, secondary *string) {
+	primary = fs.String("primary-color", "", i18n.T("cli.flag.primary_color"))
+	secondary = fs.String("secondary-color", "", i18n.T("cli.flag.secondary_color"))
+	return primary, secondary
+}
+
+// applyColors übernimmt gesetzte Farb-Flags; "" entfernt die Farbe
+func applyColors(fs *flag.FlagSet, t *models.Team, primary, secondary string) error {
+	if isSet(fs, "primary-color") {
+		t.PrimaryColor = strings.ToUpper(primary)
+	}
+	if isSet(fs, "secondary-color") {
+		t.SecondaryColor = strings.ToUpper(secondary)
+	}
+	return checkTeamColors(t)
+}
+
+func checkTeamColors(t *models.Team) error {
+	for _, c := range []string{t.PrimaryColor, t.SecondaryColor} {
+		if c != "" && !validColor(c) {
+			return i18n.Errorf("error.cli.color", c)
+		}
+	}
+	return nil
+}
+
+func teamKey(t *models.Team) string {
+	return strings.ToLower(t.Sportart) + "\x00" + strings.ToLower(t.Name)
+}
+
+func findTeamByID(id int) (*models.Team, error) {
+	teams, err := database.LoadTeams()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range teams {
+		if t.ID == id {
+			return t, nil
+		}
+	}
+	return nil, i18n.Errorf("error.cli.team_not_found", id)
+}
+
+// findTeam sucht ein Team über ID oder Namen; bei gleichnamigen Teams
+// verschiedener Sportarten entscheidet sport
+func findTeam(ref, sport string) (*models.Team, error) {
+	if id, err := strconv.Atoi(ref); err == nil {
+		return findTeamByID(id)
+	}
+	teams, err := database.LoadTeams()
+	if err != nil {
+		return nil, err
+	}
+	var found *models.Team
+	for _, t := range teams {
+		if !strings.EqualFold(t.Name, ref) || (sport != "" && t.Sportart != sport) {
+			continue
+		}
+		if found != nil {
+			return nil, i18n.Errorf("error.cli.team_ambiguous", ref)
+		}
+		found = t
+	}
+	if found == nil {
+		return nil, i18n.Errorf("error.cli.team_name_not_found", ref)
+	}
+	return found, nil
+}
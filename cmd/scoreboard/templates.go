@@ -0,0 +1,812 @@
+// cmd/scoreboard/templates.go
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/logo"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+	"github.com/KernTom/scoreboard-manager/internal/render"
+	"github.com/KernTom/scoreboard-manager/internal/templatepack"
+)
+
+var templateActions = map[string]func(args []string) error{
+	"list":     templatesList,
+	"add":      templatesAdd,
+	"update":   templatesUpdate,
+	"delete":   templatesDelete,
+	"import":   templatesImport,
+	"export":   templatesExport,
+	"render":   templatesRender,
+	"elements": templatesElements,
+	"assets":   templatesAssets,
+	"pack":     templatesPack,
+	"unpack":   templatesUnpack,
+}
+
+// defaultTemplate entspricht den Spalten-Defaults der Datenbank, Schriften
+// kommen aus der Konfiguration
+func defaultTemplate() models.TemplateSettings {
+	t := models.TemplateSettings{
+		Width: 800, Height: 200,
+		PeriodLabel: "Halbzeit", PeriodsCount: 2, PeriodDuration: 45,
+		GameclockMode: models.GameclockUpMMSS,
+		ShowPeriod:    true, ShowGameclock: true,
+		ClockFontColor:      "#FFFFFF",
+		PeriodFontColor:     "#FFFFFF",
+		ScoreFontColor:      "#FFFFFF",
+		SeparatorFontColor:  "#FFFFFF",
+		ExtraTimeFontColor:  "#FF0000",
+		BackgroundFontColor: "#000000",
+	}
+	cfg.TemplateDefaults(&t)
+	return t
+}
+
+// templateFlags registriert die per CLI änderbaren Felder; alle übrigen
+// lassen sich über export/import bearbeiten. Schriften dürfen eine
+// Ausweichkette sein, z.B. "DS-Digital, monospace".
+func templateFlags(fs *flag.FlagSet, t *models.TemplateSettings) {
+	def := defaultTemplate()
+	fs.StringVar(&t.Name, "name", "", i18n.T("cli.flag.template_name"))
+	fs.StringVar(&t.Sportart, "sport", "", i18n.T("cli.flag.sport"))
+	fs.IntVar(&t.Width, "width", def.Width, i18n.T("cli.flag.width"))
+	fs.IntVar(&t.Height, "height", def.Height, i18n.T("cli.flag.height"))
+	fs.IntVar(&t.X, "x", def.X, i18n.T("cli.flag.x"))
+	fs.IntVar(&t.Y, "y", def.Y, i18n.T("cli.flag.y"))
+	fs.StringVar(&t.PeriodLabel, "label", def.PeriodLabel, i18n.T("cli.flag.period_label"))
+	fs.IntVar(&t.PeriodsCount, "periods", def.PeriodsCount, i18n.T("cli.flag.periods"))
+	fs.IntVar(&t.PeriodDuration, "duration", def.PeriodDuration, i18n.T("cli.flag.duration"))
+	fs.StringVar(&t.GameclockMode, "clock-mode", def.GameclockMode,
+		i18n.T("cli.flag.clock_mode", models.GameclockUpMMSS, models.GameclockUpMinutes, models.GameclockDownMMSS))
+	fs.BoolVar(&t.ShowPeriod, "show-period", def.ShowPeriod, i18n.T("cli.flag.show_period"))
+	fs.BoolVar(&t.ShowGameclock, "show-gameclock", def.ShowGameclock, i18n.T("cli.flag.show_gameclock"))
+	fs.BoolVar(&t.ShowClock, "show-clock", def.ShowClock, i18n.T("cli.flag.show_clock"))
+	fs.StringVar(&t.ClockFontFamily, "clock-font", def.ClockFontFamily, i18n.T("cli.flag.clock_font"))
+	fs.IntVar(&t.ClockFontSize, "clock-size", def.ClockFontSize, i18n.T("cli.flag.clock_size"))
+	fs.StringVar(&t.PeriodFontFamily, "period-font", def.PeriodFontFamily, i18n.T("cli.flag.period_font"))
+	fs.IntVar(&t.PeriodFontSize, "period-size", def.PeriodFontSize, i18n.T("cli.flag.period_size"))
+	fs.StringVar(&t.ScoreFontFamily, "score-font", def.ScoreFontFamily, i18n.T("cli.flag.score_font"))
+	fs.IntVar(&t.ScoreFontSize, "score-size", def.ScoreFontSize, i18n.T("cli.flag.score_size"))
+	fs.StringVar(&t.SeparatorFontFamily, "separator-font", def.SeparatorFontFamily, i18n.T("cli.flag.separator_font"))
+	fs.IntVar(&t.SeparatorFontSize, "separator-size", def.SeparatorFontSize, i18n.T("cli.flag.separator_size"))
+	fs.StringVar(&t.ClockFontColor, "clock-color", def.ClockFontColor, i18n.T("cli.flag.clock_color"))
+	fs.StringVar(&t.PeriodFontColor, "period-color", def.PeriodFontColor, i18n.T("cli.flag.period_color"))
+	fs.StringVar(&t.ScoreFontColor, "score-color", def.ScoreFontColor, i18n.T("cli.flag.score_color"))
+	fs.StringVar(&t.SeparatorFontColor, "separator-color", def.SeparatorFontColor, i18n.T("cli.flag.separator_color"))
+	fs.StringVar(&t.ExtraTimeFontColor, "extra-time-color", def.ExtraTimeFontColor, i18n.T("cli.flag.extra_time_color"))
+	fs.StringVar(&t.BackgroundFontColor, "background", def.BackgroundFontColor, i18n.T("cli.flag.background"))
+}
+
+// templateFlagFields ordnet die Flags den Template-Feldern zu; gesetzte
+// Flags überschreiben bei abgeleiteten Templates das geerbte Feld
+var templateFlagFields = map[string]string{
+	"sport": "Sportart", "width": "Width", "height": "Height", "x": "X", "y": "Y",
+	"label": "PeriodLabel", "periods": "PeriodsCount", "duration": "PeriodDuration", "clock-mode": "GameclockMode",
+	"show-period": "ShowPeriod", "show-gameclock": "ShowGameclock", "show-clock": "ShowClock",
+	"clock-color": "ClockFontColor", "period-color": "PeriodFontColor", "score-color": "ScoreFontColor",
+	"separator-color": "SeparatorFontColor", "extra-time-color": "ExtraTimeFontColor", "background": "BackgroundFontColor",
+	"clock-font": "ClockFontFamily", "clock-size": "ClockFontSize", "period-font": "PeriodFontFamily", "period-size": "PeriodFontSize",
+	"score-font": "ScoreFontFamily", "score-size": "ScoreFontSize", "separator-font": "SeparatorFontFamily", "separator-size": "SeparatorFontSize",
+}
+
+// inheritanceFlags registriert Eltern-Template und Theme-Variablen
+func inheritanceFlags(fs *flag.FlagSet) (parent, theme *string) {
+	parent = fs.String("parent", "", i18n.T("cli.flag.parent"))
+	theme = fs.String("theme", "", i18n.T("cli.flag.theme"))
+	return parent, theme
+}
+
+// applyInheritance übernimmt -parent und -theme; leere Theme-Werte entfernen die Variable
+func applyInheritance(fs *flag.FlagSet, t *models.TemplateSettings, parent, theme string) error {
+	if isSet(fs, "parent") {
+		t.ParentID = 0
+		if parent != "" {
+			p, err := findTemplate(parent)
+			if err != nil {
+				return err
+			}
+			t.ParentID = p.ID
+		}
+	}
+	if t.ParentID == 0 {
+		t.Overrides = nil
+	}
+	if theme != "" {
+		if t.Theme == nil {
+			t.Theme = map[string]string{}
+		}
+		for _, kv := range strings.Split(theme, ",") {
+			k, v, ok := strings.Cut(kv, "=")
+			k = strings.TrimPrefix(strings.TrimSpace(k), "$")
+			if !ok || k == "" {
+				return i18n.Errorf("error.cli.theme", kv)
+			}
+			if v = strings.TrimSpace(v); v == "" {
+				delete(t.Theme, k)
+			} else {
+				t.Theme[k] = v
+			}
+		}
+	}
+	return nil
+}
+
+func validateTemplate(t *models.TemplateSettings) error {
+	if strings.TrimSpace(t.Name) == "" {
+		return i18n.Errorf("error.templatepack.name")
+	}
+	switch t.GameclockMode {
+	case models.GameclockUpMMSS, models.GameclockUpMinutes, models.GameclockDownMMSS:
+	default:
+		return i18n.Errorf("error.cli.clock_mode", t.GameclockMode)
+	}
+	if t.ShowClock && t.ShowGameclock {
+		return i18n.Errorf("error.cli.clock_and_gameclock")
+	}
+	for _, c := range []string{t.ClockFontColor, t.PeriodFontColor, t.ScoreFontColor, t.SeparatorFontColor, t.ExtraTimeFontColor, t.BackgroundFontColor} {
+		if _, ref := models.ThemeRef(c); !ref && !validColor(c) {
+			return i18n.Errorf("error.cli.color", c)
+		}
+	}
+	for k, v := range t.Theme {
+		if !validColor(v) {
+			return i18n.Errorf("error.cli.theme_color", k, v)
+		}
+	}
+
+	// Verweise müssen sich nach der Vererbung auflösen lassen
+	resolved := *t
+	if t.ParentID != 0 {
+		parent, err := database.FlattenTemplate(t.ParentID)
+		if err != nil {
+			return err
+		}
+		resolved = *t.Inherit(parent)
+	}
+	if err := resolved.ApplyTheme(); err != nil {
+		return err
+	}
+	if err := render.ValidateFonts(&resolved); err != nil {
+		return err
+	}
+	return render.ValidateLayout(&resolved)
+}
+
+// validColor prüft Farbcodes wie "#FFFFFF"
+func validColor(s string) bool {
+	if len(s) != 7 || s[0] != '#' {
+		return false
+	}
+	_, err := strconv.ParseUint(s[1:], 16, 32)
+	return err == nil
+}
+
+func templatesList(args []string) error {
+	fs := newFlagSet("templates list")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	templates, err := database.LoadTemplates()
+	if err != nil {
+		return err
+	}
+	names := map[int]string{}
+	for _, t := range templates {
+		names[t.ID] = t.Name
+	}
+	var rows [][]string
+	for _, raw := range templates {
+		// Werte so zeigen, wie sie angezeigt werden
+		t, err := database.FlattenTemplate(raw.ID)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, []string{strconv.Itoa(t.ID), t.Name, t.Sportart,
+			fmt.Sprintf("%dx%d+%d+%d", t.Width, t.Height, t.X, t.Y),
+			i18n.T("cli.periods", t.PeriodsCount, t.PeriodDuration, t.PeriodLabel),
+			t.GameclockMode, names[t.ParentID]})
+	}
+	return printTable(templates, []string{"cli.col.id", "cli.col.name", "cli.col.sport", "cli.col.size", "cli.col.periods", "cli.col.gameclock", "cli.col.parent"}, rows)
+}
+
+func templatesAdd(args []string) error {
+	fs := newFlagSet("templates add")
+	t := defaultTemplate()
+	templateFlags(fs, &t)
+	parent, theme := inheritanceFlags(fs)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(fs, "name"); err != nil {
+		return err
+	}
+	if err := applyInheritance(fs, &t, *parent, *theme); err != nil {
+		return err
+	}
+	if t.ParentID == 0 {
+		if err := required(fs, "sport"); err != nil {
+			return err
+		}
+	}
+	fs.Visit(func(f *flag.Flag) {
+		if field, ok := templateFlagFields[f.Name]; ok {
+			t.Override(field)
+		}
+	})
+	if err := validateTemplate(&t); err != nil {
+		return err
+	}
+	if err := database.SaveTemplate(&t); err != nil {
+		return err
+	}
+	return printResult(t, "cli.msg.template_added", t.ID)
+}
+
+func templatesUpdate(args []string) error {
+	fs := newFlagSet("templates update")
+	id := fs.Int("id", 0, i18n.T("cli.flag.template_id"))
+	var upd models.TemplateSettings
+	templateFlags(fs, &upd)
+	parent, theme := inheritanceFlags(fs)
+	inherit := fs.String("inherit", "", i18n.T("cli.flag.inherit"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(fs, "id"); err != nil {
+		return err
+	}
+
+	t, err := database.LoadTemplate(*id)
+	if err != nil {
+		return i18n.Errorf("error.cli.template_not_found", *id, err)
+	}
+	if err := applyInheritance(fs, t, *parent, *theme); err != nil {
+		return err
+	}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "name":
+			t.Name = upd.Name
+		case "sport":
+			t.Sportart = upd.Sportart
+		case "width":
+			t.Width = upd.Width
+		case "height":
+			t.Height = upd.Height
+		case "x":
+			t.X = upd.X
+		case "y":
+			t.Y = upd.Y
+		case "label":
+			t.PeriodLabel = upd.PeriodLabel
+		case "periods":
+			t.PeriodsCount = upd.PeriodsCount
+		case "duration":
+			t.PeriodDuration = upd.PeriodDuration
+		case "clock-mode":
+			t.GameclockMode = upd.GameclockMode
+		case "show-period":
+			t.ShowPeriod = upd.ShowPeriod
+		case "show-gameclock":
+			t.ShowGameclock = upd.ShowGameclock
+		case "show-clock":
+			t.ShowClock = upd.ShowClock
+		case "clock-color":
+			t.ClockFontColor = upd.ClockFontColor
+		case "period-color":
+			t.PeriodFontColor = upd.PeriodFontColor
+		case "score-color":
+			t.ScoreFontColor = upd.ScoreFontColor
+		case "separator-color":
+			t.SeparatorFontColor = upd.SeparatorFontColor
+		case "extra-time-color":
+			t.ExtraTimeFontColor = upd.ExtraTimeFontColor
+		case "background":
+			t.BackgroundFontColor = upd.BackgroundFontColor
+		case "clock-font":
+			t.ClockFontFamily = upd.ClockFontFamily
+		case "clock-size":
+			t.ClockFontSize = upd.ClockFontSize
+		case "period-font":
+			t.PeriodFontFamily = upd.PeriodFontFamily
+		case "period-size":
+			t.PeriodFontSize = upd.PeriodFontSize
+		case "score-font":
+			t.ScoreFontFamily = upd.ScoreFontFamily
+		case "score-size":
+			t.ScoreFontSize = upd.ScoreFontSize
+		case "separator-font":
+			t.SeparatorFontFamily = upd.SeparatorFontFamily
+		case "separator-size":
+			t.SeparatorFontSize = upd.SeparatorFontSize
+		}
+		if field, ok := templateFlagFields[f.Name]; ok {
+			t.Override(field)
+		}
+	})
+	for _, field := range strings.Split(*inherit, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			if !slices.Contains(models.InheritableFields(), field) {
+				return i18n.Errorf("error.cli.inherit_field", field, strings.Join(models.InheritableFields(), ", "))
+			}
+			t.Overrides = slices.DeleteFunc(t.Overrides, func(f string) bool { return f == field })
+		}
+	}
+	if err := validateTemplate(t); err != nil {
+		return err
+	}
+	if err := database.SaveTemplate(t); err != nil {
+		return err
+	}
+	return printResult(t, "cli.msg.template_saved", t.ID)
+}
+
+func templatesDelete(args []string) error {
+	fs := newFlagSet("templates delete")
+	id := fs.Int("id", 0, i18n.T("cli.flag.template_id"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(fs, "id"); err != nil {
+		return err
+	}
+	if _, err := database.LoadTemplate(*id); err != nil {
+		return i18n.Errorf("error.cli.template_not_found", *id, err)
+	}
+	if err := database.DeleteTemplate(*id); err != nil {
+		return err
+	}
+	return printResult(map[string]int{"Deleted": *id}, "cli.msg.template_deleted", *id)
+}
+
+func templatesExport(args []string) error {
+	fs := newFlagSet("templates export")
+	out := fs.String("o", "", i18n.T("flag.out_stdout"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	templates, err := database.LoadTemplates()
+	if err != nil {
+		return err
+	}
+	if templates == nil {
+		templates = []*models.TemplateSettings{}
+	}
+	return writeExport(*out, templates)
+}
+
+// templatesRender erzeugt ein Standbild des Templates mit Beispieldaten
+func templatesRender(args []string) error {
+	fs := newFlagSet("templates render")
+	id := fs.Int("id", 0, i18n.T("cli.flag.template_id"))
+	out := fs.String("o", "", i18n.T("cli.flag.png_out"))
+	thumb := fs.Int("thumb", 0, i18n.T("cli.flag.thumb"))
+	homeLogo := fs.String("home-logo", "", i18n.T("cli.flag.home_logo"))
+	awayLogo := fs.String("away-logo", "", i18n.T("cli.flag.away_logo"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(fs, "id", "o"); err != nil {
+		return err
+	}
+
+	t, err := database.FlattenTemplate(*id)
+	if err != nil {
+		return i18n.Errorf("error.cli.template", *id, err)
+	}
+	// Teamfarben wie bei einem Spiel aus den Logos übernehmen
+	var logos [2]image.Image
+	var home, away models.Team
+	for i, l := range []struct {
+		file string
+		team *models.Team
+	}{{*homeLogo, &home}, {*awayLogo, &away}} {
+		if l.file == "" {
+			continue
+		}
+		data, err := os.ReadFile(l.file)
+		if err != nil {
+			return err
+		}
+		// wie beim Speichern am Team aufbereiten, damit die Vorschau passt
+		ingested, err := logo.Ingest(data)
+		if err != nil {
+			return i18n.Errorf("error.wrap.named", l.file, err)
+		}
+		logos[i] = render.DecodeLogo(ingested.Normalized)
+		l.team.PrimaryColor, l.team.SecondaryColor = ingested.PrimaryColor, ingested.SecondaryColor
+	}
+	t.SetTeamColors(&home, &away)
+	if err := t.ApplyTheme(); err != nil {
+		return i18n.Errorf("error.cli.template", *id, err)
+	}
+	frame := render.SampleFrame(t)
+	frame.HomeLogo, frame.AwayLogo = logos[0], logos[1]
+
+	img, err := render.Render(t, frame)
+	if err != nil {
+		return err
+	}
+	if *thumb > 0 {
+		if img, err = render.Thumbnail(img, *thumb); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	b := img.Bounds()
+	return printResult(map[string]any{"File": *out, "Width": b.Dx(), "Height": b.Dy()}, "cli.msg.rendered", *out, b.Dx(), b.Dy())
+}
+
+// templatesElements zeigt bzw. ersetzt das freie Layout eines Templates
+func templatesElements(args []string) error {
+	fs := newFlagSet("templates elements")
+	id := fs.Int("id", 0, i18n.T("cli.flag.template_id"))
+	preset := fs.String("preset", "", i18n.T("cli.flag.preset", strings.Join(render.Presets(), ", ")))
+	in := fs.String("i", "", i18n.T("cli.flag.elements_in"))
+	out := fs.String("o", "", i18n.T("cli.flag.elements_out"))
+	reset := fs.Bool("clear", false, i18n.T("cli.flag.clear"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(fs, "id"); err != nil {
+		return err
+	}
+
+	t, err := database.LoadTemplate(*id)
+	if err != nil {
+		return i18n.Errorf("error.cli.template_not_found", *id, err)
+	}
+	// geerbte Elemente und Maße stammen vom Eltern-Template
+	flat, err := database.FlattenTemplate(*id)
+	if err != nil {
+		return err
+	}
+	if *out != "" {
+		elements := flat.Elements
+		if elements == nil {
+			elements = []*models.LayoutElement{}
+		}
+		return writeExport(*out, elements)
+	}
+
+	changed := true
+	switch {
+	case *reset:
+		t.Elements = nil
+	case *preset != "":
+		if t.Elements, err = render.Preset(*preset, flat); err != nil {
+			return err
+		}
+	case *in != "":
+		t.Elements = nil
+		if err := readImport(*in, &t.Elements); err != nil {
+			return err
+		}
+	default:
+		changed = false
+	}
+	if changed {
+		t.Override("Elements")
+		if err := validateTemplate(t); err != nil {
+			return err
+		}
+		if err := database.SaveTemplateElements(t.ID, t.Elements); err != nil {
+			return err
+		}
+		if t.ParentID != 0 {
+			if err := database.SaveTemplate(t); err != nil {
+				return err
+			}
+		}
+		flat.Elements = t.Elements
+	}
+
+	t = flat
+	var rows [][]string
+	for _, e := range t.Elements {
+		font := e.FontFamily
+		if e.FontSize > 0 {
+			font += fmt.Sprintf(" %d", e.FontSize)
+		}
+		rows = append(rows, []string{e.Type, fmt.Sprintf("%dx%d+%d+%d", e.Width, e.Height, e.X, e.Y),
+			e.Align, strings.TrimSpace(font), e.Color, e.Visibility, e.Text})
+	}
+	if t.Elements == nil {
+		t.Elements = []*models.LayoutElement{}
+	}
+	return printTable(t.Elements, []string{"cli.col.type", "cli.col.box", "cli.col.align", "cli.col.font", "cli.col.color", "cli.col.visible", "cli.col.text"}, rows)
+}
+
+// templatesImport gleicht über den Namen ab
+func templatesImport(args []string) error {
+	fs := newFlagSet("templates import")
+	in := fs.String("i", "", i18n.T("flag.in_stdin"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	var templates []*models.TemplateSettings
+	if err := readImport(*in, &templates); err != nil {
+		return err
+	}
+	existing, err := database.LoadTemplates()
+	if err != nil {
+		return err
+	}
+
+	// ParentID verweist auf IDs der Quelldatenbank: Eltern zuerst anlegen
+	// und über den Namen auf die neuen IDs umsetzen
+	bySrcID := map[int]*models.TemplateSettings{}
+	for _, t := range templates {
+		bySrcID[t.ID] = t
+	}
+	parentNames := map[*models.TemplateSettings]string{}
+	depth := map[*models.TemplateSettings]int{}
+	for _, t := range templates {
+		if p, ok := bySrcID[t.ParentID]; ok && t.ParentID != 0 {
+			parentNames[t] = p.Name
+		}
+		for p := t; p.ParentID != 0 && bySrcID[p.ParentID] != nil && depth[t] < len(templates); p = bySrcID[p.ParentID] {
+			depth[t]++
+		}
+	}
+	slices.SortStableFunc(templates, func(a, b *models.TemplateSettings) int { return depth[a] - depth[b] })
+
+	var sum importSummary
+	var errs []error
+	for _, t := range templates {
+		missingParent := t.ParentID != 0 && parentNames[t] == ""
+		t.ID, t.ParentID = 0, 0
+		if old := findTemplateByName(existing, t.Name); old != nil {
+			t.ID = old.ID
+		}
+		if name := parentNames[t]; name != "" {
+			if p := findTemplateByName(existing, name); p != nil {
+				t.ParentID = p.ID
+			} else {
+				missingParent = true
+			}
+		}
+		created := t.ID == 0
+		err := validateTemplate(t)
+		if missingParent {
+			err = i18n.Errorf("error.cli.parent_missing")
+		}
+		if err == nil {
+			err = database.SaveTemplate(t)
+		}
+		if err == nil {
+			err = database.SaveTemplateElements(t.ID, t.Elements)
+		}
+		if err == nil {
+			err = database.SaveTemplateAssets(t.ID, t.Assets)
+		}
+		if err != nil {
+			sum.Failed++
+			errs = append(errs, i18n.Errorf("error.wrap.template", t.Name, err))
+			continue
+		}
+		if created {
+			existing = append(existing, t)
+			sum.Created++
+		} else {
+			sum.Updated++
+		}
+	}
+	if err := sum.print(); err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+// templatesAssets verwaltet mitgelieferte Schriften (.ttf, .otf) und Bilder
+// (.png, .jpg) eines Templates
+func templatesAssets(args []string) error {
+	fs := newFlagSet("templates assets")
+	id := fs.Int("id", 0, i18n.T("cli.flag.template_id"))
+	add := fs.String("add", "", i18n.T("cli.flag.asset_add"))
+	remove := fs.String("remove", "", i18n.T("cli.flag.asset_remove"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(fs, "id"); err != nil {
+		return err
+	}
+
+	t, err := database.LoadTemplate(*id)
+	if err != nil {
+		return i18n.Errorf("error.cli.template_not_found", *id, err)
+	}
+
+	changed := false
+	if *remove != "" {
+		a := t.Asset(*remove)
+		if a == nil {
+			return i18n.Errorf("error.cli.asset_foreign", *remove)
+		}
+		t.Assets = slices.DeleteFunc(t.Assets, func(x *models.TemplateAsset) bool { return x == a })
+		changed = true
+	}
+	if *add != "" {
+		data, err := os.ReadFile(*add)
+		if err != nil {
+			return err
+		}
+		a := &models.TemplateAsset{Name: filepath.Base(*add), Data: data}
+		switch strings.ToLower(filepath.Ext(a.Name)) {
+		case ".ttf", ".otf":
+			a.Kind = models.AssetFont
+		case ".png", ".jpg", ".jpeg":
+			a.Kind = models.AssetImage
+		default:
+			return i18n.Errorf("error.cli.asset_type", a.Name)
+		}
+		if len(data) > templatepack.MaxAssetSize {
+			return i18n.Errorf("error.templatepack.file_size", a.Name, templatepack.MaxAssetSize>>20)
+		}
+		if err := render.ValidateAsset(a); err != nil {
+			return err
+		}
+		if old := t.Asset(a.Name); old != nil {
+			*old = *a
+		} else {
+			t.Assets = append(t.Assets, a)
+		}
+		changed = true
+	}
+	if changed {
+		// Bilder, die noch von Elementen benutzt werden, dürfen nicht fehlen
+		if err := validateTemplate(t); err != nil {
+			return err
+		}
+		if err := database.SaveTemplateAssets(t.ID, t.Assets); err != nil {
+			return err
+		}
+	}
+
+	var rows [][]string
+	for _, a := range t.Assets {
+		name := a.Name
+		if a.Kind == models.AssetFont {
+			name += " " + i18n.T("cli.family", render.FontFamily(a.Name))
+		}
+		rows = append(rows, []string{name, a.Kind, strconv.Itoa(len(a.Data))})
+	}
+	if t.Assets == nil {
+		t.Assets = []*models.TemplateAsset{}
+	}
+	return printTable(t.Assets, []string{"cli.col.file", "cli.col.kind", "cli.col.bytes"}, rows)
+}
+
+// templatesPack exportiert ein Template als weitergebbares Paket
+func templatesPack(args []string) error {
+	fs := newFlagSet("templates pack")
+	ref := fs.String("template", "", i18n.T("cli.flag.template_id_or_name"))
+	out := fs.String("o", "", i18n.T("cli.flag.pack_out"))
+	format := fs.String("format", "", i18n.T("cli.flag.pack_format"))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(fs, "template", "o"); err != nil {
+		return err
+	}
+	if *format == "" {
+		*format = "zip"
+		if strings.EqualFold(filepath.Ext(*out), ".json") {
+			*format = "json"
+		}
+	}
+
+	found, err := findTemplate(*ref)
+	if err != nil {
+		return err
+	}
+	// Pakete sind eigenständig: Geerbtes wird übernommen, Theme-Verweise bleiben
+	t, err := database.FlattenTemplate(found.ID)
+	if err != nil {
+		return err
+	}
+	p := templatepack.New(t)
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	switch *format {
+	case "zip":
+		err = p.WriteZIP(f)
+	case "json":
+		err = p.WriteJSON(f)
+	default:
+		err = i18n.Errorf("error.cli.pack_format", *format)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(*out)
+		return err
+	}
+	summary := map[string]any{"File": *out, "Template": t.Name, "Elements": len(t.Elements), "Assets": len(t.Assets)}
+	return printResult(summary, "cli.msg.packed",
+		t.Name, len(t.Elements), len(t.Assets), *out)
+}
+
+// templatesUnpack installiert ein Paket aus pack als neues Template
+func templatesUnpack(args []string) error {
+	fs := newFlagSet("templates unpack")
+	in := fs.String("i", "", i18n.T("cli.flag.unpack_in"))
+	conflict := fs.String("conflict", models.ConflictRename,
+		i18n.T("cli.flag.unpack_conflict", strings.Join(models.ConflictPolicies(), ", ")))
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if err := required(fs, "i"); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(*in)
+	if err != nil {
+		return err
+	}
+	p, err := templatepack.Decode(data)
+	if err != nil {
+		return err
+	}
+	if err := validateTemplate(p.TemplateSettings()); err != nil {
+		return err
+	}
+	t, err := templatepack.Install(p, *conflict)
+	if err != nil {
+		return err
+	}
+	return printResult(t, "cli.msg.installed", t.Name, t.ID)
+}
+
+func findTemplateByName(templates []*models.TemplateSettings, name string) *models.TemplateSettings {
+	for _, t := range templates {
+		if strings.EqualFold(t.Name, name) {
+			return t
+		}
+	}
+	return nil
+}
+
+// findTemplate sucht ein Template über ID oder Namen
+func findTemplate(ref string) (*models.TemplateSettings, error) {
+	if id, err := strconv.Atoi(ref); err == nil {
+		return database.LoadTemplate(id)
+	}
+	templates, err := database.LoadTemplates()
+	if err != nil {
+		return nil, err
+	}
+	if t := findTemplateByName(templates, ref); t != nil {
+		return t, nil
+	}
+	return nil, i18n.Errorf("error.cli.template_name_not_found", ref)
+}
@@ -0,0 +1,140 @@
+// internal/api/api.go
+
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/live"
+)
+
+// Server stellt die REST-API unter /api/ bereit
+type Server struct {
+	live *live.Manager
+}
+
+// New erzeugt die API für die Live-Spiele des Managers
+func New(manager *live.Manager) *Server {
+	return &Server{live: manager}
+}
+
+// Register trägt alle Routen in mux ein
+func (s *Server) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/teams", s.listTeams)
+	mux.HandleFunc("GET /api/teams/{id}", s.getTeam)
+	mux.HandleFunc("PUT /api/teams/{id}", s.updateTeam)
+	mux.HandleFunc("GET /api/templates", s.listTemplates)
+	mux.HandleFunc("GET /api/fields", s.listFields)
+
+	mux.HandleFunc("GET /api/matches", s.listMatches)
+	mux.HandleFunc("GET /api/matches/{id}", s.getMatch)
+	mux.HandleFunc("PUT /api/matches/{id}", s.updateMatch)
+	mux.HandleFunc("GET /api/matches/{id}/audit", s.matchAudit)
+	mux.HandleFunc("GET /api/matches/{id}/events", s.matchEvents)
+	mux.HandleFunc("GET /api/calendar.ics", s.calendar)
+
+	mux.HandleFunc("GET /api/live", s.listLive)
+	mux.HandleFunc("GET /api/live/{field}", s.getLive)
+	mux.HandleFunc("POST /api/live/{field}/start", s.startLive)
+	mux.HandleFunc("POST /api/live/{field}/stop", s.stopLive)
+	mux.HandleFunc("POST /api/live/{field}/score", s.liveScore)
+	mux.HandleFunc("POST /api/live/{field}/clock", s.liveClock)
+	mux.HandleFunc("POST /api/live/{field}/{action}", s.liveAction)
+}
+
+// apiError ordnet einem Fehler den passenden HTTP-Status zu
+type apiError struct {
+	status int
+	err    error
+}
+
+func (e *apiError) Error() string { return e.err.Error() }
+func (e *apiError) Unwrap() error { return e.err }
+
+func badRequest(err error) error {
+	return &apiError{status: http.StatusBadRequest, err: err}
+}
+
+func notFound(err error) error {
+	return &apiError{status: http.StatusNotFound, err: err}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Print(i18n.T("log.api_write", err))
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var ae *apiError
+	switch {
+	case errors.As(err, &ae):
+		status = ae.status
+	case errors.Is(err, sql.ErrNoRows):
+		status = http.StatusNotFound
+	case errors.Is(err, database.ErrMatchFinished):
+		status = http.StatusConflict
+	}
+	if status == http.StatusInternalServerError {
+		log.Printf("api: %v", err)
+	}
+	body := map[string]string{"error": err.Error()}
+	if key := i18n.Key(err); key != "" {
+		body["key"] = key
+	}
+	writeJSON(w, status, body)
+}
+
+func decode(r *http.Request, v any) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return badRequest(i18n.Errorf("error.invalid_json", err))
+	}
+	return nil
+}
+
+func pathID(r *http.Request, name string) (int, error) {
+	id, err := strconv.Atoi(r.PathValue(name))
+	if err != nil || id < 0 {
+		return 0, badRequest(i18n.Errorf("error.invalid_id", r.PathValue(name)))
+	}
+	return id, nil
+}
+
+func (s *Server) listTeams(w http.ResponseWriter, r *http.Request) {
+	teams, err := database.LoadTeams()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, teams)
+}
+
+func (s *Server) listTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := database.LoadTemplates()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, templates)
+}
+
+func (s *Server) listFields(w http.ResponseWriter, r *http.Request) {
+	fields, err := database.LoadFields(0)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, fields)
+}
@@ -0,0 +1,27 @@
+// internal/api/calendar.go
+
+package api
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/KernTom/scoreboard-manager/internal/ical"
+)
+
+// calendar liefert den Spielplan als iCalendar zum Abonnieren, mit
+// ?competition=... nur die Spiele eines Wettbewerbs
+func (s *Server) calendar(w http.ResponseWriter, r *http.Request) {
+	cal, err := ical.Schedule(r.URL.Query().Get("competition"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var buf bytes.Buffer
+	if err := ical.Write(&buf, cal); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,271 @@
+// internal/api/live.go
+
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/live"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+	"github.com/KernTom/scoreboard-manager/internal/tournament"
+)
+
+func (s *Server) listLive(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.live.List())
+}
+
+func (s *Server) engine(r *http.Request) (*live.Engine, error) {
+	field, err := pathID(r, "field")
+	if err != nil {
+		return nil, err
+	}
+	e, ok := s.live.Get(field)
+	if !ok {
+		return nil, notFound(i18n.Errorf("error.field_idle", field))
+	}
+	return e, nil
+}
+
+func (s *Server) getLive(w http.ResponseWriter, r *http.Request) {
+	e, err := s.engine(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, e.Snapshot())
+}
+
+// startLive übernimmt ein Spiel auf das Feld. Ohne MatchID wird das aktuelle
+// Spiel des Feldes laut Spielplan genommen.
+func (s *Server) startLive(w http.ResponseWriter, r *http.Request) {
+	field, err := pathID(r, "field")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req struct{ MatchID int }
+	if err := decode(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	var m *models.Match
+	if req.MatchID != 0 {
+		m, err = database.LoadMatch(req.MatchID)
+	} else {
+		m, err = database.CurrentMatchForField(field, time.Now())
+		if errors.Is(err, database.ErrNoCurrentMatch) {
+			err = notFound(i18n.Errorf("error.field_no_match", field))
+		}
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if m.Status == models.MatchFinished || m.Status == models.MatchAbandoned {
+		writeError(w, database.ErrMatchFinished)
+		return
+	}
+	if m.FieldID != field {
+		writeError(w, badRequest(i18n.Errorf("error.match_not_on_field", m.ID, field)))
+		return
+	}
+
+	tpl, err := database.ResolveMatchTemplate(m)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	// Sicherung nur vor dem echten Anstoß, nicht beim Fortsetzen
+	if m.Status == models.MatchScheduled {
+		database.SnapshotBeforeLive(m.ID)
+	}
+	e, err := s.live.Start(m, tpl)
+	if err != nil {
+		writeError(w, &apiError{status: http.StatusConflict, err: err})
+		return
+	}
+	writeJSON(w, http.StatusOK, e.Snapshot())
+}
+
+// stopLive nimmt ein Spiel vom Feld. Laufende Spiele bleiben als Checkpoint erhalten.
+func (s *Server) stopLive(w http.ResponseWriter, r *http.Request) {
+	e, err := s.engine(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	s.live.Stop(e.Match().FieldID)
+	if err := Checkpoint(e); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, e.Snapshot())
+}
+
+func (s *Server) liveScore(w http.ResponseWriter, r *http.Request) {
+	e, err := s.engine(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req struct {
+		Side  string
+		Delta int
+	}
+	if err := decode(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := e.AddScore(req.Side, req.Delta); err != nil {
+		writeError(w, badRequest(err))
+		return
+	}
+	writeJSON(w, http.StatusOK, e.Snapshot())
+}
+
+// liveClock startet bzw. stoppt die Uhr oder setzt die Spielzeit (Sekunden)
+func (s *Server) liveClock(w http.ResponseWriter, r *http.Request) {
+	e, err := s.engine(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req struct {
+		Running *bool
+		Elapsed *int
+	}
+	if err := decode(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	if req.Elapsed != nil {
+		if err := e.SetClock(time.Duration(*req.Elapsed) * time.Second); err != nil {
+			writeError(w, badRequest(err))
+			return
+		}
+	}
+	if req.Running != nil {
+		if *req.Running {
+			err = e.StartClock()
+		} else {
+			e.StopClock()
+		}
+		if err != nil {
+			writeError(w, badRequest(err))
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, e.Snapshot())
+}
+
+// liveAction führt einen Statuswechsel aus und speichert ihn
+func (s *Server) liveAction(w http.ResponseWriter, r *http.Request) {
+	e, err := s.engine(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	switch action := r.PathValue("action"); action {
+	case "kickoff":
+		err = e.Kickoff()
+	case "halftime":
+		err = e.Halftime()
+	case "next-period":
+		err = e.NextPeriod()
+	case "abandon":
+		err = e.Abandon()
+	case "finish":
+		err = s.finish(e)
+	default:
+		writeError(w, notFound(i18n.Errorf("error.unknown_action", action)))
+		return
+	}
+	if err != nil {
+		writeError(w, badRequest(err))
+		return
+	}
+
+	if st := e.Snapshot(); st.Status != models.MatchFinished {
+		if err := database.SetMatchStatus(st.MatchID, st.Status); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, e.Snapshot())
+}
+
+// finish beendet das Live-Spiel und speichert den Endstand inkl.
+// Weiterleitung im Turnierbaum; gespeichert wird nur, wenn die Engine das
+// Spiel beenden darf
+func (s *Server) finish(e *live.Engine) error {
+	err := e.FinishWith(func(st models.LiveState) error {
+		m, err := database.LoadMatch(st.MatchID)
+		if err != nil {
+			return err
+		}
+		return tournament.FinalizeMatch(m, st.HomeScore, st.AwayScore)
+	})
+	if err != nil {
+		return err
+	}
+	matchID := e.Match().ID
+	if err := database.SaveMatchEvents(matchID, e.Events()); err != nil {
+		return err
+	}
+	return database.DeleteLiveCheckpoint(matchID)
+}
+
+// Checkpoint sichert ein Live-Spiel; beendete Spiele werden nur noch protokolliert
+func Checkpoint(e *live.Engine) error {
+	st := e.Snapshot()
+	if st.Status == models.MatchFinished || st.Status == models.MatchAbandoned {
+		if err := database.SaveMatchEvents(st.MatchID, e.Events()); err != nil {
+			return err
+		}
+		return database.DeleteLiveCheckpoint(st.MatchID)
+	}
+	return database.SaveLiveCheckpoint(st, e.Events())
+}
+
+// Resume startet alle gesicherten Live-Spiele erneut (Uhr angehalten)
+func Resume(manager *live.Manager) error {
+	states, err := database.LoadLiveCheckpoints()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, st := range states {
+		if err := resumeOne(manager, st); err != nil {
+			errs = append(errs, i18n.Errorf("error.wrap.match_id", st.MatchID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func resumeOne(manager *live.Manager, st models.LiveState) error {
+	m, err := database.LoadMatch(st.MatchID)
+	if err != nil {
+		return err
+	}
+	tpl, err := database.ResolveMatchTemplate(m)
+	if err != nil {
+		return err
+	}
+	events, err := database.LoadMatchEvents(st.MatchID)
+	if err != nil {
+		return err
+	}
+	m.FieldID = st.FieldID
+	e, err := manager.Start(m, tpl)
+	if err != nil {
+		return err
+	}
+	e.Resume(st, events)
+	return nil
+}
@@ -0,0 +1,107 @@
+// internal/api/live_test.go
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/live"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// openTestServer öffnet eine leere Datenbank mit einem Feld und liefert
+// die API samt Feld und Pfad der Datenbank
+func openTestServer(t *testing.T) (*http.ServeMux, *models.Field, string) {
+	t.Helper()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	if err := database.Open(dbPath); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(database.Close)
+
+	tpl := &models.TemplateSettings{Name: "Standard", Sportart: "Fußball", Width: 1280, Height: 720,
+		PeriodLabel: "Halbzeit", PeriodsCount: 2, PeriodDuration: 45, GameclockMode: models.GameclockUpMMSS}
+	if err := database.SaveTemplate(tpl); err != nil {
+		t.Fatal(err)
+	}
+	venue := &models.Venue{Name: "Sportpark"}
+	if err := database.SaveVenue(venue); err != nil {
+		t.Fatal(err)
+	}
+	field := &models.Field{VenueID: venue.ID, Name: "Feld 1", TemplateID: tpl.ID}
+	if err := database.SaveField(field); err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	New(live.NewManager()).Register(mux)
+	return mux, field, dbPath
+}
+
+func post(mux *http.ServeMux, path, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	return rec
+}
+
+func TestStartLiveFieldWithoutMatch(t *testing.T) {
+	mux, field, _ := openTestServer(t)
+
+	rec := post(mux, fmt.Sprintf("/api/live/%d/start", field.ID), "{}")
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("Status %d, erwartet %d: %s", rec.Code, http.StatusNotFound, rec.Body)
+	}
+	var body map[string]string
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body["key"] != "error.field_no_match" {
+		t.Errorf("Schlüssel %q, erwartet error.field_no_match", body["key"])
+	}
+}
+
+func TestStartLiveCurrentMatch(t *testing.T) {
+	mux, field, dbPath := openTestServer(t)
+	var teams []*models.Team
+	for _, name := range []string{"Adler", "Bären"} {
+		team := &models.Team{Name: name, Sportart: "Fußball"}
+		if err := database.SaveTeam(team); err != nil {
+			t.Fatal(err)
+		}
+		teams = append(teams, team)
+	}
+	m := &models.Match{Sportart: "Fußball", Team1: teams[0], Team2: teams[1], FieldID: field.ID, Field: field.Name,
+		GameTime: time.Now().Add(time.Hour), Status: models.MatchScheduled}
+	if err := database.SaveMatches(m); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := post(mux, fmt.Sprintf("/api/live/%d/start", field.ID), "{}")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Status %d, erwartet %d: %s", rec.Code, http.StatusOK, rec.Body)
+	}
+	var st models.LiveState
+	if err := json.NewDecoder(rec.Body).Decode(&st); err != nil {
+		t.Fatal(err)
+	}
+	if st.MatchID != m.ID {
+		t.Errorf("Spiel %d gestartet, erwartet %d", st.MatchID, m.ID)
+	}
+
+	// vor dem Anstoß genau ein Snapshot
+	snaps, err := database.ListSnapshots(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := fmt.Sprintf("%s-%d", database.SnapshotLive, m.ID); len(snaps) != 1 || snaps[0].Reason != want {
+		t.Errorf("Snapshots %v, erwartet einen mit Anlass %s", snaps, want)
+	}
+}
@@ -0,0 +1,173 @@
+// internal/api/matches.go
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+	"github.com/KernTom/scoreboard-manager/internal/tournament"
+)
+
+// matchUpdate enthält die zu ändernden Felder, nicht gesetzte bleiben unverändert.
+// Beendete Spiele lassen sich nur mit Reason (Override) ändern, siehe
+// tournament.SaveMatch.
+type matchUpdate struct {
+	TeamHome    *int
+	TeamAway    *int
+	TemplateID  *int
+	Competition *string
+	GameTime    *time.Time
+	FieldID     *int
+	ScoreHome   *int
+	ScoreAway   *int
+	Status      *string
+	Reason      string
+	Actor       string
+}
+
+func (s *Server) listMatches(w http.ResponseWriter, r *http.Request) {
+	var matches []*models.Match
+	var err error
+	if c := r.URL.Query().Get("competition"); c != "" {
+		matches, err = database.LoadMatchesByCompetition(c)
+	} else {
+		matches, err = database.LoadMatches()
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, matches)
+}
+
+func (s *Server) getMatch(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	m, err := database.LoadMatch(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, m)
+}
+
+func (s *Server) updateMatch(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req matchUpdate
+	if err := decode(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	if _, ok := s.live.ByMatch(id); ok {
+		writeError(w, &apiError{status: http.StatusConflict, err: i18n.Errorf("error.match_is_live")})
+		return
+	}
+
+	m, err := database.LoadMatch(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	finished := m.Status == models.MatchFinished
+	if finished && req.Reason == "" {
+		writeError(w, database.ErrMatchFinished)
+		return
+	}
+
+	if req.TeamHome != nil {
+		m.Team1 = &models.Team{ID: *req.TeamHome}
+	}
+	if req.TeamAway != nil {
+		m.Team2 = &models.Team{ID: *req.TeamAway}
+	}
+	if req.TemplateID != nil {
+		m.TemplateSettings = &models.TemplateSettings{ID: *req.TemplateID}
+	}
+	if req.Competition != nil {
+		m.Competition = *req.Competition
+	}
+	if req.GameTime != nil {
+		m.GameTime = *req.GameTime
+	}
+	if req.FieldID != nil {
+		m.FieldID = *req.FieldID
+		m.Field = ""
+		if m.FieldID != 0 {
+			f, err := database.LoadField(m.FieldID)
+			if err != nil {
+				writeError(w, notFound(i18n.Errorf("error.field_not_found")))
+				return
+			}
+			m.Field = f.Name
+		}
+	}
+	if req.ScoreHome != nil {
+		m.ScoreHome = req.ScoreHome
+	}
+	if req.ScoreAway != nil {
+		m.ScoreAway = req.ScoreAway
+	}
+
+	var status string
+	if req.Status != nil {
+		status = *req.Status
+	}
+	if err := tournament.SaveMatch(m, status, req.Reason, req.Actor); err != nil {
+		// Fehler mit Katalogschlüssel stammen aus den Prüfungen, nicht aus der Datenbank
+		if i18n.Key(err) != "" {
+			err = badRequest(err)
+		}
+		writeError(w, err)
+		return
+	}
+
+	m, err = database.LoadMatch(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, m)
+}
+
+func (s *Server) matchAudit(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	entries, err := database.LoadMatchAudit(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+func (s *Server) matchEvents(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if e, ok := s.live.ByMatch(id); ok {
+		writeJSON(w, http.StatusOK, e.Events())
+		return
+	}
+	events, err := database.LoadMatchEvents(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, events)
+}
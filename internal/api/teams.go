@@ -0,0 +1,79 @@
+// internal/api/teams.go
+
+package api
+
+import (
+	"maps"
+	"net/http"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// teamUpdate enthält die zu ändernden Namen, nicht gesetzte bleiben
+// unverändert. In AltNames entfernt ein leerer Name den Eintrag des Wettbewerbs.
+type teamUpdate struct {
+	Name         *string
+	ShortName    *string
+	Abbreviation *string
+	AltNames     map[string]string
+}
+
+func (s *Server) getTeam(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	t, err := database.LoadTeam(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, t)
+}
+
+func (s *Server) updateTeam(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req teamUpdate
+	if err := decode(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	t, err := database.LoadTeam(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	for _, f := range []struct {
+		value *string
+		dst   *string
+	}{
+		{req.Name, &t.Name}, {req.ShortName, &t.ShortName}, {req.Abbreviation, &t.Abbreviation},
+	} {
+		if f.value != nil {
+			*f.dst = *f.value
+		}
+	}
+	if req.AltNames != nil {
+		if t.AltNames == nil {
+			t.AltNames = map[string]string{}
+		}
+		maps.Copy(t.AltNames, req.AltNames)
+	}
+	models.NormalizeTeamNames(t)
+	if err := models.ValidateTeamNames(t); err != nil {
+		writeError(w, badRequest(err))
+		return
+	}
+	if err := database.SaveTeam(t); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, t)
+}
@@ -0,0 +1,363 @@
+// internal/config/config.go
+
+// Package config lädt die Einstellungen aller Programme (Admin, Server,
+// CLI) aus einer TOML-Datei. Gesucht wird in dieser Reihenfolge:
+//
+//  1. die Datei aus der Umgebungsvariable SCOREBOARD_CONFIG
+//  2. scoreboard.toml im Arbeitsverzeichnis
+//  3. scoreboard.toml neben der exe
+//  4. scoreboard-manager/scoreboard.toml im Benutzer-Konfigurationsverzeichnis
+//     (z.B. %AppData% unter Windows)
+//
+// Ohne Datei gelten die eingebauten Vorgaben. Umgebungsvariablen der Form
+// SCOREBOARD_<ABSCHNITT>_<SCHLÜSSEL> überschreiben einzelne Werte aus der
+// Datei, z.B. SCOREBOARD_DATABASE oder SCOREBOARD_SERVER_ADDR; Flags der
+// Programme haben Vorrang vor beidem.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// FileName ist der Name der Konfigurationsdatei
+const FileName = "scoreboard.toml"
+
+// EnvPrefix steht vor allen Umgebungsvariablen; EnvFile wählt die Datei
+const (
+	EnvPrefix = "SCOREBOARD_"
+	EnvFile   = EnvPrefix + "CONFIG"
+)
+
+// Config sind alle Einstellungen. Relative Pfade aus der Datei gelten
+// relativ zu ihrem Verzeichnis, Vorgaben und Umgebungsvariablen relativ zum
+// Arbeitsverzeichnis (Admin: Schriften und Icons neben der exe).
+type Config struct {
+	Path string `toml:"-"` // geladene Datei, leer bei eingebauten Vorgaben
+
+	Database  string `toml:"database"`  // Pfad zur SQLite-Datenbank
+	FontsDir  string `toml:"fonts_dir"` // zusätzliche Schriften (TTF/OTF)
+	IconsDir  string `toml:"icons_dir"` // Icons der Admin-Oberfläche
+	Snapshots int    `toml:"snapshots"` // so viele Snapshots bleiben erhalten
+	Locale    string `toml:"locale"`    // Sprache der Oberflächen, z.B. "de" oder "en"
+
+	Fonts    Fonts    `toml:"fonts"`
+	Template Template `toml:"template"`
+	Server   Server   `toml:"server"`
+	Sports   []Sport  `toml:"sports"` // Sportarten für neue Datenbanken
+}
+
+// Fonts sind die Schriften der Admin-Oberfläche, z.B. der Tabellenansicht
+type Fonts struct {
+	Family     string `toml:"family"`
+	ScoreSize  int    `toml:"score_size"` // in Punkt
+	PeriodSize int    `toml:"period_size"`
+}
+
+// Template sind die Vorgaben für neue Templates in Admin und CLI
+type Template struct {
+	Font          string `toml:"font"` // auch als Ausweichkette, z.B. "DS-Digital, monospace"
+	ClockSize     int    `toml:"clock_size"`
+	PeriodSize    int    `toml:"period_size"`
+	ScoreSize     int    `toml:"score_size"`
+	SeparatorSize int    `toml:"separator_size"` // alle in Pixel
+}
+
+// Server sind die Einstellungen von scoreboard-server
+type Server struct {
+	Addr    string `toml:"addr"`
+	TLSCert string `toml:"tls_cert"`
+	TLSKey  string `toml:"tls_key"`
+}
+
+// Sport ist eine Sportart, die beim Anlegen der Datenbank eingetragen wird
+type Sport struct {
+	Name           string   `toml:"name"`
+	PeriodLabel    string   `toml:"period_label"`
+	Periods        int      `toml:"periods"`
+	PeriodDuration int      `toml:"period_duration"` // in Minuten
+	ClockFormat    string   `toml:"clock_format"`    // "MM:SS" oder "Minuten"
+	ClockDirection string   `toml:"clock_direction"` // "Up" oder "Down"
+	PointsWin      int      `toml:"points_win"`
+	PointsDraw     int      `toml:"points_draw"`
+	PointsLoss     int      `toml:"points_loss"`
+	Ranking        string   `toml:"ranking"`      // models.RankingPoints oder models.RankingWinPercentage
+	TieBreakers    []string `toml:"tie_breakers"` // z.B. ["goal_difference", "goals_for"]
+}
+
+// Default liefert die eingebauten Vorgaben
+func Default() *Config {
+	c := &Config{
+		Database:  "settings.db",
+		FontsDir:  "fonts",
+		IconsDir:  "icons",
+		Snapshots: database.SnapshotKeep,
+		Locale:    i18n.Fallback,
+		Fonts:     Fonts{Family: "DS-Digital", ScoreSize: 36, PeriodSize: 24},
+		Template:  Template{Font: "Segoe UI", ClockSize: 32, PeriodSize: 20, ScoreSize: 32, SeparatorSize: 28},
+		Server:    Server{Addr: ":8080"},
+	}
+	for _, s := range database.DefaultSports {
+		c.Sports = append(c.Sports, Sport{
+			Name: s.Sportart, PeriodLabel: s.PeriodLabel, Periods: s.PeriodsCount, PeriodDuration: s.PeriodDuration,
+			ClockFormat: s.ClockFormat, ClockDirection: s.ClockDirection,
+			PointsWin: s.PointsWin, PointsDraw: s.PointsDraw, PointsLoss: s.PointsLoss,
+			Ranking: s.RankingMode, TieBreakers: strings.Split(s.TieBreakers, ","),
+		})
+	}
+	return c
+}
+
+// Load sucht die Konfigurationsdatei (siehe Paketbeschreibung), wendet die
+// Umgebungsvariablen an und prüft das Ergebnis
+func Load() (*Config, error) {
+	path, err := Find()
+	if err != nil {
+		return nil, err
+	}
+	return LoadFile(path)
+}
+
+// Find liefert den Pfad der Konfigurationsdatei, leer wenn es keine gibt
+func Find() (string, error) {
+	if path := os.Getenv(EnvFile); path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return "", i18n.Errorf("error.wrap.named", EnvFile, err)
+		}
+		return path, nil
+	}
+	for _, path := range SearchPath() {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", nil
+}
+
+// SearchPath liefert die Orte, an denen Find nach der Datei sucht
+func SearchPath() []string {
+	paths := []string{FileName}
+	if exe, err := os.Executable(); err == nil {
+		paths = append(paths, filepath.Join(filepath.Dir(exe), FileName))
+	}
+	if dir, err := os.UserConfigDir(); err == nil {
+		paths = append(paths, filepath.Join(dir, "scoreboard-manager", FileName))
+	}
+	return paths
+}
+
+// LoadFile liest die Datei unter path über die Vorgaben, ohne path nur die
+// Vorgaben; danach gelten die Umgebungsvariablen. Unbekannte Schlüssel sind
+// ein Fehler, damit Tippfehler nicht still ignoriert werden.
+func LoadFile(path string) (*Config, error) {
+	c := Default()
+	if path != "" {
+		// eine Liste in der Datei ersetzt die Vorgaben, statt sie zu ergänzen
+		c.Sports = nil
+		md, err := toml.DecodeFile(path, c)
+		if err != nil {
+			return nil, i18n.Errorf("error.config.file", path, err)
+		}
+		if !md.IsDefined("sports") {
+			c.Sports = Default().Sports
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			keys := make([]string, len(undecoded))
+			for i, k := range undecoded {
+				keys[i] = k.String()
+			}
+			return nil, i18n.Errorf("error.config.unknown_keys", path, strings.Join(keys, ", "))
+		}
+		c.Path = path
+		c.resolvePaths(md, filepath.Dir(path))
+	}
+	if err := c.applyEnv(os.Environ()); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		if c.Path != "" {
+			return nil, i18n.Errorf("error.config.file", c.Path, err)
+		}
+		return nil, i18n.Errorf("error.config.defaults", err)
+	}
+	return c, nil
+}
+
+// resolvePaths macht relative Pfade aus der Datei zu Pfaden neben der Datei
+func (c *Config) resolvePaths(md toml.MetaData, dir string) {
+	for _, p := range []struct {
+		key  []string
+		path *string
+	}{
+		{[]string{"database"}, &c.Database},
+		{[]string{"fonts_dir"}, &c.FontsDir},
+		{[]string{"icons_dir"}, &c.IconsDir},
+		{[]string{"server", "tls_cert"}, &c.Server.TLSCert},
+		{[]string{"server", "tls_key"}, &c.Server.TLSKey},
+	} {
+		if md.IsDefined(p.key...) && *p.path != "" && !filepath.IsAbs(*p.path) {
+			*p.path = filepath.Join(dir, *p.path)
+		}
+	}
+}
+
+// EnvVars liefert alle Umgebungsvariablen, die einen Wert überschreiben
+func EnvVars() []string {
+	var names []string
+	walkFields(reflect.ValueOf(Default()).Elem(), EnvPrefix, func(name string, _ reflect.Value) {
+		names = append(names, name)
+	})
+	return names
+}
+
+// applyEnv überschreibt Werte aus Umgebungsvariablen (environ wie os.Environ)
+func (c *Config) applyEnv(environ []string) error {
+	env := map[string]string{}
+	for _, kv := range environ {
+		if k, v, ok := strings.Cut(kv, "="); ok && strings.HasPrefix(k, EnvPrefix) {
+			env[k] = v
+		}
+	}
+	var errs []error
+	walkFields(reflect.ValueOf(c).Elem(), EnvPrefix, func(name string, v reflect.Value) {
+		s, ok := env[name]
+		if !ok {
+			return
+		}
+		switch v.Kind() {
+		case reflect.String:
+			v.SetString(s)
+		case reflect.Int:
+			n, err := strconv.Atoi(strings.TrimSpace(s))
+			if err != nil {
+				errs = append(errs, i18n.Errorf("error.config.not_a_number", name, s))
+				return
+			}
+			v.SetInt(int64(n))
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// walkFields ruft fn für jeden einfachen Wert mit seinem Variablennamen auf;
+// Listen wie die Sportarten lassen sich nur in der Datei setzen
+func walkFields(v reflect.Value, prefix string, fn func(name string, v reflect.Value)) {
+	t := v.Type()
+	for i := range t.NumField() {
+		tag := t.Field(i).Tag.Get("toml")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		name := prefix + strings.ToUpper(tag)
+		switch f := v.Field(i); f.Kind() {
+		case reflect.Struct:
+			walkFields(f, name+"_", fn)
+		case reflect.String, reflect.Int:
+			fn(name, f)
+		}
+	}
+}
+
+// Validate prüft Wertebereiche und Sportarten
+func (c *Config) Validate() error {
+	var errs []error
+	if strings.TrimSpace(c.Database) == "" {
+		errs = append(errs, i18n.Errorf("error.config.database"))
+	}
+	if c.Snapshots < 1 {
+		errs = append(errs, i18n.Errorf("error.config.snapshots", c.Snapshots))
+	}
+	if i18n.Normalize(c.Locale) == "" {
+		errs = append(errs, i18n.Errorf("error.config.locale", c.Locale, strings.Join(i18n.Locales(), ", ")))
+	}
+	if strings.TrimSpace(c.Fonts.Family) == "" || strings.TrimSpace(c.Template.Font) == "" {
+		errs = append(errs, i18n.Errorf("error.config.fonts"))
+	}
+	for _, s := range []struct {
+		key string
+		n   int
+	}{
+		{"fonts.score_size", c.Fonts.ScoreSize}, {"fonts.period_size", c.Fonts.PeriodSize},
+		{"template.clock_size", c.Template.ClockSize}, {"template.period_size", c.Template.PeriodSize},
+		{"template.score_size", c.Template.ScoreSize}, {"template.separator_size", c.Template.SeparatorSize},
+	} {
+		if s.n < 4 || s.n > 500 {
+			errs = append(errs, i18n.Errorf("error.config.font_size", s.key, s.n))
+		}
+	}
+	if (c.Server.TLSCert == "") != (c.Server.TLSKey == "") {
+		errs = append(errs, i18n.Errorf("error.config.tls"))
+	}
+
+	seen := map[string]bool{}
+	for i, s := range c.Sports {
+		what := fmt.Sprintf("sports[%d] %q", i, s.Name)
+		if strings.TrimSpace(s.Name) == "" {
+			errs = append(errs, i18n.Errorf("error.config.sport_name", i))
+		} else if seen[strings.ToLower(s.Name)] {
+			errs = append(errs, i18n.Errorf("error.config.sport_duplicate", what))
+		}
+		seen[strings.ToLower(s.Name)] = true
+		if s.Periods < 1 || s.PeriodDuration < 1 {
+			errs = append(errs, i18n.Errorf("error.config.sport_periods", what))
+		}
+		if s.ClockFormat != "MM:SS" && s.ClockFormat != "Minuten" {
+			errs = append(errs, i18n.Errorf("error.config.clock_format", what))
+		}
+		if s.ClockDirection != "Up" && s.ClockDirection != "Down" {
+			errs = append(errs, i18n.Errorf("error.config.clock_direction", what))
+		}
+		if s.Ranking != models.RankingPoints && s.Ranking != models.RankingWinPercentage {
+			errs = append(errs, i18n.Errorf("error.config.ranking", what, s.Ranking))
+		}
+		for _, tb := range s.TieBreakers {
+			if !slices.Contains([]string{models.TieBreakHeadToHead, models.TieBreakGoalDifference, models.TieBreakGoalsFor}, tb) {
+				errs = append(errs, i18n.Errorf("error.config.tie_breaker", what, tb))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Apply überträgt die Einstellungen der Datenbankschicht und wählt die
+// Sprache; vor database.Open aufrufen, damit eine neue Datenbank die
+// konfigurierten Sportarten erhält
+func (c *Config) Apply() {
+	_ = i18n.SetLocale(c.Locale) // von Validate geprüft
+	database.SnapshotKeep = c.Snapshots
+	database.DefaultSports = nil
+	for _, s := range c.Sports {
+		database.DefaultSports = append(database.DefaultSports, models.SportartDefinition{
+			Sportart: s.Name, PeriodLabel: s.PeriodLabel, PeriodsCount: s.Periods, PeriodDuration: s.PeriodDuration,
+			ClockFormat: s.ClockFormat, ClockDirection: s.ClockDirection,
+			PointsWin: s.PointsWin, PointsDraw: s.PointsDraw, PointsLoss: s.PointsLoss,
+			RankingMode: s.Ranking, TieBreakers: strings.Join(s.TieBreakers, ","),
+		})
+	}
+}
+
+// Write schreibt die Einstellungen als TOML, z.B. als Vorlage für eine eigene Datei
+func (c *Config) Write(w io.Writer) error {
+	return toml.NewEncoder(w).Encode(c)
+}
+
+// TemplateDefaults setzt Schriften und Größen eines neuen Templates auf die Vorgaben
+func (c *Config) TemplateDefaults(t *models.TemplateSettings) {
+	t.ClockFontFamily, t.ClockFontSize = c.Template.Font, c.Template.ClockSize
+	t.PeriodFontFamily, t.PeriodFontSize = c.Template.Font, c.Template.PeriodSize
+	t.ScoreFontFamily, t.ScoreFontSize = c.Template.Font, c.Template.ScoreSize
+	t.SeparatorFontFamily, t.SeparatorFontSize = c.Template.Font, c.Template.SeparatorSize
+}
@@ -0,0 +1,322 @@
+// internal/database/backup.go
+//
+// Sicherungen der Datenbank: Backup schreibt eine Kopie im laufenden
+// Betrieb (VACUUM INTO), Snapshot legt sie rotierend im Verzeichnis backups
+// neben der Datenbank ab. Automatisch passiert das vor Migrationen, vor
+// Importen und bevor ein Spiel auf die Live-Anzeige kommt. Restore spielt eine geprüfte Sicherung zurück.
+
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+)
+
+// Anlässe für Snapshots, Teil des Dateinamens
+const (
+	SnapshotManual    = "manuell"
+	SnapshotMigration = "migration"
+	SnapshotLive      = "live"
+	SnapshotRestore   = "restore"
+	SnapshotImport    = "import"
+)
+
+// SnapshotKeep ist die Zahl der Snapshots, die beim Rotieren erhalten bleiben
+var SnapshotKeep = 20
+
+// ErrCorrupt meldet eine Datenbank, die PRAGMA integrity_check nicht besteht
+var ErrCorrupt = i18n.Errorf("error.database.corrupt")
+
+// snapshotTime ist der Zeitstempel im Dateinamen, mit Millisekunden, damit
+// zwei Anstöße in derselben Sekunde sich nicht überschreiben
+const snapshotTime = "20060102-150405.000"
+
+// openPath ist der Pfad der mit Open geöffneten Datenbank
+var openPath string
+
+// Snapshot ist eine Sicherung im Verzeichnis backups
+type Snapshot struct {
+	Path    string
+	Reason  string
+	Created time.Time
+	Size    int64
+}
+
+// Backup schreibt eine konsistente Kopie der geöffneten Datenbank nach dest,
+// auch während andere Verbindungen schreiben. Eine vorhandene Datei wird erst
+// ersetzt, wenn die Kopie die Integritätsprüfung bestanden hat.
+func Backup(dest string) error {
+	if db == nil {
+		return i18n.Errorf("error.database.not_open")
+	}
+	if abs(dest) == abs(openPath) {
+		return i18n.Errorf("error.database.backup_self")
+	}
+	tmp := dest + ".tmp"
+	os.Remove(tmp)
+	if _, err := db.Exec(`VACUUM INTO ?`, tmp); err != nil {
+		return i18n.Errorf("error.database.backup", dest, err)
+	}
+	if problems, err := CheckFile(tmp); err != nil || len(problems) > 0 {
+		os.Remove(tmp)
+		return i18n.Errorf("error.database.backup_invalid", dest, corruptError(err, problems))
+	}
+	return os.Rename(tmp, dest)
+}
+
+// CreateSnapshot sichert die geöffnete Datenbank ins Verzeichnis backups und
+// löscht die ältesten Snapshots über SnapshotKeep hinaus
+func CreateSnapshot(reason string) (*Snapshot, error) {
+	dir := snapshotDir(openPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	path := filepath.Join(dir, snapshotPrefix(openPath)+now.Format(snapshotTime)+"-"+reason+".db")
+	if err := Backup(path); err != nil {
+		return nil, err
+	}
+	if err := rotateSnapshots(openPath); err != nil {
+		log.Print(i18n.T("log.snapshot_rotate", err))
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Snapshot{Path: path, Reason: reason, Created: now, Size: info.Size()}, nil
+}
+
+// ListSnapshots liefert die Snapshots der Datenbank unter path, neueste zuerst
+func ListSnapshots(path string) ([]*Snapshot, error) {
+	entries, err := os.ReadDir(snapshotDir(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	prefix := snapshotPrefix(path)
+	var snaps []*Snapshot
+	for _, e := range entries {
+		name := e.Name()
+		rest, ok := strings.CutPrefix(name, prefix)
+		if !ok || e.IsDir() || !strings.HasSuffix(name, ".db") || len(rest) < len(snapshotTime)+1 {
+			continue
+		}
+		created, err := time.ParseInLocation(snapshotTime, rest[:len(snapshotTime)], time.Local)
+		if err != nil {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+		snaps = append(snaps, &Snapshot{
+			Path:    filepath.Join(snapshotDir(path), name),
+			Reason:  strings.TrimSuffix(strings.TrimPrefix(rest[len(snapshotTime):], "-"), ".db"),
+			Created: created,
+			Size:    info.Size(),
+		})
+	}
+	slices.SortFunc(snaps, func(a, b *Snapshot) int { return b.Created.Compare(a.Created) })
+	return snaps, nil
+}
+
+func rotateSnapshots(path string) error {
+	snaps, err := ListSnapshots(path)
+	if err != nil || len(snaps) <= SnapshotKeep {
+		return err
+	}
+	for _, s := range snaps[SnapshotKeep:] {
+		if err := os.Remove(s.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snapshotDir ist das Verzeichnis backups neben der Datenbank
+func snapshotDir(path string) string {
+	return filepath.Join(filepath.Dir(path), "backups")
+}
+
+// snapshotPrefix trennt Snapshots verschiedener Datenbanken im selben Verzeichnis
+func snapshotPrefix(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)) + "-"
+}
+
+// SnapshotBeforeLive sichert, bevor ein Spiel auf die Live-Anzeige kommt; ein
+// Fehler hält das Spiel nicht auf. Nicht innerhalb einer Transaktion aufrufen,
+// VACUUM INTO würde sie so lange aufhalten.
+func SnapshotBeforeLive(matchID int) {
+	if _, err := CreateSnapshot(SnapshotLive + "-" + strconv.Itoa(matchID)); err != nil {
+		log.Print(i18n.T("log.snapshot_match", matchID, err))
+	}
+}
+
+// CheckFile prüft die Datenbankdatei unter path mit PRAGMA integrity_check,
+// ohne sie zu migrieren. Eine leere Liste bedeutet: in Ordnung.
+func CheckFile(path string) ([]string, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	conn, err := sql.Open("sqlite", "file:"+filepath.ToSlash(path)+"?mode=ro")
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	return integrityCheck(conn)
+}
+
+// integrityCheck liefert die Meldungen von PRAGMA integrity_check außer "ok"
+func integrityCheck(conn *sql.DB) ([]string, error) {
+	rows, err := conn.Query(`PRAGMA integrity_check`)
+	if err != nil {
+		// keine SQLite-Datei oder Kopf zerstört
+		return []string{err.Error()}, nil
+	}
+	defer rows.Close()
+	var problems []string
+	for rows.Next() {
+		var msg string
+		if err := rows.Scan(&msg); err != nil {
+			return nil, err
+		}
+		if msg != "ok" {
+			problems = append(problems, msg)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return append(problems, err.Error()), nil
+	}
+	return problems, nil
+}
+
+// Restore ersetzt die Datenbank unter path durch die Sicherung src. Die
+// Sicherung muss die Integritätsprüfung bestehen und Spiele und Teams
+// enthalten; der bisherige Stand wird vorher als Snapshot abgelegt, auch wenn
+// er beschädigt ist. War die Datenbank geöffnet, wird sie danach neu geöffnet.
+func Restore(path, src string) (*Snapshot, error) {
+	if abs(path) == abs(src) {
+		return nil, i18n.Errorf("error.database.restore_self")
+	}
+	problems, err := CheckFile(src)
+	if err != nil {
+		return nil, i18n.Errorf("error.database.restore_source", src, err)
+	}
+	if len(problems) > 0 {
+		return nil, i18n.Errorf("error.database.restore_source", src, corruptError(nil, problems))
+	}
+	if err := checkSchema(src); err != nil {
+		return nil, err
+	}
+
+	reopen := db != nil && abs(openPath) == abs(path)
+	if reopen {
+		Close()
+		db = nil
+	}
+
+	// Dateikopie statt VACUUM INTO, weil der alte Stand beschädigt sein kann
+	var saved *Snapshot
+	if info, err := os.Stat(path); err == nil && info.Size() > 0 {
+		dir := snapshotDir(path)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		saved = &Snapshot{
+			Path:    filepath.Join(dir, snapshotPrefix(path)+now.Format(snapshotTime)+"-"+SnapshotRestore+".db"),
+			Reason:  SnapshotRestore,
+			Created: now,
+			Size:    info.Size(),
+		}
+		if err := copyFile(path, saved.Path); err != nil {
+			return nil, i18n.Errorf("error.database.restore_snapshot", err)
+		}
+	}
+
+	tmp := path + ".restore"
+	if err := copyFile(src, tmp); err != nil {
+		return nil, err
+	}
+	for _, suffix := range []string{"-wal", "-shm", "-journal"} {
+		os.Remove(path + suffix)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return nil, err
+	}
+	if reopen {
+		return saved, Open(path)
+	}
+	return saved, nil
+}
+
+// checkSchema lehnt SQLite-Dateien ab, die keine Scoreboard-Datenbank sind
+func checkSchema(path string) error {
+	conn, err := sql.Open("sqlite", "file:"+filepath.ToSlash(path)+"?mode=ro")
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	var n int
+	err = conn.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN ('teams', 'matches', 'sports')`).Scan(&n)
+	if err != nil {
+		return err
+	}
+	if n < 3 {
+		return i18n.Errorf("error.database.schema", path)
+	}
+	return nil
+}
+
+func copyFile(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dest)
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// corruptError fasst einen Fehler oder die Meldungen der Integritätsprüfung zusammen
+func corruptError(err error, problems []string) error {
+	if err != nil {
+		return err
+	}
+	if len(problems) > 3 {
+		problems = append(problems[:3:3], i18n.T("error.database.more_problems", len(problems)-3))
+	}
+	return i18n.Errorf("error.database.corrupt_details", ErrCorrupt, strings.Join(problems, "; "))
+}
+
+func abs(path string) string {
+	if p, err := filepath.Abs(path); err == nil {
+		return p
+	}
+	return path
+}
@@ -0,0 +1,178 @@
+// internal/database/backup_test.go
+
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// openTestDB öffnet eine leere Datenbank im Testverzeichnis und liefert ihren Pfad
+func openTestDB(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.db")
+	if err := Open(path); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(Close)
+	return path
+}
+
+func saveTestTeam(t *testing.T, name string) *models.Team {
+	t.Helper()
+	team := &models.Team{Name: name, Sportart: "Fußball"}
+	if err := SaveTeam(team); err != nil {
+		t.Fatal(err)
+	}
+	return team
+}
+
+func teamNames(t *testing.T) []string {
+	t.Helper()
+	teams, err := LoadTeams()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, team := range teams {
+		names = append(names, team.Name)
+	}
+	return names
+}
+
+func TestCreateSnapshot(t *testing.T) {
+	path := openTestDB(t)
+	saveTestTeam(t, "Adler")
+
+	snap, err := CreateSnapshot(SnapshotManual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Dir(snap.Path) != snapshotDir(path) || snap.Size == 0 {
+		t.Errorf("Snapshot %s mit %d Bytes", snap.Path, snap.Size)
+	}
+	if problems, err := CheckFile(snap.Path); err != nil || len(problems) > 0 {
+		t.Errorf("Snapshot ungültig: %v %v", err, problems)
+	}
+
+	snaps, err := ListSnapshots(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snaps) != 1 || snaps[0].Path != snap.Path || snaps[0].Reason != SnapshotManual {
+		t.Errorf("Liste %v, erwartet nur %s", snaps, snap.Path)
+	}
+}
+
+func TestSnapshotRotation(t *testing.T) {
+	path := openTestDB(t)
+	defer func(keep int) { SnapshotKeep = keep }(SnapshotKeep)
+	SnapshotKeep = 3
+
+	var newest *Snapshot
+	for range 5 {
+		snap, err := CreateSnapshot(SnapshotManual)
+		if err != nil {
+			t.Fatal(err)
+		}
+		newest = snap
+		time.Sleep(2 * time.Millisecond) // eigener Zeitstempel je Snapshot
+	}
+
+	snaps, err := ListSnapshots(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snaps) != SnapshotKeep {
+		t.Fatalf("%d Snapshots, erwartet %d", len(snaps), SnapshotKeep)
+	}
+	if snaps[0].Path != newest.Path {
+		t.Errorf("neuester Snapshot %s, erwartet %s", snaps[0].Path, newest.Path)
+	}
+}
+
+func TestCheckFile(t *testing.T) {
+	path := openTestDB(t)
+	if problems, err := CheckFile(path); err != nil || len(problems) > 0 {
+		t.Errorf("intakte Datenbank: %v %v", err, problems)
+	}
+
+	broken := filepath.Join(t.TempDir(), "kaputt.db")
+	if err := os.WriteFile(broken, []byte("keine Datenbank, nur Text"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if problems, err := CheckFile(broken); err == nil && len(problems) == 0 {
+		t.Error("beschädigte Datei besteht die Prüfung")
+	}
+}
+
+func TestRestore(t *testing.T) {
+	path := openTestDB(t)
+	saveTestTeam(t, "Adler")
+	snap, err := CreateSnapshot(SnapshotManual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saveTestTeam(t, "Bären")
+
+	saved, err := Restore(path, snap.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := teamNames(t); len(got) != 1 || got[0] != "Adler" {
+		t.Errorf("Teams nach Restore %q, erwartet nur Adler", got)
+	}
+	if saved == nil || saved.Reason != SnapshotRestore {
+		t.Fatalf("kein Snapshot des alten Stands: %v", saved)
+	}
+
+	// der alte Stand lässt sich ebenso zurückspielen
+	if _, err := Restore(path, saved.Path); err != nil {
+		t.Fatal(err)
+	}
+	if got := teamNames(t); len(got) != 2 {
+		t.Errorf("Teams %q, erwartet Adler und Bären", got)
+	}
+}
+
+func TestRestoreRejectsInvalidSource(t *testing.T) {
+	path := openTestDB(t)
+	saveTestTeam(t, "Adler")
+
+	broken := filepath.Join(t.TempDir(), "kaputt.db")
+	if err := os.WriteFile(broken, []byte("keine Datenbank, nur Text"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Restore(path, broken); err == nil {
+		t.Error("beschädigte Sicherung zurückgespielt")
+	}
+	if _, err := Restore(path, path); err == nil {
+		t.Error("Datenbank auf sich selbst zurückgespielt")
+	}
+	if got := teamNames(t); len(got) != 1 {
+		t.Errorf("Teams %q, erwartet unverändert Adler", got)
+	}
+}
+
+// Statuswechsel legen keine Snapshots an, sie laufen oft in Transaktionen
+func TestSetMatchStatusWithoutSnapshot(t *testing.T) {
+	path := openTestDB(t)
+	m := &models.Match{Sportart: "Fußball", Team1: saveTestTeam(t, "Adler"), Team2: saveTestTeam(t, "Bären"),
+		TemplateSettings: &models.TemplateSettings{ID: 1}}
+	if err := SaveMatches(m); err != nil {
+		t.Fatal(err)
+	}
+	err := InTx(func(tx *Tx) error {
+		return tx.SetMatchStatus(m.ID, models.MatchLive)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snaps, _ := ListSnapshots(path); len(snaps) != 0 {
+		t.Errorf("%d Snapshots beim Statuswechsel angelegt", len(snaps))
+	}
+}
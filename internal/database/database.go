@@ -3,11 +3,14 @@
 package database
 
 import (
+	"crypto/rand"
 	"database/sql"
+	"encoding/json"
 	"log"
 	"os"
 	"time"
 
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
 	"github.com/KernTom/scoreboard-manager/internal/models"
 	_ "modernc.org/sqlite"
 )
@@ -38,24 +41,55 @@ type Sport struct {
 
 var db *sql.DB
 
+// InitDatabase öffnet die Standard-Datenbank settings.db im Arbeitsverzeichnis
 func InitDatabase() error {
+	return Open("settings.db")
+}
+
+// Open öffnet bzw. erstellt die Datenbank unter dbPath und migriert sie.
+// Eine vorhandene Datenbank muss die Integritätsprüfung bestehen und wird vor
+// einer Migration als Snapshot gesichert.
+func Open(dbPath string) error {
 	var err error
-	dbPath := "settings.db"
 
 	// Prüfen, ob Datei existiert
-	if _, err = os.Stat(dbPath); os.IsNotExist(err) {
+	info, err := os.Stat(dbPath)
+	if os.IsNotExist(err) {
 		file, err := os.Create(dbPath)
 		if err != nil {
 			return err
 		}
 		file.Close()
 	}
+	existing := err == nil && info.Size() > 0
 
 	// DB öffnen
 	db, err = sql.Open("sqlite", dbPath)
 	if err != nil {
 		return err
 	}
+	openPath = dbPath
+
+	if existing {
+		problems, err := integrityCheck(db)
+		if err != nil {
+			return err
+		}
+		if len(problems) > 0 {
+			Close()
+			db = nil
+			return i18n.Errorf("error.database.open_corrupt", dbPath, corruptError(nil, problems))
+		}
+		pending, err := pendingMigration()
+		if err != nil {
+			return err
+		}
+		if pending {
+			if _, err := CreateSnapshot(SnapshotMigration); err != nil {
+				return i18n.Errorf("error.database.migration_snapshot", err)
+			}
+		}
+	}
 
 	// Prüfen ob Tabellen existieren
 	if err := checkAndMigrateDatabase(); err != nil {
@@ -66,6 +100,14 @@ func InitDatabase() error {
 	if err != nil {
 		return err
 	}
+	if err := migrateLogos(); err != nil {
+		return err
+	}
+
+	// Standarddaten erst nach der Migration, damit neue Spalten existieren
+	if insertErr := insertDefaultSports(); insertErr != nil {
+		return insertErr
+	}
 
 	return nil
 }
@@ -77,9 +119,6 @@ func checkAndMigrateDatabase() error {
 	if createErr := createTables(); createErr != nil {
 		return createErr
 	}
-	if insertErr := insertDefaultSports(); insertErr != nil {
-		return insertErr
-	}
 
 	return nil
 }
@@ -124,6 +163,82 @@ func createTables() error {
 			template_id int not null,
 			start_time datetime
 		);`,
+		`CREATE TABLE IF NOT EXISTS venues (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE,
+			address TEXT
+		);`,
+		`CREATE TABLE IF NOT EXISTS fields (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			venue_id INTEGER NOT NULL,
+			name TEXT NOT NULL,
+			template_id INTEGER,
+			UNIQUE (venue_id, name)
+		);`,
+		`CREATE TABLE IF NOT EXISTS template_elements (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			template_id INTEGER NOT NULL,
+			position INTEGER NOT NULL,
+			type TEXT NOT NULL,
+			x INTEGER,
+			y INTEGER,
+			width INTEGER,
+			height INTEGER,
+			align TEXT,
+			font_family TEXT,
+			font_size INTEGER,
+			color TEXT,
+			visibility TEXT,
+			text TEXT
+		);`,
+		`CREATE TABLE IF NOT EXISTS template_assets (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			template_id INTEGER NOT NULL,
+			name TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			data BLOB,
+			UNIQUE (template_id, name)
+		);`,
+		`CREATE TABLE IF NOT EXISTS logos (
+			hash TEXT PRIMARY KEY,
+			data BLOB NOT NULL
+		);`,
+		`CREATE TABLE IF NOT EXISTS fonts (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE,
+			data BLOB
+		);`,
+		`CREATE TABLE IF NOT EXISTS match_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			match_id INTEGER NOT NULL,
+			type TEXT NOT NULL,
+			side TEXT,
+			value INTEGER,
+			detail TEXT,
+			period INTEGER,
+			elapsed_ms INTEGER,
+			at TEXT
+		);`,
+		`CREATE TABLE IF NOT EXISTS live_checkpoints (
+			match_id INTEGER PRIMARY KEY,
+			field_id INTEGER NOT NULL,
+			status TEXT NOT NULL,
+			score_home INTEGER,
+			score_away INTEGER,
+			period INTEGER,
+			elapsed_ms INTEGER,
+			running BOOLEAN,
+			saved_at datetime
+		);`,
+		`CREATE TABLE IF NOT EXISTS match_audit (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			match_id INTEGER NOT NULL,
+			action TEXT NOT NULL,
+			reason TEXT NOT NULL,
+			actor TEXT,
+			details TEXT,
+			changed_at datetime
+		);`,
 	}
 
 	for _, stmt := range sqlStmts {
@@ -136,31 +251,94 @@ func createTables() error {
 	return nil
 }
 
+// Tabellen und erwartete Spalten samt Typen
+var tableColumns = map[string]map[string]string{
+	"template_settings": {
+		"clock_font_family":     "TEXT DEFAULT 'Segoe UI'",
+		"clock_font_size":       "INTEGER DEFAULT 32",
+		"clock_font_color":      "TEXT DEFAULT '#FFFFFF'",
+		"period_font_family":    "TEXT DEFAULT 'Segoe UI'",
+		"period_font_size":      "INTEGER DEFAULT 20",
+		"period_font_color":     "TEXT DEFAULT '#FFFFFF'",
+		"score_font_family":     "TEXT DEFAULT 'Segoe UI'",
+		"score_font_size":       "INTEGER DEFAULT 32",
+		"score_font_color":      "TEXT DEFAULT '#FFFFFF'",
+		"separator_font_family": "TEXT DEFAULT 'Segoe UI'",
+		"separator_font_size":   "INTEGER DEFAULT 28",
+		"separator_font_color":  "TEXT DEFAULT '#FFFFFF'",
+		"extra_time_font_color": "TEXT DEFAULT '#FF0000'",
+		"name":                  "TEXT DEFAULT 'Standard'",
+		"background_font_color": "TEXT DEFAULT '#000000'",
+		"parent_id":             "INTEGER DEFAULT 0",
+		"overridden_fields":     "TEXT DEFAULT ''",
+		"theme_variables":       "TEXT DEFAULT ''",
+	},
+	"teams": {
+		"logo_data":          "BLOB",
+		"logo_original":      "BLOB",
+		"logo_hash":          "TEXT",
+		"logo_original_hash": "TEXT",
+		"primary_color":      "TEXT",
+		"secondary_color":    "TEXT",
+		"short_name":         "TEXT",
+		"abbreviation":       "TEXT",
+		"alt_names":          "TEXT",
+	},
+	"sports": {
+		"points_win":   "INTEGER",
+		"points_draw":  "INTEGER",
+		"points_loss":  "INTEGER",
+		"ranking_mode": "TEXT",
+		"tie_breakers": "TEXT",
+	},
+	"matches": {
+		"competition":           "TEXT DEFAULT ''",
+		"score_home":            "INTEGER",
+		"score_away":            "INTEGER",
+		"round":                 "INTEGER DEFAULT 0",
+		"group_name":            "TEXT DEFAULT ''",
+		"bracket":               "TEXT DEFAULT ''",
+		"field":                 "TEXT DEFAULT ''",
+		"next_match_id":         "INTEGER DEFAULT 0",
+		"next_match_slot":       "TEXT DEFAULT ''",
+		"loser_next_match_id":   "INTEGER DEFAULT 0",
+		"loser_next_match_slot": "TEXT DEFAULT ''",
+		"status":                "TEXT DEFAULT 'scheduled'",
+		"field_id":              "INTEGER DEFAULT 0",
+		"ical_uid":              "TEXT DEFAULT ''",
+	},
+	"template_elements": {
+		"asset": "TEXT DEFAULT ''",
+	},
+}
+
+// pendingMigration meldet, ob migrateDatabase Spalten anlegen wird
+func pendingMigration() (bool, error) {
+	for tableName, columns := range tableColumns {
+		existing, err := getExistingColumns(tableName)
+		if err != nil {
+			return false, err
+		}
+		for col := range columns {
+			if _, ok := existing[col]; !ok {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
 func migrateDatabase() error {
-	// Tabellen und erwartete Spalten samt Typen
-	tableColumns := map[string]map[string]string{
-		"template_settings": {
-			"clock_font_family":     "TEXT DEFAULT 'Segoe UI'",
-			"clock_font_size":       "INTEGER DEFAULT 32",
-			"clock_font_color":      "TEXT DEFAULT '#FFFFFF'",
-			"period_font_family":    "TEXT DEFAULT 'Segoe UI'",
-			"period_font_size":      "INTEGER DEFAULT 20",
-			"period_font_color":     "TEXT DEFAULT '#FFFFFF'",
-			"score_font_family":     "TEXT DEFAULT 'Segoe UI'",
-			"score_font_size":       "INTEGER DEFAULT 32",
-			"score_font_color":      "TEXT DEFAULT '#FFFFFF'",
-			"separator_font_family": "TEXT DEFAULT 'Segoe UI'",
-			"separator_font_size":   "INTEGER DEFAULT 28",
-			"separator_font_color":  "TEXT DEFAULT '#FFFFFF'",
-			"extra_time_font_color": "TEXT DEFAULT '#FF0000'",
-			"name":                  "TEXT DEFAULT 'Standard'",
-			"background_font_color": "TEXT DEFAULT '#000000'",
-		},
-		"teams": {
-			"logo_data": "BLOB",
-		},
+	// Datenübernahme für frisch angelegte Spalten
+	backfill := map[string]string{
+		"matches.status":   `UPDATE matches SET status = 'finished' WHERE score_home IS NOT NULL AND score_away IS NOT NULL`,
+		"matches.ical_uid": `UPDATE matches SET ical_uid = lower(hex(randomblob(16))) || '@scoreboard-manager'`,
+		// bisher wurde die hochgeladene Datei unverändert gespeichert
+		"teams.logo_original": `UPDATE teams SET logo_original = logo_data`,
 	}
 
+	// erst ausführen, wenn alle Spalten existieren (Map-Reihenfolge ist zufällig)
+	var pending []string
 	for tableName, columns := range tableColumns {
 		existing, err := getExistingColumns(tableName)
 		if err != nil {
@@ -175,40 +353,70 @@ func migrateDatabase() error {
 				if err != nil {
 					return err
 				}
+				if stmt, ok := backfill[tableName+"."+col]; ok {
+					pending = append(pending, stmt)
+				}
 			}
 		}
 	}
 
+	for _, stmt := range pending {
+		if _, err := db.Exec(stmt); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func insertDefaultSports() error {
-	defaultSports := []models.SportartDefinition{
-		{Sportart: "American Football", PeriodLabel: "Halbzeit", PeriodsCount: 2, PeriodDuration: 15, ClockFormat: "MM:SS", ClockDirection: "Up"},
-		{Sportart: "Fußball", PeriodLabel: "Halbzeit", PeriodsCount: 2, PeriodDuration: 45, ClockFormat: "Minuten", ClockDirection: "Up"},
-	}
+// DefaultSports werden in jede Datenbank eingetragen, sofern es sie noch
+// nicht gibt; die Konfiguration kann sie ersetzen (siehe config.Apply)
+var DefaultSports = []models.SportartDefinition{
+	{Sportart: "American Football", PeriodLabel: "Halbzeit", PeriodsCount: 2, PeriodDuration: 15, ClockFormat: "MM:SS", ClockDirection: "Up",
+		PointsWin: 2, PointsDraw: 1, PointsLoss: 0, RankingMode: models.RankingWinPercentage,
+		TieBreakers: models.TieBreakHeadToHead + "," + models.TieBreakGoalDifference + "," + models.TieBreakGoalsFor},
+	{Sportart: "Fußball", PeriodLabel: "Halbzeit", PeriodsCount: 2, PeriodDuration: 45, ClockFormat: "Minuten", ClockDirection: "Up",
+		PointsWin: 3, PointsDraw: 1, PointsLoss: 0, RankingMode: models.RankingPoints,
+		TieBreakers: models.TieBreakGoalDifference + "," + models.TieBreakGoalsFor + "," + models.TieBreakHeadToHead},
+}
 
-	for _, sport := range defaultSports {
+func insertDefaultSports() error {
+	for _, sport := range DefaultSports {
 		_, err := db.Exec(`INSERT OR IGNORE INTO sports (sportart, period_label, periods_count, period_duration, clock_format, clock_direction)
 			VALUES (?, ?, ?, ?, ?, ?)`,
 			sport.Sportart, sport.PeriodLabel, sport.PeriodsCount, sport.PeriodDuration, sport.ClockFormat, sport.ClockDirection)
 		if err != nil {
 			return err
 		}
+
+		// Tabellenregeln nur setzen, wenn noch keine konfiguriert sind (ältere Datenbanken)
+		_, err = db.Exec(`UPDATE sports SET points_win = ?, points_draw = ?, points_loss = ?, ranking_mode = ?, tie_breakers = ?
+			WHERE sportart = ? AND ranking_mode IS NULL`,
+			sport.PointsWin, sport.PointsDraw, sport.PointsLoss, sport.RankingMode, sport.TieBreakers, sport.Sportart)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-// LoadTemplateSettings lädt die Anzeigeeinstellungen (TemplateSettings) aus der Datenbank
-func LoadTemplates() ([]*models.TemplateSettings, error) {
-	rows, err := db.Query(`SELECT id,name, width, height, x, y, sport, period_label, period_count, period_duration,
+const templateColumns = `id,name, width, height, x, y, sport, period_label, period_count, period_duration,
 			gameclock_mode, show_period, show_gameclock, show_clock,
 			clock_font_family, clock_font_size, clock_font_color,
 			period_font_family, period_font_size, period_font_color,
 			score_font_family, score_font_size, score_font_color,
 			separator_font_family, separator_font_size, separator_font_color,
-			extra_time_font_color, background_font_color
+			extra_time_font_color, background_font_color,
+			COALESCE(parent_id, 0), COALESCE(overridden_fields, ''), COALESCE(theme_variables, '')`
+
+// LoadTemplateSettings lädt die Anzeigeeinstellungen (TemplateSettings) aus der Datenbank
+func LoadTemplates() ([]*models.TemplateSettings, error) {
+	return loadTemplates(db)
+}
+
+func loadTemplates(q querier) ([]*models.TemplateSettings, error) {
+	rows, err := q.Query(`SELECT ` + templateColumns + `
 		FROM template_settings`)
 	if err != nil {
 		return nil, err
@@ -218,50 +426,98 @@ func LoadTemplates() ([]*models.TemplateSettings, error) {
 	var templates []*models.TemplateSettings
 	for rows.Next() {
 		var ts models.TemplateSettings
-		err := rows.Scan(
-			&ts.ID,
-			&ts.Name,
-			&ts.Width,
-			&ts.Height,
-			&ts.X,
-			&ts.Y,
-			&ts.Sportart,
-			&ts.PeriodLabel,
-			&ts.PeriodsCount,
-			&ts.PeriodDuration,
-			&ts.GameclockMode,
-			&ts.ShowPeriod,
-			&ts.ShowGameclock,
-			&ts.ShowClock,
-			&ts.ClockFontFamily,
-			&ts.ClockFontSize,
-			&ts.ClockFontColor,
-			&ts.PeriodFontFamily,
-			&ts.PeriodFontSize,
-			&ts.PeriodFontColor,
-			&ts.ScoreFontFamily,
-			&ts.ScoreFontSize,
-			&ts.ScoreFontColor,
-			&ts.SeparatorFontFamily,
-			&ts.SeparatorFontSize,
-			&ts.SeparatorFontColor,
-			&ts.ExtraTimeFontColor,
-			&ts.BackgroundFontColor,
-		)
-		if err != nil {
+		if err := scanTemplate(rows, &ts); err != nil {
 			return nil, err
 		}
 		templates = append(templates, &ts)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, ts := range templates {
+		if ts.Elements, err = loadTemplateElements(q, ts.ID); err != nil {
+			return nil, err
+		}
+		if ts.Assets, err = loadTemplateAssets(q, ts.ID); err != nil {
+			return nil, err
+		}
+	}
 	return templates, nil
 }
 
+// LoadTemplate lädt ein einzelnes Template
+func LoadTemplate(id int) (*models.TemplateSettings, error) {
+	var ts models.TemplateSettings
+	row := db.QueryRow(`SELECT `+templateColumns+` FROM template_settings WHERE id = ?`, id)
+	if err := scanTemplate(row, &ts); err != nil {
+		return nil, err
+	}
+	elements, err := LoadTemplateElements(ts.ID)
+	if err != nil {
+		return nil, err
+	}
+	ts.Elements = elements
+	if ts.Assets, err = LoadTemplateAssets(ts.ID); err != nil {
+		return nil, err
+	}
+	return &ts, nil
+}
+
+func scanTemplate(row rowScanner, ts *models.TemplateSettings) error {
+	var overrides, theme string
+	err := row.Scan(
+		&ts.ID,
+		&ts.Name,
+		&ts.Width,
+		&ts.Height,
+		&ts.X,
+		&ts.Y,
+		&ts.Sportart,
+		&ts.PeriodLabel,
+		&ts.PeriodsCount,
+		&ts.PeriodDuration,
+		&ts.GameclockMode,
+		&ts.ShowPeriod,
+		&ts.ShowGameclock,
+		&ts.ShowClock,
+		&ts.ClockFontFamily,
+		&ts.ClockFontSize,
+		&ts.ClockFontColor,
+		&ts.PeriodFontFamily,
+		&ts.PeriodFontSize,
+		&ts.PeriodFontColor,
+		&ts.ScoreFontFamily,
+		&ts.ScoreFontSize,
+		&ts.ScoreFontColor,
+		&ts.SeparatorFontFamily,
+		&ts.SeparatorFontSize,
+		&ts.SeparatorFontColor,
+		&ts.ExtraTimeFontColor,
+		&ts.BackgroundFontColor,
+		&ts.ParentID,
+		&overrides,
+		&theme,
+	)
+	if err != nil {
+		return err
+	}
+	return decodeInheritance(ts, overrides, theme)
+}
+
 // SaveTemplateSettings speichert die Anzeigeeinstellungen (TemplateSettings) in die Datenbank
 func SaveTemplate(template *models.TemplateSettings) error {
-	log.Printf("speichere template: %v", template)
+	return saveTemplate(db, template)
+}
+
+func saveTemplate(q querier, template *models.TemplateSettings) error {
+	log.Print(i18n.T("log.template_save", template))
+	if err := checkParent(q, template); err != nil {
+		return err
+	}
 	if template.ID == 0 {
 		// Neu
-		res, err := db.Exec(`
+		res, err := q.Exec(`
 			INSERT INTO template_settings (
 				width, height, x, y, sport, period_label, period_count, period_duration,
 				gameclock_mode, show_period, show_gameclock, show_clock,
@@ -307,7 +563,7 @@ func SaveTemplate(template *models.TemplateSettings) error {
 		template.ID = int(lastID)
 	} else {
 		// Update
-		_, err := db.Exec(`
+		_, err := q.Exec(`
 			UPDATE template_settings SET
 				width = ?, height = ?, x = ?, y = ?, sport = ?, period_label = ?, period_count = ?, period_duration = ?,
 				gameclock_mode = ?, show_period = ?, show_gameclock = ?, show_clock = ?,
@@ -348,28 +604,61 @@ func SaveTemplate(template *models.TemplateSettings) error {
 			template.ID,
 		)
 		if err != nil {
-			log.Printf("Fehler beim speichern des templates: %v", err)
+			log.Print(i18n.T("log.template_save_failed", err))
 			return err
 		}
 	}
 
-	return nil
+	return saveInheritance(q, template)
 }
 
-// SaveTemplateSettings speichert die Anzeigeeinstellungen (TemplateSettings) in die Datenbank
+// SaveMatches speichert ein Match. Der Status wird nur beim Anlegen gesetzt und
+// danach ausschließlich über SetMatchStatus geändert; beendete Spiele sind gesperrt.
 func SaveMatches(match *models.Match) error {
+	return saveMatch(db, match)
+}
+
+func saveMatch(q querier, match *models.Match) error {
 	if match.ID == 0 {
 		// Neu
-		res, err := db.Exec(`
+		if match.Status == "" {
+			match.Status = models.MatchScheduled
+		}
+		if match.ICalUID == "" {
+			match.ICalUID = newUID()
+		}
+		templateID, err := matchTemplateID(q, match)
+		if err != nil {
+			return err
+		}
+		res, err := q.Exec(`
 			INSERT INTO matches (
-				sportart, team_home, team_away, template_id, start_time
-			) VALUES ( ?, ?, ?, ?, ?)
+				sportart, team_home, team_away, template_id, start_time,
+				competition, score_home, score_away,
+				round, group_name, bracket, field,
+				next_match_id, next_match_slot, loser_next_match_id, loser_next_match_slot,
+				status, field_id, ical_uid
+			) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		`,
 			match.Sportart,
 			match.Team1.ID,
 			match.Team2.ID,
-			match.TemplateSettings.ID,
+			templateID,
 			match.GameTime.Format(time.RFC3339),
+			match.Competition,
+			match.ScoreHome,
+			match.ScoreAway,
+			match.Round,
+			match.Group,
+			match.Bracket,
+			match.Field,
+			match.NextMatchID,
+			match.NextMatchSlot,
+			match.LoserNextMatchID,
+			match.LoserNextMatchSlot,
+			match.Status,
+			match.FieldID,
+			match.ICalUID,
 		)
 		if err != nil {
 			return err
@@ -378,40 +667,146 @@ func SaveMatches(match *models.Match) error {
 		match.ID = int(lastID)
 	} else {
 		// Update
-		_, err := db.Exec(`
-			UPDATE matches SET
-				sportart = ?, team_home = ?, team_away = ?, template_id = ?, start_time = ?
-			WHERE id = ?
-		`,
-			match.Sportart,
-			match.Team1.ID,
-			match.Team2.ID,
-			match.TemplateSettings.ID,
-			match.GameTime.Format(time.RFC3339),
-			match.ID,
-		)
+		status, err := loadMatchStatus(q, match.ID)
 		if err != nil {
 			return err
 		}
+		if status == models.MatchFinished {
+			return ErrMatchFinished
+		}
+		if err := updateMatch(q, match); err != nil {
+			return err
+		}
+		match.Status = status
 	}
 
 	return nil
 }
 
+// newUID erzeugt die Kalenderkennung für ein neues Spiel; sie bleibt beim
+// Verschieben gleich, damit Kalender-Abos den Termin aktualisieren
+func newUID() string {
+	return rand.Text() + "@scoreboard-manager"
+}
+
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// querier ist *sql.DB oder *sql.Tx, siehe InTx
+type querier interface {
+	execer
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+func updateMatch(q querier, match *models.Match) error {
+	templateID, err := matchTemplateID(q, match)
+	if err != nil {
+		return err
+	}
+	_, err = q.Exec(`
+		UPDATE matches SET
+			sportart = ?, team_home = ?, team_away = ?, template_id = ?, start_time = ?,
+			competition = ?, score_home = ?, score_away = ?,
+			round = ?, group_name = ?, bracket = ?, field = ?,
+			next_match_id = ?, next_match_slot = ?, loser_next_match_id = ?, loser_next_match_slot = ?,
+			field_id = ?, ical_uid = COALESCE(NULLIF(?, ''), ical_uid)
+		WHERE id = ?
+	`,
+		match.Sportart,
+		match.Team1.ID,
+		match.Team2.ID,
+		templateID,
+		match.GameTime.Format(time.RFC3339),
+		match.Competition,
+		match.ScoreHome,
+		match.ScoreAway,
+		match.Round,
+		match.Group,
+		match.Bracket,
+		match.Field,
+		match.NextMatchID,
+		match.NextMatchSlot,
+		match.LoserNextMatchID,
+		match.LoserNextMatchSlot,
+		match.FieldID,
+		match.ICalUID,
+		match.ID,
+	)
+	return err
+}
+
+// matchSelect ist die gemeinsame Abfrage für Matchlisten inkl. Team- und Templatenamen.
+// Turnierspiele können noch offene Teams (ID 0) haben, daher LEFT JOIN.
+const matchSelect = `
+		SELECT
+			m.id, m.sportart, m.team_home, m.team_away, m.template_id, m.start_time,
+			COALESCE(m.competition, ''), COALESCE(m.status, 'scheduled'), m.score_home, m.score_away,
+			COALESCE(m.round, 0), COALESCE(m.group_name, ''), COALESCE(m.bracket, ''), COALESCE(m.field, ''),
+			COALESCE(m.next_match_id, 0), COALESCE(m.next_match_slot, ''),
+			COALESCE(m.loser_next_match_id, 0), COALESCE(m.loser_next_match_slot, ''),
+			COALESCE(m.field_id, 0), COALESCE(m.ical_uid, ''),
+			COALESCE(t1.name, ''), COALESCE(t1.short_name, ''), COALESCE(t1.abbreviation, ''), COALESCE(t1.alt_names, ''),
+			COALESCE(t2.name, ''), COALESCE(t2.short_name, ''), COALESCE(t2.abbreviation, ''), COALESCE(t2.alt_names, ''),
+			COALESCE(ts.name, '')
+		FROM matches m
+		LEFT JOIN teams t1 ON m.team_home = t1.id
+		LEFT JOIN teams t2 ON m.team_away = t2.id
+		LEFT JOIN template_settings ts ON m.template_id = ts.id
+`
+
 // LoadMatches lädt die Matches aus der Datenbank
 func LoadMatches() ([]*models.Match, error) {
-	rows, err := db.Query(`
-		SELECT 
-			id, sportart, team_home, team_away, template_id, start_time
-			t1.name,
-			t2.name,
-			ts.name
-		FROM matches m
-		JOIN teams t1 ON m.team_home = t1.id
-		JOIN teams t2 ON m.team_away = t2.id
-		JOIN template_settings ts ON m.template_id = ts.id
-		ORDER BY m.start_time DESC
-		`)
+	return loadMatches(db)
+}
+
+func loadMatches(q querier) ([]*models.Match, error) {
+	return queryMatches(q, matchSelect+` ORDER BY m.start_time DESC`)
+}
+
+// LoadMatch lädt ein einzelnes Match inkl. Team- und Templatenamen
+func LoadMatch(id int) (*models.Match, error) {
+	return loadMatch(db, id)
+}
+
+func loadMatch(q querier, id int) (*models.Match, error) {
+	matches, err := queryMatches(q, matchSelect+` WHERE m.id = ?`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return matches[0], nil
+}
+
+// LoadMatchesByCompetition lädt alle Matches eines Wettbewerbs
+func LoadMatchesByCompetition(competition string) ([]*models.Match, error) {
+	return queryMatches(db, matchSelect+` WHERE m.competition = ? ORDER BY m.start_time`, competition)
+}
+
+// LoadCompetitions liefert die Namen aller Wettbewerbe, für die Matches existieren
+func LoadCompetitions() ([]string, error) {
+	rows, err := db.Query(`SELECT DISTINCT competition FROM matches WHERE competition IS NOT NULL AND competition != '' ORDER BY competition`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var competitions []string
+	for rows.Next() {
+		var c string
+		if err := rows.Scan(&c); err != nil {
+			return nil, err
+		}
+		competitions = append(competitions, c)
+	}
+	return competitions, rows.Err()
+}
+
+func queryMatches(q querier, query string, args ...any) ([]*models.Match, error) {
+	rows, err := q.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -419,7 +814,13 @@ func LoadMatches() ([]*models.Match, error) {
 
 	var matches []*models.Match
 	for rows.Next() {
-		var ms models.Match
+		ms := models.Match{
+			Team1:            &models.Team{},
+			Team2:            &models.Team{},
+			TemplateSettings: &models.TemplateSettings{},
+		}
+		var scoreHome, scoreAway sql.NullInt64
+		var altHome, altAway string
 		err := rows.Scan(
 			&ms.ID,
 			&ms.Sportart,
@@ -427,16 +828,48 @@ func LoadMatches() ([]*models.Match, error) {
 			&ms.Team2.ID,
 			&ms.TemplateSettings.ID,
 			&ms.GameTime,
-			&ms.Team1.Name,
-			&ms.Team2.Name,
+			&ms.Competition,
+			&ms.Status,
+			&scoreHome,
+			&scoreAway,
+			&ms.Round,
+			&ms.Group,
+			&ms.Bracket,
+			&ms.Field,
+			&ms.NextMatchID,
+			&ms.NextMatchSlot,
+			&ms.LoserNextMatchID,
+			&ms.LoserNextMatchSlot,
+			&ms.FieldID,
+			&ms.ICalUID,
+			&ms.Team1.Name, &ms.Team1.ShortName, &ms.Team1.Abbreviation, &altHome,
+			&ms.Team2.Name, &ms.Team2.ShortName, &ms.Team2.Abbreviation, &altAway,
 			&ms.TemplateSettings.Name,
 		)
 		if err != nil {
 			return nil, err
 		}
+		if err := decodeAltNames(ms.Team1, altHome); err != nil {
+			return nil, err
+		}
+		if err := decodeAltNames(ms.Team2, altAway); err != nil {
+			return nil, err
+		}
+		ms.ScoreHome = nullIntPtr(scoreHome)
+		ms.ScoreAway = nullIntPtr(scoreAway)
+		ms.Team1.Sportart = ms.Sportart
+		ms.Team2.Sportart = ms.Sportart
 		matches = append(matches, &ms)
 	}
-	return matches, nil
+	return matches, rows.Err()
+}
+
+func nullIntPtr(n sql.NullInt64) *int {
+	if !n.Valid {
+		return nil
+	}
+	v := int(n.Int64)
+	return &v
 }
 
 // LoadMatches lädt die Matches aus der Datenbank
@@ -444,8 +877,8 @@ func LoadSingleMatch(id int) (m models.Match, err error) {
 	err = db.QueryRow(`
 		SELECT 
 			id, sportart, team_home, team_away, template_id, start_time
-			t1.name, t1.logo_data,
-			t2.name, t2.logo_data,
+			t1.name, COALESCE(t1.logo_hash, ''),
+			t2.name, COALESCE(t2.logo_hash, ''),
 			ts.period_label, ts.periods_count, ts.period_duration,
 			ts.gameclock_mode, ts.show_period, ts.show_gameclock, ts.show_clock,
 			ts.clock_font_family, ts.clock_font_size, ts.clock_font_color,
@@ -466,9 +899,9 @@ func LoadSingleMatch(id int) (m models.Match, err error) {
 		&m.TemplateSettings.ID,
 		&m.GameTime,
 		&m.Team1.Name,
-		&m.Team1.LogoData,
+		&m.Team1.LogoHash,
 		&m.Team2.Name,
-		&m.Team2.LogoData,
+		&m.Team2.LogoHash,
 		&m.TemplateSettings.PeriodLabel,
 		&m.TemplateSettings.PeriodsCount,
 		&m.TemplateSettings.PeriodDuration,
@@ -504,8 +937,25 @@ func DeleteMatch(id int) error {
 }
 
 func DeleteTemplate(id int) error {
-	_, err := db.Exec(`DELETE FROM template_settings WHERE id = ?`, id)
-	return err
+	if err := checkNoChildren(id); err != nil {
+		return err
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM template_elements WHERE template_id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM template_assets WHERE template_id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM template_settings WHERE id = ?`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func Close() {
@@ -516,7 +966,11 @@ func Close() {
 
 // LoadTeams lädt alle Teams aus der Datenbank
 func LoadTeams() ([]*models.Team, error) {
-	rows, err := db.Query(`SELECT id, name, sportart, logo_data FROM teams`)
+	return loadTeams(db)
+}
+
+func loadTeams(q querier) ([]*models.Team, error) {
+	rows, err := q.Query(`SELECT ` + teamColumns + ` FROM teams`)
 	if err != nil {
 		return nil, err
 	}
@@ -525,7 +979,7 @@ func LoadTeams() ([]*models.Team, error) {
 	var teams []*models.Team
 	for rows.Next() {
 		var team models.Team
-		if err := rows.Scan(&team.ID, &team.Name, &team.Sportart, &team.LogoData); err != nil {
+		if err := scanTeam(rows, &team); err != nil {
 			return nil, err
 		}
 		teams = append(teams, &team)
@@ -533,11 +987,41 @@ func LoadTeams() ([]*models.Team, error) {
 	return teams, nil
 }
 
+// LoadTeam lädt ein einzelnes Team, Logos nur als Hash
+func LoadTeam(id int) (*models.Team, error) {
+	var t models.Team
+	if err := scanTeam(db.QueryRow(`SELECT `+teamColumns+` FROM teams WHERE id = ?`, id), &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// SaveTeam legt ein Team an oder aktualisiert es; Logodaten werden in der
+// Tabelle logos abgelegt, nicht mehr benutzte Logos entfernt
 func SaveTeam(team *models.Team) error {
+	return saveTeam(db, team)
+}
+
+func saveTeam(q querier, team *models.Team) error {
+	models.NormalizeTeamNames(team)
+	if err := models.ValidateTeamNames(team); err != nil {
+		return err
+	}
+	altNames, err := encodeAltNames(team.AltNames)
+	if err != nil {
+		return err
+	}
+	if err := prepareTeamLogos(q, team); err != nil {
+		return err
+	}
+	hash, original := nullString(team.LogoHash), nullString(team.LogoOriginalHash)
 	if team.ID == 0 {
 		// Neues Team einfügen
-		res, err := db.Exec(`INSERT INTO teams (name, sportart, logo_data) VALUES (?, ?, ?)`,
-			team.Name, team.Sportart, team.LogoData)
+		res, err := q.Exec(`INSERT INTO teams (name, sportart, logo_hash, logo_original_hash, primary_color, secondary_color,
+			short_name, abbreviation, alt_names)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			team.Name, team.Sportart, hash, original, team.PrimaryColor, team.SecondaryColor,
+			team.ShortName, team.Abbreviation, altNames)
 		if err != nil {
 			return err
 		}
@@ -547,24 +1031,70 @@ func SaveTeam(team *models.Team) error {
 		}
 	} else {
 		// Bestehendes Team updaten
-		_, err := db.Exec(`UPDATE teams SET name = ?, sportart = ?, logo_data = ? WHERE id = ?`,
-			team.Name, team.Sportart, team.LogoData, team.ID)
+		_, err := q.Exec(`UPDATE teams SET name = ?, sportart = ?, logo_hash = ?, logo_original_hash = ?,
+			primary_color = ?, secondary_color = ?, short_name = ?, abbreviation = ?, alt_names = ? WHERE id = ?`,
+			team.Name, team.Sportart, hash, original, team.PrimaryColor, team.SecondaryColor,
+			team.ShortName, team.Abbreviation, altNames, team.ID)
 		if err != nil {
 			return err
 		}
 	}
+	return pruneLogos(q)
+}
+
+const teamColumns = `id, name, sportart, COALESCE(logo_hash, ''), COALESCE(logo_original_hash, ''),
+	COALESCE(primary_color, ''), COALESCE(secondary_color, ''),
+	COALESCE(short_name, ''), COALESCE(abbreviation, ''), COALESCE(alt_names, '')`
+
+func scanTeam(row rowScanner, t *models.Team) error {
+	var altNames string
+	if err := row.Scan(&t.ID, &t.Name, &t.Sportart, &t.LogoHash, &t.LogoOriginalHash, &t.PrimaryColor, &t.SecondaryColor,
+		&t.ShortName, &t.Abbreviation, &altNames); err != nil {
+		return err
+	}
+	return decodeAltNames(t, altNames)
+}
+
+// alt_names enthält die Namen je Wettbewerb als JSON-Objekt
+func decodeAltNames(t *models.Team, altNames string) error {
+	t.AltNames = nil
+	if altNames == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(altNames), &t.AltNames); err != nil {
+		return i18n.Errorf("error.database.alt_names", t.ID, err)
+	}
 	return nil
 }
 
+func encodeAltNames(altNames map[string]string) (string, error) {
+	if len(altNames) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(altNames)
+	return string(data), err
+}
+
+// nullString speichert leere Verweise als NULL
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
 // DeleteTeam löscht ein Team anhand seiner ID
 func DeleteTeam(teamID int) error {
-	_, err := db.Exec(`DELETE FROM teams WHERE id = ?`, teamID)
-	return err
+	if _, err := db.Exec(`DELETE FROM teams WHERE id = ?`, teamID); err != nil {
+		return err
+	}
+	return pruneLogos(db)
 }
 
 // Sportarten laden
 func LoadSports() ([]*models.SportartDefinition, error) {
-	rows, err := db.Query(`SELECT id, sportart, period_label, periods_count, period_duration, clock_format, clock_direction FROM sports`)
+	return loadSports(db)
+}
+
+func loadSports(q querier) ([]*models.SportartDefinition, error) {
+	rows, err := q.Query(`SELECT ` + sportColumns + ` FROM sports`)
 	if err != nil {
 		return nil, err
 	}
@@ -573,7 +1103,7 @@ func LoadSports() ([]*models.SportartDefinition, error) {
 	var sports []*models.SportartDefinition
 	for rows.Next() {
 		var sport models.SportartDefinition
-		if err := rows.Scan(&sport.ID, &sport.Sportart, &sport.PeriodLabel, &sport.PeriodsCount, &sport.PeriodDuration, &sport.ClockFormat, &sport.ClockDirection); err != nil {
+		if err := scanSport(rows, &sport); err != nil {
 			return nil, err
 		}
 		sports = append(sports, &sport)
@@ -581,12 +1111,63 @@ func LoadSports() ([]*models.SportartDefinition, error) {
 	return sports, nil
 }
 
+// LoadSport lädt eine einzelne Sportart anhand ihres Namens
+func LoadSport(sportart string) (*models.SportartDefinition, error) {
+	var sport models.SportartDefinition
+	row := db.QueryRow(`SELECT `+sportColumns+` FROM sports WHERE sportart = ?`, sportart)
+	if err := scanSport(row, &sport); err != nil {
+		return nil, err
+	}
+	return &sport, nil
+}
+
+// Sportarten ohne konfigurierte Tabellenregeln werden nach 3-1-0 gewertet
+const sportColumns = `id, sportart, period_label, periods_count, period_duration, clock_format, clock_direction,
+	COALESCE(points_win, 3), COALESCE(points_draw, 1), COALESCE(points_loss, 0),
+	COALESCE(ranking_mode, 'points'), COALESCE(tie_breakers, 'goal_difference,goals_for,head_to_head')`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanSport(row rowScanner, sport *models.SportartDefinition) error {
+	return row.Scan(&sport.ID, &sport.Sportart, &sport.PeriodLabel, &sport.PeriodsCount, &sport.PeriodDuration, &sport.ClockFormat, &sport.ClockDirection,
+		&sport.PointsWin, &sport.PointsDraw, &sport.PointsLoss, &sport.RankingMode, &sport.TieBreakers)
+}
+
 // Sportart speichern
 func SaveSport(sport *models.SportartDefinition) error {
-	_, err := db.Exec(`
-		INSERT INTO sports (sportart, period_label, periods_count, period_duration, clock_format, clock_direction)
-		VALUES (?, ?, ?, ?, ?, ?)
-	`, sport.Sportart, sport.PeriodLabel, sport.PeriodsCount, sport.PeriodDuration, sport.ClockFormat, sport.ClockDirection)
+	return saveSport(db, sport)
+}
+
+func saveSport(q querier, sport *models.SportartDefinition) error {
+	if sport.ID != 0 {
+		_, err := q.Exec(`
+			UPDATE sports SET sportart = ?, period_label = ?, periods_count = ?, period_duration = ?, clock_format = ?, clock_direction = ?,
+				points_win = ?, points_draw = ?, points_loss = ?, ranking_mode = ?, tie_breakers = ?
+			WHERE id = ?
+		`, sport.Sportart, sport.PeriodLabel, sport.PeriodsCount, sport.PeriodDuration, sport.ClockFormat, sport.ClockDirection,
+			sport.PointsWin, sport.PointsDraw, sport.PointsLoss, sport.RankingMode, sport.TieBreakers, sport.ID)
+		return err
+	}
+
+	res, err := q.Exec(`
+		INSERT INTO sports (sportart, period_label, periods_count, period_duration, clock_format, clock_direction,
+			points_win, points_draw, points_loss, ranking_mode, tie_breakers)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, sport.Sportart, sport.PeriodLabel, sport.PeriodsCount, sport.PeriodDuration, sport.ClockFormat, sport.ClockDirection,
+		sport.PointsWin, sport.PointsDraw, sport.PointsLoss, sport.RankingMode, sport.TieBreakers)
+	if err != nil {
+		return err
+	}
+	lastID, _ := res.LastInsertId()
+	sport.ID = int(lastID)
+	return nil
+}
+
+// DeleteSport löscht eine Sportart
+func DeleteSport(id int) error {
+	_, err := db.Exec(`DELETE FROM sports WHERE id = ?`, id)
 	return err
 }
 
@@ -0,0 +1,109 @@
+// internal/database/elements.go
+
+package database
+
+import (
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// LoadTemplateElements lädt die Layout-Elemente eines Templates in Zeichenreihenfolge
+func LoadTemplateElements(templateID int) ([]*models.LayoutElement, error) {
+	return loadTemplateElements(db, templateID)
+}
+
+func loadTemplateElements(q querier, templateID int) ([]*models.LayoutElement, error) {
+	rows, err := q.Query(`SELECT id, template_id, type, COALESCE(x, 0), COALESCE(y, 0), COALESCE(width, 0), COALESCE(height, 0),
+		COALESCE(align, 'center'), COALESCE(font_family, ''), COALESCE(font_size, 0), COALESCE(color, ''),
+		COALESCE(visibility, 'always'), COALESCE(text, ''), COALESCE(asset, '')
+		FROM template_elements WHERE template_id = ? ORDER BY position, id`, templateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var elements []*models.LayoutElement
+	for rows.Next() {
+		var e models.LayoutElement
+		if err := rows.Scan(&e.ID, &e.TemplateID, &e.Type, &e.X, &e.Y, &e.Width, &e.Height,
+			&e.Align, &e.FontFamily, &e.FontSize, &e.Color, &e.Visibility, &e.Text, &e.Asset); err != nil {
+			return nil, err
+		}
+		elements = append(elements, &e)
+	}
+	return elements, rows.Err()
+}
+
+// SaveTemplateElements ersetzt die Layout-Elemente eines Templates. Die
+// Reihenfolge der Liste ist die Zeichenreihenfolge (spätere liegen oben).
+func SaveTemplateElements(templateID int, elements []*models.LayoutElement) error {
+	return saveTemplateElements(db, templateID, elements)
+}
+
+func saveTemplateElements(q querier, templateID int, elements []*models.LayoutElement) error {
+	return inTx(q, func(tx querier) error {
+		if _, err := tx.Exec(`DELETE FROM template_elements WHERE template_id = ?`, templateID); err != nil {
+			return err
+		}
+		for i, e := range elements {
+			res, err := tx.Exec(`INSERT INTO template_elements
+				(template_id, position, type, x, y, width, height, align, font_family, font_size, color, visibility, text, asset)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				templateID, i, e.Type, e.X, e.Y, e.Width, e.Height, e.Align, e.FontFamily, e.FontSize, e.Color, e.Visibility, e.Text, e.Asset)
+			if err != nil {
+				return err
+			}
+			lastID, _ := res.LastInsertId()
+			e.ID = int(lastID)
+			e.TemplateID = templateID
+		}
+		return nil
+	})
+}
+
+// LoadTemplateAssets lädt die mitgelieferten Schriften und Bilder eines Templates
+func LoadTemplateAssets(templateID int) ([]*models.TemplateAsset, error) {
+	return loadTemplateAssets(db, templateID)
+}
+
+func loadTemplateAssets(q querier, templateID int) ([]*models.TemplateAsset, error) {
+	rows, err := q.Query(`SELECT id, template_id, name, kind, data FROM template_assets
+		WHERE template_id = ? ORDER BY name`, templateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var assets []*models.TemplateAsset
+	for rows.Next() {
+		var a models.TemplateAsset
+		if err := rows.Scan(&a.ID, &a.TemplateID, &a.Name, &a.Kind, &a.Data); err != nil {
+			return nil, err
+		}
+		assets = append(assets, &a)
+	}
+	return assets, rows.Err()
+}
+
+// SaveTemplateAssets ersetzt die Schriften und Bilder eines Templates
+func SaveTemplateAssets(templateID int, assets []*models.TemplateAsset) error {
+	return saveTemplateAssets(db, templateID, assets)
+}
+
+func saveTemplateAssets(q querier, templateID int, assets []*models.TemplateAsset) error {
+	return inTx(q, func(tx querier) error {
+		if _, err := tx.Exec(`DELETE FROM template_assets WHERE template_id = ?`, templateID); err != nil {
+			return err
+		}
+		for _, a := range assets {
+			res, err := tx.Exec(`INSERT INTO template_assets (template_id, name, kind, data) VALUES (?, ?, ?, ?)`,
+				templateID, a.Name, a.Kind, a.Data)
+			if err != nil {
+				return err
+			}
+			lastID, _ := res.LastInsertId()
+			a.ID = int(lastID)
+			a.TemplateID = templateID
+		}
+		return nil
+	})
+}
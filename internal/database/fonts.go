@@ -0,0 +1,57 @@
+// internal/database/fonts.go
+
+package database
+
+import (
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// LoadFonts lädt alle in der Datenbank hinterlegten Schriften
+func LoadFonts() ([]*models.Font, error) {
+	return loadFonts(db)
+}
+
+func loadFonts(q querier) ([]*models.Font, error) {
+	rows, err := q.Query(`SELECT id, name, data FROM fonts ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fonts []*models.Font
+	for rows.Next() {
+		var f models.Font
+		if err := rows.Scan(&f.ID, &f.Name, &f.Data); err != nil {
+			return nil, err
+		}
+		fonts = append(fonts, &f)
+	}
+	return fonts, rows.Err()
+}
+
+// SaveFont legt eine Schrift an; eine Schrift gleichen Namens wird ersetzt
+func SaveFont(f *models.Font) error {
+	return saveFont(db, f)
+}
+
+func saveFont(q querier, f *models.Font) error {
+	_, err := q.Exec(`INSERT INTO fonts (name, data) VALUES (?, ?)
+		ON CONFLICT(name) DO UPDATE SET data = excluded.data`, f.Name, f.Data)
+	if err != nil {
+		return err
+	}
+	return q.QueryRow(`SELECT id FROM fonts WHERE name = ?`, f.Name).Scan(&f.ID)
+}
+
+// DeleteFont entfernt eine Schrift anhand ihres Dateinamens
+func DeleteFont(name string) error {
+	res, err := db.Exec(`DELETE FROM fonts WHERE name = ?`, name)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return i18n.Errorf("error.database.font_missing", name)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+// internal/database/inheritance.go
+
+package database
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// maxTemplateDepth begrenzt Vererbungsketten, damit kaputte Daten nicht endlos laufen
+const maxTemplateDepth = 16
+
+// decodeInheritance übernimmt overridden_fields (kommagetrennt) und
+// theme_variables (JSON-Objekt) ins Template
+func decodeInheritance(ts *models.TemplateSettings, overrides, theme string) error {
+	ts.Overrides = nil
+	for _, f := range strings.Split(overrides, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			ts.Overrides = append(ts.Overrides, f)
+		}
+	}
+	ts.Theme = nil
+	if theme != "" {
+		if err := json.Unmarshal([]byte(theme), &ts.Theme); err != nil {
+			return i18n.Errorf("error.database.theme", ts.ID, err)
+		}
+	}
+	return nil
+}
+
+func saveInheritance(q querier, t *models.TemplateSettings) error {
+	theme := ""
+	if len(t.Theme) > 0 {
+		data, err := json.Marshal(t.Theme)
+		if err != nil {
+			return err
+		}
+		theme = string(data)
+	}
+	overrides := ""
+	if t.ParentID != 0 {
+		overrides = strings.Join(t.Overrides, ",")
+	}
+	_, err := q.Exec(`UPDATE template_settings SET parent_id = ?, overridden_fields = ?, theme_variables = ? WHERE id = ?`,
+		t.ParentID, overrides, theme, t.ID)
+	return err
+}
+
+// checkParent verhindert Zyklen und Verweise auf fehlende Templates
+func checkParent(q querier, t *models.TemplateSettings) error {
+	for _, f := range t.Overrides {
+		if !slices.Contains(models.InheritableFields(), f) {
+			return i18n.Errorf("error.database.override_field", f)
+		}
+	}
+	id := t.ParentID
+	for depth := 0; id != 0; depth++ {
+		if id == t.ID && t.ID != 0 {
+			return i18n.Errorf("error.database.inherit_self")
+		}
+		if depth >= maxTemplateDepth {
+			return i18n.Errorf("error.database.inherit_depth", maxTemplateDepth)
+		}
+		if err := q.QueryRow(`SELECT COALESCE(parent_id, 0) FROM template_settings WHERE id = ?`, id).Scan(&id); err != nil {
+			return i18n.Errorf("error.database.parent_missing", err)
+		}
+	}
+	return nil
+}
+
+// checkNoChildren verhindert das Löschen von Templates, von denen andere erben
+func checkNoChildren(id int) error {
+	var names []string
+	rows, err := db.Query(`SELECT name FROM template_settings WHERE parent_id = ? ORDER BY name`, id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(names) > 0 {
+		return i18n.Errorf("error.database.template_in_use", strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// FlattenTemplate lädt ein Template und übernimmt alle geerbten Felder aus
+// seinen Eltern. Verweise auf Theme-Variablen bleiben erhalten.
+func FlattenTemplate(id int) (*models.TemplateSettings, error) {
+	t, err := LoadTemplate(id)
+	if err != nil {
+		return nil, err
+	}
+	if t.ParentID == 0 {
+		return t, nil
+	}
+
+	seen := map[int]bool{t.ID: true}
+	chain := []*models.TemplateSettings{t}
+	for p := t.ParentID; p != 0; {
+		if seen[p] || len(chain) > maxTemplateDepth {
+			return nil, i18n.Errorf("error.database.inherit_cycle", id)
+		}
+		seen[p] = true
+		parent, err := LoadTemplate(p)
+		if err != nil {
+			return nil, i18n.Errorf("error.database.parent", id, p, err)
+		}
+		chain = append(chain, parent)
+		p = parent.ParentID
+	}
+
+	// von der Wurzel abwärts vererben
+	flat := chain[len(chain)-1]
+	for i := len(chain) - 2; i >= 0; i-- {
+		flat = chain[i].Inherit(flat)
+	}
+	return flat, nil
+}
+
+// ResolveTemplate liefert das Template so, wie es angezeigt wird: geerbte
+// Felder übernommen und Theme-Variablen durch ihre Farben ersetzt
+func ResolveTemplate(id int) (*models.TemplateSettings, error) {
+	t, err := FlattenTemplate(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.ApplyTheme(); err != nil {
+		return nil, i18n.Errorf("error.wrap.template", t.Name, err)
+	}
+	return t, nil
+}
+
+// ResolveTemplates liefert alle Templates aufgelöst, z.B. für Vorschauen
+func ResolveTemplates() ([]*models.TemplateSettings, error) {
+	templates, err := LoadTemplates()
+	if err != nil {
+		return nil, err
+	}
+	resolved := make([]*models.TemplateSettings, 0, len(templates))
+	for _, t := range templates {
+		r, err := ResolveTemplate(t.ID)
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, r)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,125 @@
+// internal/database/live.go
+
+package database
+
+import (
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// SaveLiveCheckpoint sichert Stand und Ereignisse eines Live-Spiels, damit es
+// nach einem Neustart fortgesetzt werden kann. Die Ereignisse des Spiels
+// werden dabei vollständig ersetzt, der Aufruf ist also wiederholbar.
+func SaveLiveCheckpoint(state models.LiveState, events []models.MatchEvent) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`INSERT OR REPLACE INTO live_checkpoints
+		(match_id, field_id, status, score_home, score_away, period, elapsed_ms, running, saved_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		state.MatchID, state.FieldID, state.Status, state.HomeScore, state.AwayScore,
+		state.Period, state.Elapsed.Milliseconds(), state.Running, time.Now().Format(time.RFC3339))
+	if err != nil {
+		return err
+	}
+
+	if err := replaceMatchEvents(tx, state.MatchID, events); err != nil {
+		return err
+	}
+
+	// Status nur übernehmen, Endstände schreibt FinalizeMatch
+	if state.Status != models.MatchFinished {
+		if _, err := tx.Exec(`UPDATE matches SET status = ? WHERE id = ?`, state.Status, state.MatchID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SaveMatchEvents ersetzt die gespeicherten Ereignisse eines Spiels
+func SaveMatchEvents(matchID int, events []models.MatchEvent) error {
+	return saveMatchEvents(db, matchID, events)
+}
+
+func saveMatchEvents(q querier, matchID int, events []models.MatchEvent) error {
+	return inTx(q, func(tx querier) error {
+		if err := replaceMatchEvents(tx, matchID, events); err != nil {
+			return err
+		}
+		return nil
+	})
+}
+
+func replaceMatchEvents(ex execer, matchID int, events []models.MatchEvent) error {
+	if _, err := ex.Exec(`DELETE FROM match_events WHERE match_id = ?`, matchID); err != nil {
+		return err
+	}
+	for _, ev := range events {
+		_, err := ex.Exec(`INSERT INTO match_events (match_id, type, side, value, detail, period, elapsed_ms, at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			matchID, ev.Type, ev.Side, ev.Value, ev.Detail, ev.Period, ev.Elapsed.Milliseconds(), ev.At.Format(time.RFC3339Nano))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadLiveCheckpoints lädt alle gesicherten Live-Spiele
+func LoadLiveCheckpoints() ([]models.LiveState, error) {
+	rows, err := db.Query(`SELECT match_id, field_id, status, COALESCE(score_home, 0), COALESCE(score_away, 0),
+		COALESCE(period, 1), COALESCE(elapsed_ms, 0), COALESCE(running, 0)
+		FROM live_checkpoints ORDER BY field_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var states []models.LiveState
+	for rows.Next() {
+		var s models.LiveState
+		var elapsedMS int64
+		if err := rows.Scan(&s.MatchID, &s.FieldID, &s.Status, &s.HomeScore, &s.AwayScore, &s.Period, &elapsedMS, &s.Running); err != nil {
+			return nil, err
+		}
+		s.Elapsed = time.Duration(elapsedMS) * time.Millisecond
+		states = append(states, s)
+	}
+	return states, rows.Err()
+}
+
+// DeleteLiveCheckpoint entfernt die Sicherung eines Live-Spiels
+func DeleteLiveCheckpoint(matchID int) error {
+	_, err := db.Exec(`DELETE FROM live_checkpoints WHERE match_id = ?`, matchID)
+	return err
+}
+
+// LoadMatchEvents lädt die Ereignisse eines Spiels in zeitlicher Reihenfolge
+func LoadMatchEvents(matchID int) ([]models.MatchEvent, error) {
+	rows, err := db.Query(`SELECT id, match_id, type, COALESCE(side, ''), COALESCE(value, 0), COALESCE(detail, ''),
+		COALESCE(period, 1), COALESCE(elapsed_ms, 0), COALESCE(at, '')
+		FROM match_events WHERE match_id = ? ORDER BY id`, matchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []models.MatchEvent
+	for rows.Next() {
+		var ev models.MatchEvent
+		var elapsedMS int64
+		var at string
+		if err := rows.Scan(&ev.ID, &ev.MatchID, &ev.Type, &ev.Side, &ev.Value, &ev.Detail, &ev.Period, &elapsedMS, &at); err != nil {
+			return nil, err
+		}
+		ev.Elapsed = time.Duration(elapsedMS) * time.Millisecond
+		ev.At, _ = time.Parse(time.RFC3339Nano, at)
+		events = append(events, ev)
+	}
+	return events, rows.Err()
+}
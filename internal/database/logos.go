@@ -0,0 +1,136 @@
+// internal/database/logos.go
+
+package database
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// Logos liegen inhaltsadressiert in der Tabelle logos: der Schlüssel ist der
+// SHA-256 der Datei, gleiche Logos werden nur einmal gespeichert. Teams
+// verweisen über logo_hash (normalisiert) und logo_original_hash darauf.
+
+// LogoHash liefert den Schlüssel einer Logodatei
+func LogoHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// LoadLogo lädt eine Logodatei anhand ihres Hashes
+func LoadLogo(hash string) ([]byte, error) {
+	var data []byte
+	err := db.QueryRow(`SELECT data FROM logos WHERE hash = ?`, hash).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, i18n.Errorf("error.database.logo_missing", shortHash(hash))
+	}
+	return data, err
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
+
+// storeLogo legt eine Datei ab, sofern sie noch nicht vorhanden ist
+func storeLogo(ex execer, data []byte) (string, error) {
+	hash := LogoHash(data)
+	_, err := ex.Exec(`INSERT OR IGNORE INTO logos (hash, data) VALUES (?, ?)`, hash, data)
+	return hash, err
+}
+
+// prepareTeamLogos speichert mitgegebene Logodaten und setzt die Hashes;
+// ohne Daten muss ein angegebener Hash bereits existieren
+func prepareTeamLogos(q querier, team *models.Team) error {
+	for _, l := range []struct {
+		data []byte
+		hash *string
+	}{{team.LogoData, &team.LogoHash}, {team.LogoOriginal, &team.LogoOriginalHash}} {
+		if len(l.data) > 0 {
+			hash, err := storeLogo(q, l.data)
+			if err != nil {
+				return err
+			}
+			*l.hash = hash
+			continue
+		}
+		if *l.hash == "" {
+			continue
+		}
+		var n int
+		if err := q.QueryRow(`SELECT COUNT(*) FROM logos WHERE hash = ?`, *l.hash).Scan(&n); err != nil {
+			return err
+		}
+		if n == 0 {
+			return i18n.Errorf("error.database.logo_missing", shortHash(*l.hash))
+		}
+	}
+	return nil
+}
+
+// pruneLogos entfernt Logos, auf die kein Team mehr verweist
+func pruneLogos(q querier) error {
+	_, err := q.Exec(`DELETE FROM logos WHERE hash NOT IN (
+		SELECT logo_hash FROM teams WHERE logo_hash IS NOT NULL
+		UNION SELECT logo_original_hash FROM teams WHERE logo_original_hash IS NOT NULL)`)
+	return err
+}
+
+// migrateLogos verschiebt Logos aus den Spalten logo_data/logo_original der
+// Teams in die Tabelle logos. Die alten Spalten bleiben danach leer.
+func migrateLogos() error {
+	rows, err := db.Query(`SELECT id, logo_data, logo_original FROM teams
+		WHERE logo_hash IS NULL AND length(logo_data) > 0`)
+	if err != nil {
+		return err
+	}
+	type legacy struct {
+		id             int
+		data, original []byte
+	}
+	var teams []legacy
+	for rows.Next() {
+		var l legacy
+		if err := rows.Scan(&l.id, &l.data, &l.original); err != nil {
+			rows.Close()
+			return err
+		}
+		teams = append(teams, l)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(teams) == 0 {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, l := range teams {
+		hash, err := storeLogo(tx, l.data)
+		if err != nil {
+			return err
+		}
+		var original any
+		if len(l.original) > 0 {
+			if original, err = storeLogo(tx, l.original); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.Exec(`UPDATE teams SET logo_hash = ?, logo_original_hash = ?, logo_data = NULL, logo_original = NULL
+			WHERE id = ?`, hash, original, l.id); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,143 @@
+// internal/database/matchstatus.go
+
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// ErrMatchFinished wird geliefert, wenn ein beendetes Spiel ohne Override geändert werden soll
+var ErrMatchFinished = i18n.Errorf("error.match_finished")
+
+func loadMatchStatus(q querier, id int) (string, error) {
+	var status string
+	err := q.QueryRow(`SELECT COALESCE(status, 'scheduled') FROM matches WHERE id = ?`, id).Scan(&status)
+	return status, err
+}
+
+// SetMatchStatus wechselt den Status eines Spiels gemäß models.ValidateTransition.
+// Beendet werden kann ein Spiel nur mit gespeichertem Endstand.
+func SetMatchStatus(id int, status string) error {
+	return setMatchStatus(db, id, status)
+}
+
+func setMatchStatus(q querier, id int, status string) error {
+	current, err := loadMatchStatus(q, id)
+	if err != nil {
+		return err
+	}
+	if err := models.ValidateTransition(current, status); err != nil {
+		return err
+	}
+
+	if status == models.MatchFinished {
+		var hasResult bool
+		err := q.QueryRow(`SELECT score_home IS NOT NULL AND score_away IS NOT NULL FROM matches WHERE id = ?`, id).Scan(&hasResult)
+		if err != nil {
+			return err
+		}
+		if !hasResult {
+			return i18n.Errorf("error.match_without_result")
+		}
+	}
+
+	_, err = q.Exec(`UPDATE matches SET status = ? WHERE id = ?`, status, id)
+	return err
+}
+
+// SaveMatchesOverride speichert ein Match inkl. Status ohne Sperre und
+// Statusprüfung. Jede Änderung wird mit Begründung in match_audit protokolliert.
+func SaveMatchesOverride(match *models.Match, reason, actor string) error {
+	return saveMatchOverride(db, match, reason, actor)
+}
+
+func saveMatchOverride(q querier, match *models.Match, reason, actor string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return i18n.Errorf("error.override_reason")
+	}
+	if match.ID == 0 {
+		return i18n.Errorf("error.override_unsaved")
+	}
+	if _, ok := statusSet()[match.Status]; !ok {
+		return i18n.Errorf("error.unknown_status", match.Status)
+	}
+
+	before, err := loadMatch(q, match.ID)
+	if err != nil {
+		return err
+	}
+
+	return inTx(q, func(tx querier) error {
+		if err := updateMatch(tx, match); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`UPDATE matches SET status = ? WHERE id = ?`, match.Status, match.ID); err != nil {
+			return err
+		}
+		_, err := tx.Exec(`INSERT INTO match_audit (match_id, action, reason, actor, details, changed_at) VALUES (?, ?, ?, ?, ?, ?)`,
+			match.ID, "override", reason, actor, describeChange(before, match), time.Now().Format(time.RFC3339))
+		return err
+	})
+}
+
+// LoadMatchAudit lädt das Änderungsprotokoll eines Spiels, neueste zuerst
+func LoadMatchAudit(matchID int) ([]*models.MatchAuditEntry, error) {
+	rows, err := db.Query(`SELECT id, match_id, action, reason, COALESCE(actor, ''), COALESCE(details, ''), changed_at
+		FROM match_audit WHERE match_id = ? ORDER BY id DESC`, matchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*models.MatchAuditEntry
+	for rows.Next() {
+		var e models.MatchAuditEntry
+		if err := rows.Scan(&e.ID, &e.MatchID, &e.Action, &e.Reason, &e.Actor, &e.Details, &e.ChangedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
+
+func statusSet() map[string]struct{} {
+	set := map[string]struct{}{}
+	for _, s := range models.MatchStatuses() {
+		set[s] = struct{}{}
+	}
+	return set
+}
+
+func describeChange(before, after *models.Match) string {
+	var changes []string
+	if before.Status != after.Status {
+		changes = append(changes, i18n.T("audit.status", before.Status, after.Status))
+	}
+	if b, a := formatScore(before), formatScore(after); b != a {
+		changes = append(changes, i18n.T("audit.result", b, a))
+	}
+	if before.Team1.ID != after.Team1.ID || before.Team2.ID != after.Team2.ID {
+		changes = append(changes, i18n.T("audit.teams", before.Team1.ID, before.Team2.ID, after.Team1.ID, after.Team2.ID))
+	}
+	// Vergleich im gespeicherten Format, die Datenbank hält nur Sekunden
+	if b, a := before.GameTime.Format(time.RFC3339), after.GameTime.Format(time.RFC3339); b != a {
+		changes = append(changes, i18n.T("audit.kickoff", b, a))
+	}
+	if len(changes) == 0 {
+		return i18n.T("audit.unchanged")
+	}
+	return strings.Join(changes, "; ")
+}
+
+func formatScore(m *models.Match) string {
+	if !m.HasResult() {
+		return "-:-"
+	}
+	return fmt.Sprintf("%d:%d", *m.ScoreHome, *m.ScoreAway)
+}
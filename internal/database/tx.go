@@ -0,0 +1,136 @@
+// internal/database/tx.go
+
+package database
+
+import (
+	"database/sql"
+
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// Tx fasst Änderungen zusammen, die nur gemeinsam gespeichert werden dürfen,
+// z.B. einen Turnierplan samt Verknüpfungen oder einen Import
+type Tx struct {
+	q querier
+}
+
+// InTx führt fn in einer Transaktion aus. Liefert fn einen Fehler, wird
+// nichts gespeichert.
+func InTx(fn func(tx *Tx) error) error {
+	return inTx(db, func(q querier) error {
+		return fn(&Tx{q: q})
+	})
+}
+
+// inTx führt fn in einer neuen Transaktion aus; ist q bereits eine, läuft
+// fn darin mit und sie wird erst vom Aufrufer abgeschlossen
+func inTx(q querier, fn func(tx querier) error) error {
+	if _, ok := q.(*sql.Tx); ok {
+		return fn(q)
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// LoadSports wie database.LoadSports, innerhalb der Transaktion
+func (t *Tx) LoadSports() ([]*models.SportartDefinition, error) {
+	return loadSports(t.q)
+}
+
+// SaveSport wie database.SaveSport, innerhalb der Transaktion
+func (t *Tx) SaveSport(sport *models.SportartDefinition) error {
+	return saveSport(t.q, sport)
+}
+
+// LoadFonts wie database.LoadFonts, innerhalb der Transaktion
+func (t *Tx) LoadFonts() ([]*models.Font, error) {
+	return loadFonts(t.q)
+}
+
+// SaveFont wie database.SaveFont, innerhalb der Transaktion
+func (t *Tx) SaveFont(f *models.Font) error {
+	return saveFont(t.q, f)
+}
+
+// LoadTemplates wie database.LoadTemplates, innerhalb der Transaktion
+func (t *Tx) LoadTemplates() ([]*models.TemplateSettings, error) {
+	return loadTemplates(t.q)
+}
+
+// SaveTemplate wie database.SaveTemplate, innerhalb der Transaktion
+func (t *Tx) SaveTemplate(template *models.TemplateSettings) error {
+	return saveTemplate(t.q, template)
+}
+
+// SaveTemplateElements wie database.SaveTemplateElements, innerhalb der Transaktion
+func (t *Tx) SaveTemplateElements(templateID int, elements []*models.LayoutElement) error {
+	return saveTemplateElements(t.q, templateID, elements)
+}
+
+// SaveTemplateAssets wie database.SaveTemplateAssets, innerhalb der Transaktion
+func (t *Tx) SaveTemplateAssets(templateID int, assets []*models.TemplateAsset) error {
+	return saveTemplateAssets(t.q, templateID, assets)
+}
+
+// LoadVenues wie database.LoadVenues, innerhalb der Transaktion
+func (t *Tx) LoadVenues() ([]*models.Venue, error) {
+	return loadVenues(t.q)
+}
+
+// SaveVenue wie database.SaveVenue, innerhalb der Transaktion
+func (t *Tx) SaveVenue(venue *models.Venue) error {
+	return saveVenue(t.q, venue)
+}
+
+// SaveField wie database.SaveField, innerhalb der Transaktion
+func (t *Tx) SaveField(field *models.Field) error {
+	return saveField(t.q, field)
+}
+
+// LoadTeams wie database.LoadTeams, innerhalb der Transaktion
+func (t *Tx) LoadTeams() ([]*models.Team, error) {
+	return loadTeams(t.q)
+}
+
+// SaveTeam wie database.SaveTeam, innerhalb der Transaktion
+func (t *Tx) SaveTeam(team *models.Team) error {
+	return saveTeam(t.q, team)
+}
+
+// LoadMatches wie database.LoadMatches, innerhalb der Transaktion
+func (t *Tx) LoadMatches() ([]*models.Match, error) {
+	return loadMatches(t.q)
+}
+
+// LoadMatch wie database.LoadMatch, innerhalb der Transaktion
+func (t *Tx) LoadMatch(id int) (*models.Match, error) {
+	return loadMatch(t.q, id)
+}
+
+// SaveMatches wie database.SaveMatches, innerhalb der Transaktion
+func (t *Tx) SaveMatches(match *models.Match) error {
+	return saveMatch(t.q, match)
+}
+
+// SaveMatchesOverride wie database.SaveMatchesOverride, innerhalb der Transaktion
+func (t *Tx) SaveMatchesOverride(match *models.Match, reason, actor string) error {
+	return saveMatchOverride(t.q, match, reason, actor)
+}
+
+// SetMatchStatus wie database.SetMatchStatus, innerhalb der Transaktion
+func (t *Tx) SetMatchStatus(id int, status string) error {
+	return setMatchStatus(t.q, id, status)
+}
+
+// SaveMatchEvents wie database.SaveMatchEvents, innerhalb der Transaktion
+func (t *Tx) SaveMatchEvents(matchID int, events []models.MatchEvent) error {
+	return saveMatchEvents(t.q, matchID, events)
+}
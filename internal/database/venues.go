@@ -0,0 +1,266 @@
+// internal/database/venues.go
+
+package database
+
+import (
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// LoadVenues lädt alle Spielorte inkl. ihrer Felder
+func LoadVenues() ([]*models.Venue, error) {
+	return loadVenues(db)
+}
+
+func loadVenues(q querier) ([]*models.Venue, error) {
+	rows, err := q.Query(`SELECT id, name, COALESCE(address, '') FROM venues ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var venues []*models.Venue
+	byID := map[int]*models.Venue{}
+	for rows.Next() {
+		var v models.Venue
+		if err := rows.Scan(&v.ID, &v.Name, &v.Address); err != nil {
+			return nil, err
+		}
+		venues = append(venues, &v)
+		byID[v.ID] = &v
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	fields, err := loadFields(q, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range fields {
+		if v, ok := byID[f.VenueID]; ok {
+			v.Fields = append(v.Fields, f)
+		}
+	}
+	return venues, nil
+}
+
+// SaveVenue legt einen Spielort an oder aktualisiert ihn
+func SaveVenue(venue *models.Venue) error {
+	return saveVenue(db, venue)
+}
+
+func saveVenue(q querier, venue *models.Venue) error {
+	if venue.ID == 0 {
+		res, err := q.Exec(`INSERT INTO venues (name, address) VALUES (?, ?)`, venue.Name, venue.Address)
+		if err != nil {
+			return err
+		}
+		lastID, _ := res.LastInsertId()
+		venue.ID = int(lastID)
+		return nil
+	}
+
+	_, err := q.Exec(`UPDATE venues SET name = ?, address = ? WHERE id = ?`, venue.Name, venue.Address, venue.ID)
+	return err
+}
+
+// DeleteVenue löscht einen Spielort samt Feldern; zugeordnete Spiele verlieren ihr Feld
+func DeleteVenue(id int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`UPDATE matches SET field_id = 0 WHERE field_id IN (SELECT id FROM fields WHERE venue_id = ?)`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM fields WHERE venue_id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM venues WHERE id = ?`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+const fieldSelect = `SELECT f.id, f.venue_id, f.name, COALESCE(f.template_id, 0), COALESCE(v.name, '')
+	FROM fields f
+	LEFT JOIN venues v ON f.venue_id = v.id`
+
+// LoadFields lädt die Felder eines Spielorts, bei venueID 0 alle Felder
+func LoadFields(venueID int) ([]*models.Field, error) {
+	return loadFields(db, venueID)
+}
+
+func loadFields(q querier, venueID int) ([]*models.Field, error) {
+	query := fieldSelect + ` ORDER BY v.name, f.name`
+	var args []any
+	if venueID != 0 {
+		query = fieldSelect + ` WHERE f.venue_id = ? ORDER BY f.name`
+		args = append(args, venueID)
+	}
+
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fields []*models.Field
+	for rows.Next() {
+		var f models.Field
+		if err := rows.Scan(&f.ID, &f.VenueID, &f.Name, &f.TemplateID, &f.VenueName); err != nil {
+			return nil, err
+		}
+		fields = append(fields, &f)
+	}
+	return fields, rows.Err()
+}
+
+// LoadField lädt ein einzelnes Feld
+func LoadField(id int) (*models.Field, error) {
+	return loadField(db, id)
+}
+
+func loadField(q querier, id int) (*models.Field, error) {
+	var f models.Field
+	err := q.QueryRow(fieldSelect+` WHERE f.id = ?`, id).Scan(&f.ID, &f.VenueID, &f.Name, &f.TemplateID, &f.VenueName)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// SaveField legt ein Feld an oder aktualisiert es
+func SaveField(field *models.Field) error {
+	return saveField(db, field)
+}
+
+func saveField(q querier, field *models.Field) error {
+	if field.VenueID == 0 {
+		return i18n.Errorf("error.database.field_venue")
+	}
+	if field.ID == 0 {
+		res, err := q.Exec(`INSERT INTO fields (venue_id, name, template_id) VALUES (?, ?, ?)`,
+			field.VenueID, field.Name, field.TemplateID)
+		if err != nil {
+			return err
+		}
+		lastID, _ := res.LastInsertId()
+		field.ID = int(lastID)
+		return nil
+	}
+
+	_, err := q.Exec(`UPDATE fields SET venue_id = ?, name = ?, template_id = ? WHERE id = ?`,
+		field.VenueID, field.Name, field.TemplateID, field.ID)
+	return err
+}
+
+// DeleteField löscht ein Feld; zugeordnete Spiele verlieren ihr Feld
+func DeleteField(id int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`UPDATE matches SET field_id = 0 WHERE field_id = ?`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM fields WHERE id = ?`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// LoadMatchesByField lädt alle Spiele eines Feldes in zeitlicher Reihenfolge
+func LoadMatchesByField(fieldID int) ([]*models.Match, error) {
+	return queryMatches(db, matchSelect+` WHERE m.field_id = ? ORDER BY m.start_time`, fieldID)
+}
+
+// ErrNoCurrentMatch liefert CurrentMatchForField, wenn auf dem Feld nichts ansteht
+var ErrNoCurrentMatch = i18n.Errorf("error.database.no_current_match")
+
+// CurrentMatchForField liefert das Spiel, das die Anzeige des Feldes zeigen soll:
+// ein laufendes Spiel, sonst das nächste geplante Spiel ab dem Tag von now.
+// Steht auf dem Feld nichts an, kommt ErrNoCurrentMatch.
+func CurrentMatchForField(fieldID int, now time.Time) (*models.Match, error) {
+	matches, err := LoadMatchesByField(fieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range matches {
+		if m.Status == models.MatchLive || m.Status == models.MatchHalftime {
+			return m, nil
+		}
+	}
+
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	var next *models.Match
+	for _, m := range matches {
+		if m.Status != models.MatchScheduled || m.GameTime.Before(day) {
+			continue
+		}
+		if next == nil || m.GameTime.Before(next.GameTime) {
+			next = m
+		}
+	}
+	if next == nil {
+		return nil, ErrNoCurrentMatch
+	}
+	return next, nil
+}
+
+// ResolveMatchTemplate liefert das Template eines Spiels; ohne eigenes
+// Template gilt das Standard-Template des zugeordneten Feldes. Die Farben
+// der beiden Teams stehen als Theme-Variablen bereit.
+func ResolveMatchTemplate(match *models.Match) (*models.TemplateSettings, error) {
+	id, err := matchTemplateID(db, match)
+	if err != nil {
+		return nil, err
+	}
+	if id == 0 {
+		return nil, i18n.Errorf("error.database.no_template")
+	}
+	t, err := FlattenTemplate(id)
+	if err != nil {
+		return nil, err
+	}
+	t.SetTeamColors(matchTeam(match.Team1), matchTeam(match.Team2))
+	if err := t.ApplyTheme(); err != nil {
+		return nil, i18n.Errorf("error.wrap.template", t.Name, err)
+	}
+	return t, nil
+}
+
+// matchTeam lädt ein Team eines Spiels mit seinen Farben; offene
+// Turnierplätze haben noch kein Team
+func matchTeam(t *models.Team) *models.Team {
+	if t == nil || t.ID == 0 {
+		return nil
+	}
+	team, err := LoadTeam(t.ID)
+	if err != nil {
+		return nil
+	}
+	return team
+}
+
+func matchTemplateID(q querier, match *models.Match) (int, error) {
+	if match.TemplateSettings != nil && match.TemplateSettings.ID != 0 {
+		return match.TemplateSettings.ID, nil
+	}
+	if match.FieldID == 0 {
+		return 0, nil
+	}
+	field, err := loadField(q, match.FieldID)
+	if err != nil {
+		return 0, err
+	}
+	return field.TemplateID, nil
+}
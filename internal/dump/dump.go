@@ -0,0 +1,307 @@
+// internal/dump/dump.go
+
+// Package dump exportiert den kompletten Datenbestand als versionierte
+// JSON-Datei und führt eine solche Datei mit einer vorhandenen Datenbank
+// zusammen, z.B. beim Umzug auf einen neuen PC oder für den Nachbarverein.
+//
+// IDs in der Datei gelten nur innerhalb der Datei; Import vergibt neue und
+// setzt alle Verweise (Teams, Templates, Felder, Turnierbaum) um. Logos,
+// Schriften und Template-Dateien stehen base64-codiert in der Datei.
+package dump
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+const (
+	Format  = "scoreboard-dump"
+	Version = 1 // aktuelle Formatversion; neuere Dateien werden abgelehnt
+)
+
+// Dump ist der Inhalt einer Exportdatei
+type Dump struct {
+	Format    string
+	Version   int
+	Exported  time.Time
+	Sports    []*models.SportartDefinition
+	Fonts     []*models.Font
+	Templates []*models.TemplateSettings // mit Elementen und Dateien, ParentID verweist in die Datei
+	Venues    []*models.Venue            // mit Feldern
+	Teams     []*models.Team             // mit LogoData und LogoOriginal
+	Matches   []*Match
+}
+
+// Match ist ein Spiel mit Verweisen auf IDs in der Datei und seinen Ereignissen
+type Match struct {
+	ID          int
+	ICalUID     string // erkennt das Spiel beim Import wieder
+	Sportart    string
+	Competition string
+	GameTime    time.Time
+	Status      string
+	ScoreHome   *int
+	ScoreAway   *int
+	HomeTeamID  int // 0 = noch offen (Turnier)
+	AwayTeamID  int
+	TemplateID  int
+	FieldID     int
+	Field       string
+
+	Round              int
+	Group              string
+	Bracket            string
+	NextMatchID        int
+	NextMatchSlot      string
+	LoserNextMatchID   int
+	LoserNextMatchSlot string
+
+	Events []models.MatchEvent
+}
+
+// Export liest den gesamten Datenbestand
+func Export() (*Dump, error) {
+	d := &Dump{Format: Format, Version: Version, Exported: time.Now().UTC().Truncate(time.Second)}
+	var err error
+	if d.Sports, err = database.LoadSports(); err != nil {
+		return nil, err
+	}
+	if d.Fonts, err = database.LoadFonts(); err != nil {
+		return nil, err
+	}
+	if d.Templates, err = database.LoadTemplates(); err != nil {
+		return nil, err
+	}
+	if d.Venues, err = database.LoadVenues(); err != nil {
+		return nil, err
+	}
+	if d.Teams, err = database.LoadTeams(); err != nil {
+		return nil, err
+	}
+	for _, t := range d.Teams {
+		if t.LogoHash != "" {
+			if t.LogoData, err = database.LoadLogo(t.LogoHash); err != nil {
+				return nil, i18n.Errorf("error.wrap.team", t.Name, err)
+			}
+		}
+		if t.LogoOriginalHash != "" {
+			if t.LogoOriginal, err = database.LoadLogo(t.LogoOriginalHash); err != nil {
+				return nil, i18n.Errorf("error.wrap.team", t.Name, err)
+			}
+		}
+	}
+
+	matches, err := database.LoadMatches()
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(matches, func(a, b *models.Match) int { return a.ID - b.ID })
+	for _, m := range matches {
+		rec := fromModel(m)
+		if rec.Events, err = database.LoadMatchEvents(m.ID); err != nil {
+			return nil, err
+		}
+		d.Matches = append(d.Matches, rec)
+	}
+	return d, nil
+}
+
+func fromModel(m *models.Match) *Match {
+	return &Match{
+		ID:                 m.ID,
+		ICalUID:            m.ICalUID,
+		Sportart:           m.Sportart,
+		Competition:        m.Competition,
+		GameTime:           m.GameTime,
+		Status:             m.Status,
+		ScoreHome:          m.ScoreHome,
+		ScoreAway:          m.ScoreAway,
+		HomeTeamID:         m.Team1.ID,
+		AwayTeamID:         m.Team2.ID,
+		TemplateID:         m.TemplateSettings.ID,
+		FieldID:            m.FieldID,
+		Field:              m.Field,
+		Round:              m.Round,
+		Group:              m.Group,
+		Bracket:            m.Bracket,
+		NextMatchID:        m.NextMatchID,
+		NextMatchSlot:      m.NextMatchSlot,
+		LoserNextMatchID:   m.LoserNextMatchID,
+		LoserNextMatchSlot: m.LoserNextMatchSlot,
+	}
+}
+
+// Write schreibt die Datei als eingerücktes JSON
+func (d *Dump) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
+
+// Decode liest eine Exportdatei und prüft sie. Format und Version werden
+// zuerst gelesen, damit neuere Dateien eine verständliche Meldung liefern.
+func Decode(data []byte) (*Dump, error) {
+	var head struct {
+		Format  string
+		Version int
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, i18n.Errorf("error.dump.not_a_dump", err)
+	}
+	if head.Format != Format {
+		return nil, i18n.Errorf("error.dump.format", head.Format)
+	}
+	if head.Version < 1 || head.Version > Version {
+		return nil, i18n.Errorf("error.dump.version", head.Version, Version)
+	}
+
+	var d Dump
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&d); err != nil {
+		return nil, i18n.Errorf("error.dump.invalid", err)
+	}
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// Validate prüft Namen und alle Verweise innerhalb der Datei
+func (d *Dump) Validate() error {
+	var errs []error
+	for _, s := range d.Sports {
+		if strings.TrimSpace(s.Sportart) == "" {
+			errs = append(errs, i18n.Errorf("error.dump.sport_name"))
+		}
+	}
+	for _, f := range d.Fonts {
+		if strings.TrimSpace(f.Name) == "" || len(f.Data) == 0 {
+			errs = append(errs, i18n.Errorf("error.dump.font"))
+		}
+	}
+
+	templates, err := ids("dump.kind.template", d.Templates, func(t *models.TemplateSettings) int { return t.ID })
+	errs = append(errs, err...)
+	for _, t := range d.Templates {
+		if strings.TrimSpace(t.Name) == "" {
+			errs = append(errs, i18n.Errorf("error.dump.template_name", t.ID))
+		}
+		if t.ParentID != 0 && !templates[t.ParentID] {
+			errs = append(errs, i18n.Errorf("error.dump.template_parent", t.Name, t.ParentID))
+		}
+	}
+	if _, err := templateOrder(d.Templates); err != nil {
+		errs = append(errs, err)
+	}
+
+	var fieldList []*models.Field
+	for _, v := range d.Venues {
+		if strings.TrimSpace(v.Name) == "" {
+			errs = append(errs, i18n.Errorf("error.dump.venue_name", v.ID))
+		}
+		for _, f := range v.Fields {
+			fieldList = append(fieldList, f)
+			if f.TemplateID != 0 && !templates[f.TemplateID] {
+				errs = append(errs, i18n.Errorf("error.dump.field_template", f.Name, f.TemplateID))
+			}
+		}
+	}
+	fields, err := ids("dump.kind.field", fieldList, func(f *models.Field) int { return f.ID })
+	errs = append(errs, err...)
+
+	teams, err := ids("dump.kind.team", d.Teams, func(t *models.Team) int { return t.ID })
+	errs = append(errs, err...)
+	for _, t := range d.Teams {
+		if strings.TrimSpace(t.Name) == "" {
+			errs = append(errs, i18n.Errorf("error.dump.team_name", t.ID))
+		}
+	}
+
+	matches, err := ids("dump.kind.match", d.Matches, func(m *Match) int { return m.ID })
+	errs = append(errs, err...)
+	for _, m := range d.Matches {
+		what := i18n.M("error.dump.match", m.ID)
+		for _, ref := range []struct {
+			kind string
+			id   int
+			ok   map[int]bool
+		}{
+			{"dump.kind.home", m.HomeTeamID, teams}, {"dump.kind.away", m.AwayTeamID, teams},
+			{"dump.kind.template", m.TemplateID, templates}, {"dump.kind.field", m.FieldID, fields},
+			{"dump.kind.next", m.NextMatchID, matches}, {"dump.kind.loser_next", m.LoserNextMatchID, matches},
+		} {
+			if ref.id != 0 && !ref.ok[ref.id] {
+				errs = append(errs, i18n.Errorf("error.dump.reference", what, i18n.M(ref.kind), ref.id))
+			}
+		}
+		if !slices.Contains(models.MatchStatuses(), m.Status) {
+			errs = append(errs, i18n.Errorf("error.dump.status", what, m.Status))
+		}
+	}
+	if _, err := matchOrder(d.Matches); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// ids sammelt die IDs einer Liste und meldet doppelte oder fehlende; kind
+// ist der Schlüssel der Bezeichnung
+func ids[T any](kind string, list []T, id func(T) int) (map[int]bool, []error) {
+	seen := map[int]bool{}
+	var errs []error
+	for _, v := range list {
+		switch n := id(v); {
+		case n <= 0:
+			errs = append(errs, i18n.Errorf("error.dump.id", i18n.M(kind)))
+		case seen[n]:
+			errs = append(errs, i18n.Errorf("error.dump.duplicate", i18n.M(kind), n))
+		default:
+			seen[n] = true
+		}
+	}
+	return seen, errs
+}
+
+// templateOrder sortiert Templates so, dass Eltern vor ihren Kindern kommen
+func templateOrder(templates []*models.TemplateSettings) ([]*models.TemplateSettings, error) {
+	byID := map[int]*models.TemplateSettings{}
+	for _, t := range templates {
+		byID[t.ID] = t
+	}
+	var order []*models.TemplateSettings
+	state := map[int]int{} // 1 = in Arbeit, 2 = fertig
+	var visit func(t *models.TemplateSettings) error
+	visit = func(t *models.TemplateSettings) error {
+		switch state[t.ID] {
+		case 1:
+			return i18n.Errorf("error.dump.template_cycle", t.Name)
+		case 2:
+			return nil
+		}
+		state[t.ID] = 1
+		if p, ok := byID[t.ParentID]; ok && t.ParentID != 0 {
+			if err := visit(p); err != nil {
+				return err
+			}
+		}
+		state[t.ID] = 2
+		order = append(order, t)
+		return nil
+	}
+	for _, t := range templates {
+		if err := visit(t); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
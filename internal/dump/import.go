@@ -0,0 +1,508 @@
+// internal/dump/import.go
+
+package dump
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// Was Import mit einem Eintrag gemacht hat
+const (
+	ActionCreated  = "created"
+	ActionSkipped  = "skipped"
+	ActionReplaced = "replaced"
+	ActionRenamed  = "renamed"
+)
+
+// Entry ist ein importierter Eintrag
+type Entry struct {
+	Kind   string // "sport", "team", ...; Bezeichnung mit KindName
+	Name   string // Name in der Datenbank, bei ActionRenamed der neue
+	Action string
+	ID     int // ID in der Datenbank
+}
+
+// Report ist das Ergebnis von Import
+type Report struct {
+	Snapshot string // Sicherung des Stands vor dem Import
+	Entries  []Entry
+}
+
+// Count zählt die Einträge einer Art mit einer Aktion
+func (r *Report) Count(kind, action string) int {
+	n := 0
+	for _, e := range r.Entries {
+		if e.Kind == kind && e.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// KindName liefert die übersetzte Bezeichnung einer Art
+func KindName(kind string) string {
+	return i18n.T("dump.kind." + kind)
+}
+
+// Kinds liefert die Arten in Importreihenfolge
+func Kinds() []string {
+	return []string{kindSport, kindFont, kindTemplate, kindVenue, kindTeam, kindMatch}
+}
+
+const (
+	kindSport    = "sport"
+	kindFont     = "font"
+	kindTemplate = "template"
+	kindVenue    = "venue"
+	kindTeam     = "team"
+	kindMatch    = "match"
+)
+
+// importer hält die Zuordnung der IDs aus der Datei zu den neuen IDs
+type importer struct {
+	tx        *database.Tx
+	policy    string
+	report    *Report
+	sports    map[string]string // Sportart in der Datei → in der Datenbank
+	templates map[int]int
+	fields    map[int]int
+	teams     map[int]int
+	matches   map[int]int
+}
+
+// Import führt die Datei mit der geöffneten Datenbank zusammen; ohne policy
+// gilt models.ConflictSkip; Sportarten werden auch bei models.ConflictRename
+// nicht doppelt angelegt. Erkannt werden Sportarten, Schriften, Templates
+// und Spielorte am Namen, Teams an Name und Sportart, Spiele an ihrer
+// Kalender-UID. Alles geschieht in einer Transaktion, bei einem Fehler bleibt
+// die Datenbank unverändert. Der vorher angelegte Snapshot erlaubt es, auch
+// einen gelungenen Import zurückzunehmen (siehe database.Restore).
+func Import(d *Dump, policy string) (*Report, error) {
+	policy = cmp.Or(policy, models.ConflictSkip)
+	if err := models.ValidateConflictPolicy(policy); err != nil {
+		return nil, err
+	}
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	snap, err := database.CreateSnapshot(database.SnapshotImport)
+	if err != nil {
+		return nil, i18n.Errorf("error.dump.snapshot", err)
+	}
+
+	im := &importer{
+		policy:    policy,
+		report:    &Report{Snapshot: snap.Path},
+		sports:    map[string]string{},
+		templates: map[int]int{},
+		fields:    map[int]int{},
+		teams:     map[int]int{},
+		matches:   map[int]int{},
+	}
+	err = database.InTx(func(tx *database.Tx) error {
+		im.tx = tx
+		for _, step := range []func(*Dump) error{im.importSports, im.importFonts, im.importTemplates, im.importVenues, im.importTeams, im.importMatches} {
+			if err := step(d); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, i18n.Errorf("error.dump.import", err)
+	}
+	return im.report, nil
+}
+
+// resolve entscheidet über einen Eintrag; taken prüft, ob ein Name vergeben ist
+func (im *importer) resolve(kind, name string, exists bool, taken func(string) bool) (action, newName string, err error) {
+	switch {
+	case !exists:
+		return ActionCreated, name, nil
+	case im.policy == models.ConflictReplace:
+		return ActionReplaced, name, nil
+	case im.policy == models.ConflictRename:
+		return ActionRenamed, models.UniqueName(name, taken), nil
+	case im.policy == models.ConflictFail:
+		return "", "", i18n.Errorf("error.dump.exists", i18n.M("dump.kind."+kind), name)
+	}
+	return ActionSkipped, name, nil
+}
+
+func (im *importer) add(kind, name, action string, id int) {
+	im.report.Entries = append(im.report.Entries, Entry{Kind: kind, Name: name, Action: action, ID: id})
+}
+
+// byName baut eine Suche ohne Beachtung der Groß-/Kleinschreibung
+func byName[T any](list []T, name func(T) string) (find func(string) (T, bool)) {
+	m := map[string]T{}
+	for _, v := range list {
+		m[strings.ToLower(name(v))] = v
+	}
+	return func(n string) (T, bool) {
+		v, ok := m[strings.ToLower(n)]
+		return v, ok
+	}
+}
+
+func (im *importer) sport(name string) string {
+	return cmp.Or(im.sports[strings.ToLower(name)], name)
+}
+
+func (im *importer) importSports(d *Dump) error {
+	existing, err := im.tx.LoadSports()
+	if err != nil {
+		return err
+	}
+	for _, src := range d.Sports {
+		find := byName(existing, func(s *models.SportartDefinition) string { return s.Sportart })
+		taken := func(n string) bool { _, ok := find(n); return ok }
+		old, exists := find(src.Sportart)
+		action, name, err := im.resolve(kindSport, src.Sportart, exists, taken)
+		if err != nil {
+			return err
+		}
+		if action == ActionRenamed {
+			// Teams und Spiele hängen am Namen der Sportart; eine Kopie
+			// "Fußball (2)" würde die Tabellen aufteilen
+			action = ActionSkipped
+		}
+
+		s := *src
+		s.ID, s.Sportart = 0, name
+		switch action {
+		case ActionSkipped:
+			s = *old
+		case ActionReplaced:
+			s.ID = old.ID
+		}
+		if action != ActionSkipped {
+			if err := im.tx.SaveSport(&s); err != nil {
+				return i18n.Errorf("error.wrap.sport", src.Sportart, err)
+			}
+			existing = append(existing, &s)
+		}
+		im.sports[strings.ToLower(src.Sportart)] = s.Sportart
+		im.add(kindSport, s.Sportart, action, s.ID)
+	}
+	return nil
+}
+
+func (im *importer) importFonts(d *Dump) error {
+	existing, err := im.tx.LoadFonts()
+	if err != nil {
+		return err
+	}
+	for _, src := range d.Fonts {
+		find := byName(existing, func(f *models.Font) string { return f.Name })
+		taken := func(n string) bool { _, ok := find(n); return ok }
+		old, exists := find(src.Name)
+		action, name, err := im.resolve(kindFont, src.Name, exists, taken)
+		if err != nil {
+			return err
+		}
+
+		f := &models.Font{Name: name, Data: src.Data}
+		if action == ActionSkipped {
+			f = old
+		} else {
+			if exists && action == ActionReplaced {
+				f.Name = old.Name // SaveFont ersetzt nur bei exakt gleichem Namen
+			}
+			if err := im.tx.SaveFont(f); err != nil {
+				return i18n.Errorf("error.wrap.font", src.Name, err)
+			}
+			existing = append(existing, f)
+		}
+		im.add(kindFont, f.Name, action, f.ID)
+	}
+	return nil
+}
+
+func (im *importer) importTemplates(d *Dump) error {
+	existing, err := im.tx.LoadTemplates()
+	if err != nil {
+		return err
+	}
+	order, err := templateOrder(d.Templates)
+	if err != nil {
+		return err
+	}
+	for _, src := range order {
+		find := byName(existing, func(t *models.TemplateSettings) string { return t.Name })
+		taken := func(n string) bool { _, ok := find(n); return ok }
+		old, exists := find(src.Name)
+		action, name, err := im.resolve(kindTemplate, src.Name, exists, taken)
+		if err != nil {
+			return err
+		}
+		if action == ActionSkipped {
+			im.templates[src.ID] = old.ID
+			im.add(kindTemplate, old.Name, action, old.ID)
+			continue
+		}
+
+		t := *src
+		t.ID, t.Name = 0, name
+		if action == ActionReplaced {
+			t.ID = old.ID
+		}
+		t.ParentID = im.templates[src.ParentID]
+		if t.Sportart != "" {
+			t.Sportart = im.sport(t.Sportart)
+		}
+		t.Elements, t.Assets = nil, nil
+		for _, e := range src.Elements {
+			c := *e
+			c.ID, c.TemplateID = 0, 0
+			t.Elements = append(t.Elements, &c)
+		}
+		for _, a := range src.Assets {
+			c := *a
+			c.ID, c.TemplateID = 0, 0
+			t.Assets = append(t.Assets, &c)
+		}
+		if err := im.tx.SaveTemplate(&t); err != nil {
+			return i18n.Errorf("error.wrap.template", src.Name, err)
+		}
+		if err := im.tx.SaveTemplateElements(t.ID, t.Elements); err != nil {
+			return i18n.Errorf("error.wrap.template", src.Name, err)
+		}
+		if err := im.tx.SaveTemplateAssets(t.ID, t.Assets); err != nil {
+			return i18n.Errorf("error.wrap.template", src.Name, err)
+		}
+		existing = append(existing, &t)
+		im.templates[src.ID] = t.ID
+		im.add(kindTemplate, t.Name, action, t.ID)
+	}
+	return nil
+}
+
+// importVenues ordnet Felder eines behaltenen oder überschriebenen Spielorts
+// am Namen zu; fehlende Felder werden in jedem Fall angelegt
+func (im *importer) importVenues(d *Dump) error {
+	existing, err := im.tx.LoadVenues()
+	if err != nil {
+		return err
+	}
+	for _, src := range d.Venues {
+		find := byName(existing, func(v *models.Venue) string { return v.Name })
+		taken := func(n string) bool { _, ok := find(n); return ok }
+		old, exists := find(src.Name)
+		action, name, err := im.resolve(kindVenue, src.Name, exists, taken)
+		if err != nil {
+			return err
+		}
+
+		v := &models.Venue{Name: name, Address: src.Address}
+		var oldFields []*models.Field
+		switch action {
+		case ActionSkipped:
+			v, oldFields = old, old.Fields
+		case ActionReplaced:
+			v.ID, oldFields = old.ID, old.Fields
+		}
+		if action != ActionSkipped {
+			if err := im.tx.SaveVenue(v); err != nil {
+				return i18n.Errorf("error.wrap.venue", src.Name, err)
+			}
+			existing = append(existing, v)
+		}
+
+		findField := byName(oldFields, func(f *models.Field) string { return f.Name })
+		for _, sf := range src.Fields {
+			f := &models.Field{VenueID: v.ID, Name: sf.Name, TemplateID: im.templates[sf.TemplateID]}
+			if of, ok := findField(sf.Name); ok {
+				if action == ActionSkipped {
+					im.fields[sf.ID] = of.ID
+					continue
+				}
+				f.ID = of.ID
+			}
+			if err := im.tx.SaveField(f); err != nil {
+				return i18n.Errorf("error.wrap.field", sf.Name, err)
+			}
+			im.fields[sf.ID] = f.ID
+		}
+		im.add(kindVenue, v.Name, action, v.ID)
+	}
+	return nil
+}
+
+func (im *importer) importTeams(d *Dump) error {
+	existing, err := im.tx.LoadTeams()
+	if err != nil {
+		return err
+	}
+	key := func(name, sport string) string { return name + "\x00" + strings.ToLower(sport) }
+	for _, src := range d.Teams {
+		sport := im.sport(src.Sportart)
+		find := byName(existing, func(t *models.Team) string { return key(t.Name, t.Sportart) })
+		taken := func(n string) bool { _, ok := find(key(n, sport)); return ok }
+		old, exists := find(key(src.Name, sport))
+		action, name, err := im.resolve(kindTeam, src.Name, exists, taken)
+		if err != nil {
+			return err
+		}
+		if action == ActionSkipped {
+			im.teams[src.ID] = old.ID
+			im.add(kindTeam, old.Name, action, old.ID)
+			continue
+		}
+
+		t := *src
+		t.ID, t.Name, t.Sportart = 0, name, sport
+		if action == ActionReplaced {
+			t.ID = old.ID
+		}
+		// die Hashes ergeben sich aus den Logodaten, ohne Daten kein Logo
+		t.LogoHash, t.LogoOriginalHash = "", ""
+		if err := im.tx.SaveTeam(&t); err != nil {
+			return i18n.Errorf("error.wrap.team", src.Name, err)
+		}
+		existing = append(existing, &t)
+		im.teams[src.ID] = t.ID
+		im.add(kindTeam, t.Name, action, t.ID)
+	}
+	return nil
+}
+
+// importMatches legt Folgespiele vor den Spielen an, die auf sie verweisen,
+// damit der Turnierbaum gleich mit den neuen IDs gespeichert wird
+func (im *importer) importMatches(d *Dump) error {
+	existing, err := im.tx.LoadMatches()
+	if err != nil {
+		return err
+	}
+	byUID := map[string]*models.Match{}
+	for _, m := range existing {
+		if m.ICalUID != "" {
+			byUID[m.ICalUID] = m
+		}
+	}
+	teamNames := map[int]string{}
+	for _, t := range d.Teams {
+		teamNames[t.ID] = t.Name
+	}
+
+	order, err := matchOrder(d.Matches)
+	if err != nil {
+		return err
+	}
+	open := i18n.T("format.team_open")
+	for _, src := range order {
+		label := fmt.Sprintf("%s – %s (%s)", cmp.Or(teamNames[src.HomeTeamID], open),
+			cmp.Or(teamNames[src.AwayTeamID], open), src.GameTime.Local().Format("2006-01-02 15:04"))
+		old, exists := byUID[src.ICalUID]
+		exists = exists && src.ICalUID != ""
+		action, _, err := im.resolve(kindMatch, label, exists, func(string) bool { return false })
+		if err != nil {
+			return err
+		}
+		if action == ActionSkipped {
+			im.matches[src.ID] = old.ID
+			im.add(kindMatch, label, action, old.ID)
+			continue
+		}
+
+		m := im.toModel(src)
+		switch action {
+		case ActionRenamed:
+			// Spiele haben keinen Namen: ein weiteres Spiel mit neuer UID
+			m.ICalUID, action = "", ActionCreated
+		case ActionReplaced:
+			m.ID = old.ID
+		}
+		if action == ActionReplaced && (old.Status != m.Status || old.Status == models.MatchFinished) {
+			err = im.tx.SaveMatchesOverride(m, i18n.T("audit.import_reason"), i18n.T("audit.import_actor"))
+		} else {
+			err = im.tx.SaveMatches(m)
+		}
+		if err != nil {
+			return i18n.Errorf("error.wrap.match", label, err)
+		}
+		events := make([]models.MatchEvent, len(src.Events))
+		for i, ev := range src.Events {
+			ev.ID, ev.MatchID = 0, m.ID
+			events[i] = ev
+		}
+		if err := im.tx.SaveMatchEvents(m.ID, events); err != nil {
+			return i18n.Errorf("error.wrap.match", label, err)
+		}
+		im.matches[src.ID] = m.ID
+		im.add(kindMatch, label, action, m.ID)
+	}
+	return nil
+}
+
+// toModel setzt die IDs der Datei um. Spiele, die beim Export live waren,
+// kommen als geplant an: ohne Live-Engine ließen sie sich nicht beenden.
+func (im *importer) toModel(src *Match) *models.Match {
+	status := src.Status
+	if status == models.MatchLive || status == models.MatchHalftime {
+		status = models.MatchScheduled
+	}
+	return &models.Match{
+		ICalUID:            src.ICalUID,
+		Sportart:           im.sport(src.Sportart),
+		Competition:        src.Competition,
+		GameTime:           src.GameTime,
+		Status:             status,
+		ScoreHome:          src.ScoreHome,
+		ScoreAway:          src.ScoreAway,
+		Team1:              &models.Team{ID: im.teams[src.HomeTeamID]},
+		Team2:              &models.Team{ID: im.teams[src.AwayTeamID]},
+		TemplateSettings:   &models.TemplateSettings{ID: im.templates[src.TemplateID]},
+		FieldID:            im.fields[src.FieldID],
+		Field:              src.Field,
+		Round:              src.Round,
+		Group:              src.Group,
+		Bracket:            src.Bracket,
+		NextMatchID:        im.matches[src.NextMatchID],
+		NextMatchSlot:      src.NextMatchSlot,
+		LoserNextMatchID:   im.matches[src.LoserNextMatchID],
+		LoserNextMatchSlot: src.LoserNextMatchSlot,
+	}
+}
+
+// matchOrder sortiert Spiele so, dass Folgespiele zuerst kommen
+func matchOrder(matches []*Match) ([]*Match, error) {
+	byID := map[int]*Match{}
+	for _, m := range matches {
+		byID[m.ID] = m
+	}
+	var order []*Match
+	state := map[int]int{} // 1 = in Arbeit, 2 = fertig
+	var visit func(m *Match) error
+	visit = func(m *Match) error {
+		switch state[m.ID] {
+		case 1:
+			return i18n.Errorf("error.dump.bracket_cycle", m.ID)
+		case 2:
+			return nil
+		}
+		state[m.ID] = 1
+		for _, next := range []int{m.NextMatchID, m.LoserNextMatchID} {
+			if n, ok := byID[next]; ok && next != 0 {
+				if err := visit(n); err != nil {
+					return err
+				}
+			}
+		}
+		state[m.ID] = 2
+		order = append(order, m)
+		return nil
+	}
+	for _, m := range matches {
+		if err := visit(m); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
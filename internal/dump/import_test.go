@@ -0,0 +1,129 @@
+// internal/dump/import_test.go
+
+package dump
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// openTestDB öffnet eine leere Datenbank mit dem Team "Adler"
+func openTestDB(t *testing.T) {
+	t.Helper()
+	if err := database.Open(filepath.Join(t.TempDir(), "test.db")); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(database.Close)
+	if err := database.SaveTeam(&models.Team{Name: "Adler", Sportart: "Fußball"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testDump enthält eine neue Sportart und ein Team, das es schon gibt
+func testDump() *Dump {
+	return &Dump{
+		Format:  Format,
+		Version: Version,
+		Sports:  []*models.SportartDefinition{{ID: 1, Sportart: "Curling", PeriodLabel: "End", PeriodsCount: 8}},
+		Teams:   []*models.Team{{ID: 1, Name: "Adler", Sportart: "Fußball"}},
+	}
+}
+
+func teamNames(t *testing.T) []string {
+	t.Helper()
+	teams, err := database.LoadTeams()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, team := range teams {
+		names = append(names, team.Name)
+	}
+	return names
+}
+
+func hasSport(t *testing.T, name string) bool {
+	t.Helper()
+	sports, err := database.LoadSports()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range sports {
+		if s.Sportart == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestImportRename(t *testing.T) {
+	openTestDB(t)
+	report, err := Import(testDump(), models.ConflictRename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := report.Count(kindTeam, ActionRenamed); n != 1 {
+		t.Errorf("%d Teams umbenannt, erwartet 1", n)
+	}
+	if got := teamNames(t); len(got) != 2 || got[0] != "Adler" || got[1] != "Adler (2)" {
+		t.Errorf("Teams %q, erwartet Adler und Adler (2)", got)
+	}
+	if !hasSport(t, "Curling") {
+		t.Error("Sportart Curling fehlt")
+	}
+}
+
+// Scheitert ein späterer Schritt, bleibt auch von den früheren nichts übrig
+func TestImportFailRollsBack(t *testing.T) {
+	openTestDB(t)
+	if _, err := Import(testDump(), models.ConflictFail); err == nil {
+		t.Fatal("Import ohne Fehler, erwartet Abbruch wegen Adler")
+	}
+	if hasSport(t, "Curling") {
+		t.Error("Sportart Curling trotz Abbruch gespeichert")
+	}
+	if got := teamNames(t); len(got) != 1 {
+		t.Errorf("Teams %q, erwartet nur Adler", got)
+	}
+}
+
+func TestImportUnknownPolicy(t *testing.T) {
+	openTestDB(t)
+	if _, err := Import(testDump(), "overwrite"); err == nil {
+		t.Error("unbekannte Konfliktauflösung akzeptiert")
+	}
+}
+
+// Sportarten werden nie kopiert, laufende Spiele kommen als geplant an
+func TestImportRenameKeepsSports(t *testing.T) {
+	openTestDB(t)
+	d := testDump()
+	d.Sports = append(d.Sports, &models.SportartDefinition{ID: 2, Sportart: "Fußball", PeriodLabel: "Halbzeit", PeriodsCount: 2})
+	d.Matches = []*Match{{ID: 1, ICalUID: "spiel-1@liga", Sportart: "Fußball", Status: models.MatchLive,
+		GameTime: time.Date(2026, 5, 2, 15, 0, 0, 0, time.UTC), HomeTeamID: 1}}
+
+	report, err := Import(d, models.ConflictRename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := report.Count(kindSport, ActionRenamed); n != 0 {
+		t.Errorf("%d Sportarten umbenannt, erwartet keine", n)
+	}
+	if hasSport(t, "Fußball (2)") {
+		t.Error("Sportart Fußball doppelt angelegt")
+	}
+	matches, err := database.LoadMatches()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("%d Spiele, erwartet 1", len(matches))
+	}
+	if m := matches[0]; m.Status != models.MatchScheduled || m.Sportart != "Fußball" {
+		t.Errorf("Spiel %s mit Status %q, erwartet Fußball und %q", m.Sportart, m.Status, models.MatchScheduled)
+	}
+}
@@ -0,0 +1,87 @@
+// internal/fixtures/datetime.go
+
+package fixtures
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+)
+
+// Datumsformate in Spielplänen; Schrägstriche werden wie in Europa als
+// Tag/Monat gelesen
+var dateLayouts = []string{"02.01.2006", "2.1.2006", "02.01.06", "2.1.06", "2006-01-02", "02/01/2006", "2/1/2006"}
+
+var timeLayouts = []string{"15:04", "15:04:05", "15.04", "3:04 PM"}
+
+// excelEpoch ist Tag 0 der Excel-Seriennummern (wegen des Schaltjahrfehlers
+// von 1900 der 30.12.1899)
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// parseDateTime setzt den Anstoß aus Datums- und Zeitzelle zusammen. Beide
+// dürfen Excel-Seriennummern sein; steht die Uhrzeit in der Datumszelle,
+// darf die Zeitzelle fehlen. hasTime meldet, ob eine Uhrzeit angegeben war.
+func parseDateTime(date, clock string, loc *time.Location) (t time.Time, hasTime bool, err error) {
+	if date == "" {
+		return time.Time{}, false, i18n.Errorf("error.fixtures.date_missing")
+	}
+	if t, err := time.Parse(time.RFC3339, date); err == nil && clock == "" {
+		return t, true, nil // Zeitpunkt mit Zeitzone, z.B. aus einem Kalender
+	}
+	day, dayTime, hasTime, err := parseDate(date)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if clock != "" {
+		if dayTime, err = parseClock(clock); err != nil {
+			return time.Time{}, false, err
+		}
+		hasTime = true
+	}
+	y, m, d := day.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc).Add(dayTime), hasTime, nil
+}
+
+func parseDate(s string) (day time.Time, dayTime time.Duration, hasTime bool, err error) {
+	if serial, err := strconv.ParseFloat(s, 64); err == nil && serial > 0 {
+		days, frac := math.Modf(serial)
+		return excelEpoch.AddDate(0, 0, int(days)), fraction(frac), frac > 0, nil
+	}
+	datePart, timePart, _ := strings.Cut(strings.TrimSpace(s), " ")
+	for _, layout := range dateLayouts {
+		if day, err = time.Parse(layout, datePart); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return time.Time{}, 0, false, i18n.Errorf("error.fixtures.date", s)
+	}
+	if timePart = strings.TrimSpace(timePart); timePart != "" {
+		if dayTime, err = parseClock(timePart); err != nil {
+			return time.Time{}, 0, false, err
+		}
+		hasTime = true
+	}
+	return day, dayTime, hasTime, nil
+}
+
+func parseClock(s string) (time.Duration, error) {
+	if v, err := strconv.ParseFloat(s, 64); err == nil && v >= 0 && v < 1 {
+		return fraction(v), nil
+	}
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "Uhr"))
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+		}
+	}
+	return 0, i18n.Errorf("error.fixtures.time", s)
+}
+
+// fraction rechnet einen Tagesanteil in eine auf Minuten gerundete Dauer um
+func fraction(f float64) time.Duration {
+	return time.Duration(math.Round(f*24*60)) * time.Minute
+}
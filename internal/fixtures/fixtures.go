@@ -0,0 +1,558 @@
+// internal/fixtures/fixtures.go
+//
+// Import von Spielplänen aus Tabellen (CSV, XLSX) und Kalendern (iCalendar),
+// wie sie Ligen verschicken.
+// Build liest die Zeilen und erstellt einen Plan: welche Teams zugeordnet
+// oder neu angelegt und welche Spiele angelegt werden. Apply schreibt ihn
+// in einer Transaktion; ohne Apply ist das ein Probelauf.
+
+package fixtures
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// Spalten, die der Import kennt
+const (
+	ColHome        = "home"
+	ColAway        = "away"
+	ColDate        = "date"
+	ColTime        = "time"
+	ColSport       = "sport"
+	ColVenue       = "venue"
+	ColCompetition = "competition"
+	ColUID         = "uid"    // Kennung aus dem Kalender, erkennt verlegte Spiele
+	ColStatus      = "status" // "abgesagt" bzw. CANCELLED sagt das Spiel mit gleicher UID ab
+)
+
+// Columns liefert die Spalten in der Reihenfolge der Hilfe
+func Columns() []string {
+	return []string{ColHome, ColAway, ColDate, ColTime, ColSport, ColVenue, ColCompetition, ColUID, ColStatus}
+}
+
+// headerAliases sind die Überschriften, die ohne Zuordnung erkannt werden
+var headerAliases = map[string][]string{
+	ColHome:        {"heim", "heimmannschaft", "heimteam", "gastgeber", "home", "home team"},
+	ColAway:        {"gast", "gastmannschaft", "gastteam", "away", "away team", "guest"},
+	ColDate:        {"datum", "date", "tag", "spieldatum"},
+	ColTime:        {"uhrzeit", "zeit", "anstoss", "anpfiff", "kickoff", "beginn", "time"},
+	ColSport:       {"sportart", "sport"},
+	ColVenue:       {"spielort", "ort", "platz", "feld", "venue", "field", "location"},
+	ColCompetition: {"wettbewerb", "liga", "staffel", "competition", "league"},
+	ColUID:         {"uid", "ical uid", "spielkennung"},
+	ColStatus:      {"status", "spielstatus"},
+}
+
+// cancelledStatus sind die Werte der Statusspalte für abgesagte Spiele
+var cancelledStatus = []string{"abgesagt", "ausgefallen", "cancelled", "canceled", models.MatchAbandoned}
+
+// Options steuern den Import
+type Options struct {
+	Mapping     map[string]string // Spalte → Überschrift in der Datei, sonst headerAliases
+	Sport       string            // Sportart, wenn die Datei keine Spalte dafür hat
+	Competition string            // Wettbewerb, wenn die Datei keine Spalte dafür hat
+	CreateTeams bool              // unbekannte Teams anlegen statt als Fehler melden
+	Threshold   float64           // Mindestähnlichkeit für unscharfe Zuordnung (0..1)
+	Location    *time.Location    // Zeitzone der Anstoßzeiten, Standard: lokal
+}
+
+// DefaultThreshold ist die Mindestähnlichkeit, wenn Options keine vorgibt
+const DefaultThreshold = 0.8
+
+// ParseMapping liest Zuordnungen im Format "home=Heim,away=Gast"
+func ParseMapping(spec string) (map[string]string, error) {
+	m := map[string]string{}
+	for _, kv := range strings.Split(spec, ",") {
+		if strings.TrimSpace(kv) == "" {
+			continue
+		}
+		col, header, ok := strings.Cut(kv, "=")
+		col = strings.ToLower(strings.TrimSpace(col))
+		if !ok || !slices.Contains(Columns(), col) {
+			return nil, i18n.Errorf("error.fixtures.mapping",
+				kv, strings.Join(Columns(), ", "))
+		}
+		m[col] = strings.TrimSpace(header)
+	}
+	return m, nil
+}
+
+// TeamMatch ordnet einen Teamnamen aus der Datei einem Team zu
+type TeamMatch struct {
+	Input   string
+	Sport   string
+	Team    *models.Team // vorhandenes Team oder, bei Created, das neue
+	Score   float64      // Ähnlichkeit, 1 = exakt
+	Created bool
+	Similar string // bei neuen Teams: ähnlichstes vorhandenes Team, möglicher Tippfehler
+}
+
+// Fixture ist ein Spiel aus einer Zeile der Datei
+type Fixture struct {
+	Line      int // Zeilennummer in der Datei
+	Match     *models.Match
+	Existing  bool          // schon vorhanden, wird übersprungen
+	Previous  *models.Match // Spiel mit gleicher UID vor der Änderung; Apply verschiebt es
+	Cancelled bool          // laut Datei abgesagt: Apply sagt Previous ab, ohne Previous wird nichts angelegt
+	Warnings  []string
+}
+
+// RowError ist ein Fehler in einer Zeile; die Zeile wird nicht importiert
+type RowError struct {
+	Line    int
+	Message string
+}
+
+func (e RowError) Error() string {
+	return i18n.T("fixtures.row_error", e.Line, e.Message)
+}
+
+// Plan ist das Ergebnis von Build
+type Plan struct {
+	Teams    []*TeamMatch // je Name und Sportart einmal, in Reihenfolge des Auftretens
+	Fixtures []*Fixture
+	Errors   []RowError
+}
+
+// NewTeams zählt die Teams, die Apply anlegt
+func (p *Plan) NewTeams() int {
+	n := 0
+	for _, t := range p.Teams {
+		if t.Created {
+			n++
+		}
+	}
+	return n
+}
+
+// NewMatches zählt die Spiele, die Apply anlegt
+func (p *Plan) NewMatches() int {
+	n := 0
+	for _, f := range p.Fixtures {
+		if !f.Existing && f.Previous == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// MovedMatches zählt die vorhandenen Spiele, die Apply ändert
+func (p *Plan) MovedMatches() int {
+	n := 0
+	for _, f := range p.Fixtures {
+		if !f.Existing && f.Previous != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// builder hält die geladenen Stammdaten während Build
+type builder struct {
+	opts     Options
+	teams    []*models.Team
+	sports   []*models.SportartDefinition
+	fields   []*models.Field
+	existing []*models.Match
+	resolved map[string]*TeamMatch // Sportart + normalisierter Name
+	plan     *Plan
+}
+
+// Build liest die Zeilen (erste Zeile = Überschriften) und erstellt den Plan
+func Build(rows [][]string, opts Options) (*Plan, error) {
+	if opts.Threshold <= 0 {
+		opts.Threshold = DefaultThreshold
+	}
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+	if len(rows) == 0 {
+		return nil, i18n.Errorf("error.fixtures.empty")
+	}
+	cols, err := columnIndex(rows[0], opts.Mapping)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := cols[ColSport]; !ok && opts.Sport == "" {
+		return nil, i18n.Errorf("error.fixtures.no_sport_column")
+	}
+
+	b := &builder{opts: opts, resolved: map[string]*TeamMatch{}, plan: &Plan{}}
+	if b.teams, err = database.LoadTeams(); err != nil {
+		return nil, err
+	}
+	if b.sports, err = database.LoadSports(); err != nil {
+		return nil, err
+	}
+	if b.fields, err = database.LoadFields(0); err != nil {
+		return nil, err
+	}
+	if b.existing, err = database.LoadMatches(); err != nil {
+		return nil, err
+	}
+
+	for i, row := range rows[1:] {
+		line := i + 2
+		cell := func(col string) string {
+			if idx, ok := cols[col]; ok && idx < len(row) {
+				return strings.TrimSpace(row[idx])
+			}
+			return ""
+		}
+		if strings.TrimSpace(strings.Join(row, "")) == "" {
+			continue
+		}
+		f, err := b.fixture(line, cell)
+		if err != nil {
+			b.plan.Errors = append(b.plan.Errors, RowError{Line: line, Message: err.Error()})
+			continue
+		}
+		b.plan.Fixtures = append(b.plan.Fixtures, f)
+	}
+	return b.plan, nil
+}
+
+// columnIndex ordnet den Spalten ihre Position in der Kopfzeile zu
+func columnIndex(header []string, mapping map[string]string) (map[string]int, error) {
+	cols := map[string]int{}
+	for _, col := range Columns() {
+		names := headerAliases[col]
+		if h, ok := mapping[col]; ok {
+			names = []string{h}
+		}
+		for i, h := range header {
+			if slices.ContainsFunc(names, func(n string) bool { return normalize(n) == normalize(h) }) {
+				cols[col] = i
+				break
+			}
+		}
+		if _, ok := cols[col]; !ok && mapping[col] != "" {
+			return nil, i18n.Errorf("error.fixtures.column_missing", mapping[col], strings.Join(header, ", "))
+		}
+	}
+	for _, col := range []string{ColHome, ColAway, ColDate} {
+		if _, ok := cols[col]; !ok {
+			return nil, i18n.Errorf("error.fixtures.column_unknown",
+				col, strings.Join(header, ", "))
+		}
+	}
+	return cols, nil
+}
+
+func (b *builder) fixture(line int, cell func(string) string) (*Fixture, error) {
+	if slices.Contains(cancelledStatus, normalize(cell(ColStatus))) {
+		return b.cancel(line, cell)
+	}
+	f := &Fixture{Line: line}
+
+	sport, err := b.sport(cmp.Or(cell(ColSport), b.opts.Sport))
+	if err != nil {
+		return nil, err
+	}
+	start, hasTime, err := parseDateTime(cell(ColDate), cell(ColTime), b.opts.Location)
+	if err != nil {
+		return nil, err
+	}
+	if !hasTime {
+		f.Warnings = append(f.Warnings, i18n.T("fixtures.no_time"))
+	}
+	if cell(ColHome) == "" || cell(ColAway) == "" {
+		return nil, i18n.Errorf("error.fixtures.team_missing")
+	}
+	home, err := b.team(cell(ColHome), sport)
+	if err != nil {
+		return nil, err
+	}
+	away, err := b.team(cell(ColAway), sport)
+	if err != nil {
+		return nil, err
+	}
+	if home.Team == away.Team {
+		return nil, i18n.Errorf("error.fixtures.same_team", home.Team.Name)
+	}
+
+	m := &models.Match{
+		Sportart:         sport,
+		Competition:      cmp.Or(cell(ColCompetition), b.opts.Competition),
+		Team1:            home.Team,
+		Team2:            away.Team,
+		GameTime:         start,
+		TemplateSettings: &models.TemplateSettings{},
+	}
+	if venue := cell(ColVenue); venue != "" {
+		m.Field = venue
+		if field := b.field(venue); field != nil {
+			m.FieldID, m.Field = field.ID, field.Name
+		} else {
+			f.Warnings = append(f.Warnings, i18n.T("fixtures.unknown_field", venue))
+		}
+	}
+	f.Match = m
+	if uid := cell(ColUID); uid != "" {
+		m.ICalUID = uid
+		if prev := b.byUID(uid); prev != nil {
+			return b.move(f, prev)
+		}
+	}
+	f.Existing = b.exists(m)
+	if !f.Existing {
+		b.existing = append(b.existing, m) // doppelte Zeilen nur einmal anlegen
+	}
+	return f, nil
+}
+
+// move übernimmt Anstoß, Teams, Feld und Wettbewerb aus der Datei in das
+// vorhandene Spiel mit gleicher UID; Ergebnis, Status und Turnierdaten bleiben
+func (b *builder) move(f *Fixture, prev *models.Match) (*Fixture, error) {
+	m := *prev
+	m.ICalUID = f.Match.ICalUID
+	m.GameTime = f.Match.GameTime
+	m.Team1, m.Team2 = f.Match.Team1, f.Match.Team2
+	m.Sportart = f.Match.Sportart
+	m.Competition = cmp.Or(f.Match.Competition, prev.Competition)
+	if f.Match.Field != "" {
+		m.Field, m.FieldID = f.Match.Field, f.Match.FieldID
+	}
+	f.Match = &m
+
+	unchanged := m.GameTime.Equal(prev.GameTime) && m.Team1.ID == prev.Team1.ID && m.Team2.ID == prev.Team2.ID &&
+		m.Field == prev.Field && m.FieldID == prev.FieldID && m.Competition == prev.Competition &&
+		m.Sportart == prev.Sportart && m.ICalUID == prev.ICalUID
+	switch {
+	case unchanged:
+		f.Existing = true
+	case prev.Status == models.MatchFinished:
+		f.Match, f.Existing = prev, true
+		f.Warnings = append(f.Warnings, i18n.T("fixtures.finished"))
+	default:
+		f.Previous = prev
+	}
+	return f, nil
+}
+
+// cancel sagt das Spiel mit der UID aus der Datei ab. Abgesagte Termine ohne
+// bekanntes Spiel werden nicht angelegt und brauchen daher auch keine Teams.
+func (b *builder) cancel(line int, cell func(string) string) (*Fixture, error) {
+	f := &Fixture{Line: line, Cancelled: true}
+	var prev *models.Match
+	if uid := cell(ColUID); uid != "" {
+		prev = b.byUID(uid)
+	}
+	if prev == nil {
+		start, _, err := parseDateTime(cell(ColDate), cell(ColTime), b.opts.Location)
+		if err != nil {
+			return nil, err
+		}
+		f.Match = &models.Match{
+			Team1:       &models.Team{Name: cell(ColHome)},
+			Team2:       &models.Team{Name: cell(ColAway)},
+			GameTime:    start,
+			Competition: cmp.Or(cell(ColCompetition), b.opts.Competition),
+			Field:       cell(ColVenue),
+			Status:      models.MatchAbandoned,
+		}
+		f.Existing = true
+		return f, nil
+	}
+
+	m := *prev
+	m.Status = models.MatchAbandoned
+	f.Match = &m
+	switch prev.Status {
+	case models.MatchAbandoned:
+		f.Existing = true
+	case models.MatchFinished:
+		f.Match, f.Existing = prev, true
+		f.Warnings = append(f.Warnings, i18n.T("fixtures.finished"))
+	default:
+		f.Previous = prev
+	}
+	return f, nil
+}
+
+// byUID sucht das gespeicherte Spiel mit der UID aus dem Kalender
+func (b *builder) byUID(uid string) *models.Match {
+	for _, old := range b.existing {
+		if old.ID != 0 && old.ICalUID == uid {
+			return old
+		}
+	}
+	return nil
+}
+
+// sport prüft die Sportart gegen die Tabelle sports
+func (b *builder) sport(name string) (string, error) {
+	if name == "" {
+		return "", i18n.Errorf("error.fixtures.sport_missing")
+	}
+	var known []string
+	for _, s := range b.sports {
+		if normalize(s.Sportart) == normalize(name) {
+			return s.Sportart, nil
+		}
+		known = append(known, s.Sportart)
+	}
+	return "", i18n.Errorf("error.fixtures.sport_unknown", name, strings.Join(known, ", "))
+}
+
+// team ordnet einen Namen zu: exakt, unscharf oder als neues Team
+func (b *builder) team(input, sport string) (*TeamMatch, error) {
+	key := sport + "\x00" + normalize(input)
+	if tm, ok := b.resolved[key]; ok {
+		return tm, nil
+	}
+
+	var best *models.Team
+	bestScore, ambiguous := 0.0, false
+	for _, t := range b.teams {
+		if !strings.EqualFold(t.Sportart, sport) {
+			continue
+		}
+		score := teamScore(t, input)
+		switch {
+		case score > bestScore:
+			best, bestScore, ambiguous = t, score, false
+		case score == bestScore && score > 0:
+			ambiguous = true
+		}
+	}
+
+	tm := &TeamMatch{Input: input, Sport: sport}
+	switch {
+	case best != nil && bestScore >= b.opts.Threshold && ambiguous:
+		return nil, i18n.Errorf("error.fixtures.team_ambiguous", input, bestScore*100)
+	case best != nil && bestScore >= b.opts.Threshold:
+		tm.Team, tm.Score = best, bestScore
+	case b.opts.CreateTeams:
+		tm.Team = &models.Team{Name: input, Sportart: sport}
+		tm.Created = true
+		if best != nil && bestScore >= b.opts.Threshold/2 {
+			tm.Similar, tm.Score = best.Name, bestScore
+		}
+	default:
+		if best != nil {
+			return nil, i18n.Errorf("error.fixtures.team_similar", input, best.Name, bestScore*100)
+		}
+		return nil, i18n.Errorf("error.fixtures.team_not_found", input)
+	}
+	b.resolved[key] = tm
+	b.plan.Teams = append(b.plan.Teams, tm)
+	return tm, nil
+}
+
+// teamScore vergleicht input mit Name, Kurzname, Namen je Wettbewerb und Kürzel
+func teamScore(t *models.Team, input string) float64 {
+	in := normalize(input)
+	if t.Abbreviation != "" && normalize(t.Abbreviation) == in {
+		return 1
+	}
+	best := 0.0
+	for _, n := range append([]string{t.Name, t.ShortName}, slices.Collect(maps.Values(t.AltNames))...) {
+		if n != "" {
+			best = max(best, similarity(normalize(n), in), wordScore(normalize(n), in))
+		}
+	}
+	return best
+}
+
+// wordScore erkennt Namen, die aus ganzen Wörtern des Teamnamens bestehen
+// ("Dukes" für "Ingolstadt Dukes"); kurze Wörter wie "FC" zählen nicht
+func wordScore(name, in string) float64 {
+	if len(in) < 4 {
+		return 0
+	}
+	words := strings.Fields(name)
+	for _, w := range strings.Fields(in) {
+		if !slices.Contains(words, w) {
+			return 0
+		}
+	}
+	return 0.9
+}
+
+// field sucht ein Feld über "Spielort Feld", den Feldnamen oder einen
+// Spielort mit nur einem Feld
+func (b *builder) field(venue string) *models.Field {
+	v := normalize(venue)
+	perVenue := map[string][]*models.Field{}
+	for _, f := range b.fields {
+		if normalize(f.VenueName+" "+f.Name) == v || normalize(f.Name) == v {
+			return f
+		}
+		perVenue[normalize(f.VenueName)] = append(perVenue[normalize(f.VenueName)], f)
+	}
+	if fs := perVenue[v]; len(fs) == 1 {
+		return fs[0]
+	}
+	return nil
+}
+
+// exists prüft wie matches import: gleicher Wettbewerb, gleiche Teams und Anstoß
+func (b *builder) exists(m *models.Match) bool {
+	if m.Team1.ID == 0 || m.Team2.ID == 0 {
+		return false
+	}
+	for _, old := range b.existing {
+		if old.Competition == m.Competition && old.Team1.ID == m.Team1.ID && old.Team2.ID == m.Team2.ID &&
+			old.GameTime.Equal(m.GameTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply legt die neuen Teams und Spiele des Plans an, verschiebt Spiele
+// mit bekannter UID und sagt abgesagte ab. Zeilen mit Fehlern sind nicht im Plan und werden nicht
+// geschrieben. Alles geschieht in einer Transaktion: scheitert eine Zeile,
+// bleibt die Datenbank unverändert.
+func Apply(p *Plan) error {
+	err := database.InTx(p.apply)
+	if err != nil {
+		// IDs aus der zurückgerollten Transaktion verwerfen, damit ein
+		// erneutes Apply Teams und Spiele wieder anlegt
+		for _, tm := range p.Teams {
+			if tm.Created {
+				tm.Team.ID = 0
+			}
+		}
+		for _, f := range p.Fixtures {
+			if !f.Existing && f.Previous == nil {
+				f.Match.ID = 0
+			}
+		}
+	}
+	return err
+}
+
+func (p *Plan) apply(tx *database.Tx) error {
+	for _, tm := range p.Teams {
+		if tm.Created && tm.Team.ID == 0 {
+			if err := tx.SaveTeam(tm.Team); err != nil {
+				return i18n.Errorf("error.wrap.team", tm.Team.Name, err)
+			}
+		}
+	}
+	for _, f := range p.Fixtures {
+		if f.Existing {
+			continue
+		}
+		if f.Cancelled {
+			if err := tx.SetMatchStatus(f.Match.ID, models.MatchAbandoned); err != nil {
+				return RowError{Line: f.Line, Message: err.Error()}
+			}
+			continue
+		}
+		if err := tx.SaveMatches(f.Match); err != nil {
+			return RowError{Line: f.Line, Message: err.Error()}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+// internal/fixtures/fixtures_test.go
+
+package fixtures
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+func TestColumnIndex(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  []string
+		mapping map[string]string
+		want    map[string]int
+		wantErr bool
+	}{
+		{
+			name:   "Überschriften erkannt",
+			header: []string{"Datum", "Anstoß", "Heimmannschaft", "Gastmannschaft", "Spielort"},
+			want:   map[string]int{ColDate: 0, ColTime: 1, ColHome: 2, ColAway: 3, ColVenue: 4},
+		},
+		{
+			name:    "Zuordnung vor Überschrift",
+			header:  []string{"Team A", "Team B", "Heim", "Tag"},
+			mapping: map[string]string{ColHome: "Team A", ColAway: "team b"},
+			want:    map[string]int{ColHome: 0, ColAway: 1, ColDate: 3},
+		},
+		{
+			name:    "zugeordnete Spalte fehlt",
+			header:  []string{"Heim", "Gast", "Datum"},
+			mapping: map[string]string{ColVenue: "Halle"},
+			wantErr: true,
+		},
+		{
+			name:    "Pflichtspalte fehlt",
+			header:  []string{"Heim", "Gast", "Uhrzeit"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := columnIndex(tt.header, tt.mapping)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("kein Fehler, Spalten %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("Spalten %v, erwartet %v", got, tt.want)
+			}
+			for col, i := range tt.want {
+				if got[col] != i {
+					t.Errorf("Spalte %s an %d, erwartet %d", col, got[col], i)
+				}
+			}
+		})
+	}
+}
+
+func TestTeamScore(t *testing.T) {
+	koeln := &models.Team{Name: "1. FC Köln", Abbreviation: "KOE"}
+	dukes := &models.Team{Name: "Ingolstadt Dukes", AltNames: map[string]string{"Pokal": "ERC Dukes"}}
+	tests := []struct {
+		team  *models.Team
+		input string
+		min   float64
+		max   float64
+	}{
+		{koeln, "1 FC Koeln", 1, 1},
+		{koeln, "koe", 1, 1},
+		{koeln, "1. FC Kln", DefaultThreshold, 0.99},
+		{koeln, "Bayern", 0, 0.5},
+		{dukes, "Dukes", 0.9, 0.9},
+		{dukes, "ERC Dukes", 1, 1},
+		{dukes, "Ingolstadt", 0.9, 0.9},
+		{dukes, "Ing", 0, 0.5},
+	}
+	for _, tt := range tests {
+		if got := teamScore(tt.team, tt.input); got < tt.min || got > tt.max {
+			t.Errorf("%q gegen %q: %.2f, erwartet %.2f..%.2f", tt.input, tt.team.Name, got, tt.min, tt.max)
+		}
+	}
+}
+
+// openTestDB öffnet eine leere Datenbank mit dem Team "Ingolstadt Dukes"
+func openTestDB(t *testing.T) {
+	t.Helper()
+	if err := database.Open(filepath.Join(t.TempDir(), "test.db")); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(database.Close)
+	if err := database.SaveTeam(&models.Team{Name: "Ingolstadt Dukes", Sportart: "Fußball"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testPlan(t *testing.T) *Plan {
+	t.Helper()
+	p, err := Build([][]string{
+		{"Heim", "Gast", "Datum", "Uhrzeit"},
+		{"Dukes", "Munich Cowboys", "02.05.2026", "15:00"},
+		{"Munich Cowboys", "Ingolstadt Dukez", "09.05.2026", "15:00"},
+	}, Options{Sport: "Fußball", Competition: "Liga", CreateTeams: true, Location: time.UTC})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Errors) != 0 {
+		t.Fatalf("Fehler im Plan: %v", p.Errors)
+	}
+	return p
+}
+
+func TestBuildMatchesTeams(t *testing.T) {
+	openTestDB(t)
+	p := testPlan(t)
+
+	if n := p.NewTeams(); n != 1 {
+		t.Errorf("%d neue Teams, erwartet 1", n)
+	}
+	for _, f := range p.Fixtures {
+		for _, team := range []*models.Team{f.Match.Team1, f.Match.Team2} {
+			if team.Name != "Ingolstadt Dukes" && team.Name != "Munich Cowboys" {
+				t.Errorf("Zeile %d: Team %q", f.Line, team.Name)
+			}
+		}
+	}
+}
+
+func TestApplyRollback(t *testing.T) {
+	openTestDB(t)
+	p := testPlan(t)
+	// das letzte Spiel verweist auf ein unbekanntes Feld ohne Template
+	p.Fixtures[len(p.Fixtures)-1].Match.FieldID = 999
+
+	if err := Apply(p); err == nil {
+		t.Fatal("Apply trotz unbekanntem Feld erfolgreich")
+	}
+	teams, err := database.LoadTeams()
+	if err != nil {
+		t.Fatal(err)
+	}
+	matches, err := database.LoadMatches()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(teams) != 1 || len(matches) != 0 {
+		t.Errorf("%d Teams und %d Spiele gespeichert, erwartet nur das vorhandene Team", len(teams), len(matches))
+	}
+
+	// ohne den Fehler legt ein erneutes Apply alles an
+	p.Fixtures[len(p.Fixtures)-1].Match.FieldID = 0
+	if err := Apply(p); err != nil {
+		t.Fatal(err)
+	}
+	if matches, _ = database.LoadMatches(); len(matches) != 2 {
+		t.Errorf("%d Spiele gespeichert, erwartet 2", len(matches))
+	}
+}
+
+func icsEvent(status string) []byte {
+	return []byte("BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VEVENT\r\nUID:spiel-1@liga\r\n" +
+		"DTSTART:20260502T130000Z\r\nSUMMARY:Ingolstadt Dukes – Munich Cowboys\r\n" +
+		"STATUS:" + status + "\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n")
+}
+
+// Eine Absage im Kalender sagt das Spiel mit gleicher UID ab
+func TestCancelledEvent(t *testing.T) {
+	openTestDB(t)
+	opts := Options{Sport: "Fußball", CreateTeams: true}
+	importICS := func(status string) *Plan {
+		t.Helper()
+		rows, err := readICS(icsEvent(status))
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, err := Build(rows, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := Apply(p); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	// abgesagt und unbekannt: nichts anlegen, auch kein Team
+	if p := importICS("CANCELLED"); p.NewTeams() != 0 || p.NewMatches() != 0 {
+		t.Errorf("%d Teams und %d Spiele angelegt, erwartet keine", p.NewTeams(), p.NewMatches())
+	}
+
+	importICS("CONFIRMED")
+	p := importICS("CANCELLED")
+	if n := p.MovedMatches(); n != 1 {
+		t.Errorf("%d Spiele geändert, erwartet 1", n)
+	}
+	matches, err := database.LoadMatches()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("%d Spiele, erwartet 1", len(matches))
+	}
+	if matches[0].Status != models.MatchAbandoned {
+		t.Errorf("Status %q, erwartet %q", matches[0].Status, models.MatchAbandoned)
+	}
+}
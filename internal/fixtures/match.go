@@ -0,0 +1,59 @@
+// internal/fixtures/match.go
+
+package fixtures
+
+import (
+	"strings"
+	"unicode"
+)
+
+// foldings vereinheitlicht Umlaute und Akzente, damit "München" und
+// "Muenchen" gleich verglichen werden
+var foldings = strings.NewReplacer(
+	"ä", "ae", "ö", "oe", "ü", "ue", "ß", "ss",
+	"á", "a", "à", "a", "â", "a", "é", "e", "è", "e", "ê", "e",
+	"í", "i", "ó", "o", "ô", "o", "ú", "u", "ç", "c", "ñ", "n",
+)
+
+// normalize macht Namen vergleichbar: klein, ohne Umlaute, Satzzeichen und
+// doppelte Leerzeichen ("1. FC Köln" → "1 fc koeln")
+func normalize(s string) string {
+	s = foldings.Replace(strings.ToLower(s))
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// similarity liefert die Ähnlichkeit zweier normalisierter Namen als Anteil
+// übereinstimmender Zeichen (1 = gleich) nach der Levenshtein-Distanz
+func similarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	n := max(len(ra), len(rb))
+	if n == 0 {
+		return 0
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(n)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,112 @@
+// internal/fixtures/read.go
+
+package fixtures
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/ical"
+	"github.com/xuri/excelize/v2"
+)
+
+// ReadFile liest eine CSV-, XLSX- oder iCalendar-Datei als Zeilen von Zellen.
+// Bei XLSX gilt das Blatt sheet bzw. das erste Blatt; Zellen kommen als
+// Rohwert, Datum und Uhrzeit also als Excel-Seriennummer (siehe
+// parseDateTime). Bei .ics ist jeder Termin eine Zeile.
+func ReadFile(file, sheet string) ([][]string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".xlsx", ".xlsm":
+		return readXLSX(data, sheet)
+	case ".csv", ".txt":
+		return readCSV(data)
+	case ".ics", ".ical":
+		return readICS(data)
+	}
+	return nil, i18n.Errorf("error.fixtures.format", file)
+}
+
+// readICS macht aus jedem Termin eine Zeile; der Titel "Heim – Gast" wird
+// in die Teams geteilt. Abgesagte Termine behalten ihren Status, damit Build
+// das Spiel mit gleicher UID absagen kann.
+func readICS(data []byte) ([][]string, error) {
+	cal, err := ical.Parse(bytes.NewReader(data), time.Local)
+	if err != nil {
+		return nil, i18n.Errorf("error.fixtures.ical", err)
+	}
+	rows := [][]string{{ColUID, ColHome, ColAway, ColDate, ColSport, ColVenue, ColCompetition, ColStatus}}
+	for _, e := range cal.Events {
+		home, away, ok := e.Teams()
+		if !ok {
+			home = e.Summary // meldet Build als fehlendes Team
+		}
+		date := ""
+		switch {
+		case e.AllDay:
+			date = e.Start.Format("2006-01-02")
+		case !e.Start.IsZero():
+			date = e.Start.Format(time.RFC3339)
+		}
+		competition := ""
+		if len(e.Categories) > 0 {
+			competition = e.Categories[0]
+		}
+		rows = append(rows, []string{e.UID, home, away, date, e.Sport, e.Location, competition, e.Status})
+	}
+	return rows, nil
+}
+
+// readCSV erkennt das Trennzeichen an der Kopfzeile: deutsche Excel-Exporte
+// verwenden ";", andere "," oder Tabulatoren
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF"))
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	sep := ','
+	for _, c := range []rune{';', '\t'} {
+		if bytes.Count(header, []byte(string(c))) > bytes.Count(header, []byte(string(sep))) {
+			sep = c
+		}
+	}
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = sep
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	r.TrimLeadingSpace = true
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, i18n.Errorf("error.fixtures.csv", err)
+	}
+	return rows, nil
+}
+
+func readXLSX(data []byte, sheet string) ([][]string, error) {
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, i18n.Errorf("error.fixtures.xlsx", err)
+	}
+	defer f.Close()
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, i18n.Errorf("error.fixtures.no_sheet")
+	}
+	if sheet == "" {
+		sheet = sheets[0]
+	}
+	rows, err := f.GetRows(sheet, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, i18n.Errorf("error.fixtures.sheet", sheet, err, strings.Join(sheets, ", "))
+	}
+	if len(rows) == 0 {
+		return nil, i18n.Errorf("error.fixtures.sheet_empty", sheet, strings.Join(sheets, ", "))
+	}
+	return rows, nil
+}
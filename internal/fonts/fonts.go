@@ -0,0 +1,338 @@
+// internal/fonts/fonts.go
+//
+// Schriftregister für Renderer und Overlay: beide zeichnen mit denselben
+// Dateien, damit ein Scoreboard auf jedem PC gleich aussieht, egal welche
+// Schriften dort installiert sind. Quellen in aufsteigender Priorität:
+// eingebettete Schriften (Go-Schriften und die Segmentschriften aus ttf/),
+// ein Schriftenverzeichnis und die Tabelle fonts der Datenbank.
+
+package fonts
+
+//go:generate go run gen_segment.go
+
+import (
+	"embed"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/gomonobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+//go:embed ttf/*.ttf
+var embedded embed.FS
+
+// Herkunft einer Schrift
+const (
+	SourceBuiltin   = "eingebaut"
+	SourceDirectory = "verzeichnis"
+	SourceDatabase  = "datenbank"
+)
+
+// Font ist eine geladene Schriftdatei
+type Font struct {
+	Family string // Familie, unter der die Schrift im Template angegeben wird
+	File   string // Dateiname, unter dem das Overlay sie ausliefert
+	Source string
+	Data   []byte
+
+	otf *opentype.Font
+}
+
+// OpenType liefert die geparste Schrift für den Renderer
+func (f *Font) OpenType() *opentype.Font {
+	return f.otf
+}
+
+// TableFamily liefert die Familie laut Namenstabelle, unter der z.B. Windows
+// die Schrift nach dem Registrieren kennt
+func (f *Font) TableFamily() string {
+	var buf sfnt.Buffer
+	if name, err := f.otf.Name(&buf, sfnt.NameIDFamily); err == nil && name != "" {
+		return name
+	}
+	return f.Family
+}
+
+// Parse liest eine TTF/OTF-Datei. Die Familie ist der Dateiname ohne Endung,
+// z.B. "Vereinsschrift.ttf" → "Vereinsschrift".
+func Parse(file string, data []byte, source string) (*Font, error) {
+	otf, err := opentype.Parse(data)
+	if err != nil {
+		return nil, i18n.Errorf("error.wrap.font", file, err)
+	}
+	return &Font{Family: Family(file), File: file, Source: source, Data: data, otf: otf}, nil
+}
+
+// Family liefert den Familiennamen zu einem Dateinamen
+func Family(file string) string {
+	return strings.TrimSuffix(file, path.Ext(file))
+}
+
+// aliases ersetzt verbreitete Systemschriften, die generischen CSS-Familien
+// und Digitalschriften durch eine eingebettete Schrift. Liegt eine Schrift
+// gleichen Namens im Verzeichnis oder in der Datenbank, gewinnt diese.
+var aliases = map[string]string{
+	"sans-serif":  "Go",
+	"serif":       "Go",
+	"system-ui":   "Go",
+	"segoe ui":    "Go",
+	"arial":       "Go",
+	"helvetica":   "Go",
+	"calibri":     "Go",
+	"tahoma":      "Go",
+	"verdana":     "Go",
+	"monospace":   "Go Mono",
+	"consolas":    "Go Mono",
+	"courier new": "Go Mono",
+	"ds-digital":  "Scoreboard Segment Bold",
+	"digital-7":   "Scoreboard Segment",
+}
+
+var (
+	mu     sync.RWMutex
+	byName = map[string]*Font{} // Kleinschreibung der Familie → Schrift
+	byFile = map[string]*Font{} // Kleinschreibung des Dateinamens → Schrift
+)
+
+func init() {
+	for _, f := range []struct {
+		family, file string
+		data         []byte
+	}{
+		{"Go", "Go-Regular.ttf", goregular.TTF},
+		{"Go Bold", "Go-Bold.ttf", gobold.TTF},
+		{"Go Mono", "Go-Mono.ttf", gomono.TTF},
+		{"Go Mono Bold", "Go-Mono-Bold.ttf", gomonobold.TTF},
+		{"Scoreboard Segment", "ScoreboardSegment.ttf", mustEmbedded("ScoreboardSegment.ttf")},
+		{"Scoreboard Segment Bold", "ScoreboardSegment-Bold.ttf", mustEmbedded("ScoreboardSegment-Bold.ttf")},
+	} {
+		font, err := Parse(f.file, f.data, SourceBuiltin)
+		if err != nil {
+			panic(err)
+		}
+		font.Family = f.family
+		register(font)
+	}
+}
+
+func mustEmbedded(name string) []byte {
+	data, err := embedded.ReadFile("ttf/" + name)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// register nimmt eine Schrift unter ihrer Familie, dem Dateinamen ohne
+// Endung und ihrem Namen laut Namenstabelle auf. Aufruf nur mit mu.
+func register(f *Font) {
+	if old, ok := byFile[strings.ToLower(f.File)]; ok {
+		for name, other := range byName {
+			if other == old {
+				delete(byName, name)
+			}
+		}
+	}
+	byFile[strings.ToLower(f.File)] = f
+	for _, name := range append([]string{f.Family}, tableNames(f.otf)...) {
+		byName[strings.ToLower(name)] = f
+	}
+}
+
+// tableNames liefert den vollen Namen und, bei normalem Schnitt, die Familie
+// aus der Namenstabelle, damit z.B. "DS-DIGI.TTF" als "DS-Digital" gilt
+func tableNames(otf *opentype.Font) []string {
+	var (
+		buf   sfnt.Buffer
+		names []string
+	)
+	if full, err := otf.Name(&buf, sfnt.NameIDFull); err == nil && full != "" {
+		names = append(names, full)
+	}
+	sub, _ := otf.Name(&buf, sfnt.NameIDSubfamily)
+	if sub == "" || strings.EqualFold(sub, "Regular") || strings.EqualFold(sub, "Normal") {
+		if family, err := otf.Name(&buf, sfnt.NameIDFamily); err == nil && family != "" {
+			names = append(names, family)
+		}
+	}
+	return names
+}
+
+// Add nimmt eine Schriftdatei ins Register auf; eine Datei gleichen Namens
+// wird ersetzt
+func Add(file string, data []byte, source string) (*Font, error) {
+	f, err := Parse(file, data, source)
+	if err != nil {
+		return nil, err
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	register(f)
+	return f, nil
+}
+
+// IsFontFile meldet, ob der Dateiname eine unterstützte Schrift bezeichnet
+func IsFontFile(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".ttf", ".otf":
+		return true
+	}
+	return false
+}
+
+// LoadDir lädt alle TTF/OTF-Dateien eines Verzeichnisses. Ein fehlendes
+// Verzeichnis ist kein Fehler, ein leerer Pfad wird ignoriert.
+func LoadDir(dir string) error {
+	if dir == "" {
+		return nil
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !IsFontFile(e.Name()) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return err
+		}
+		if _, err := Add(e.Name(), data, SourceDirectory); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadDatabase lädt die Schriften aus der geöffneten Datenbank
+func LoadDatabase() error {
+	stored, err := database.LoadFonts()
+	if err != nil {
+		return err
+	}
+	for _, f := range stored {
+		if _, err := Add(f.Name, f.Data, SourceDatabase); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate prüft eine Schrift, bevor sie in der Datenbank landet
+func Validate(f *models.Font) error {
+	if !IsFontFile(f.Name) {
+		return i18n.Errorf("error.font_format", f.Name)
+	}
+	if strings.ContainsAny(f.Name, `/\`) {
+		return i18n.Errorf("error.font_path", f.Name)
+	}
+	_, err := Parse(f.Name, f.Data, SourceDatabase)
+	return err
+}
+
+// Lookup sucht eine einzelne Familie, bei Bedarf über die Aliase
+func Lookup(family string) (*Font, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	key := strings.ToLower(strings.TrimSpace(family))
+	if f, ok := byName[key]; ok {
+		return f, true
+	}
+	if alias, ok := aliases[key]; ok {
+		f, ok := byName[strings.ToLower(alias)]
+		return f, ok
+	}
+	return nil, false
+}
+
+// ByFile sucht eine Schrift anhand ihres Dateinamens
+func ByFile(file string) (*Font, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	f, ok := byFile[strings.ToLower(file)]
+	return f, ok
+}
+
+// All liefert alle Schriften sortiert nach Familie
+func All() []*Font {
+	mu.RLock()
+	defer mu.RUnlock()
+	all := make([]*Font, 0, len(byFile))
+	for _, f := range byFile {
+		all = append(all, f)
+	}
+	sort.Slice(all, func(i, j int) bool { return strings.ToLower(all[i].Family) < strings.ToLower(all[j].Family) })
+	return all
+}
+
+// Families liefert die Familien des Registers gefolgt von den bekanntesten
+// Aliasen, wie sie in Auswahllisten angeboten werden
+func Families() []string {
+	var families []string
+	for _, f := range All() {
+		families = append(families, f.Family)
+	}
+	return append(families, "sans-serif", "monospace", "Segoe UI", "Arial", "Consolas", "DS-Digital")
+}
+
+// faceNames liefert alle Namen, unter denen eine Schrift erreichbar ist,
+// inkl. Aliase
+func faceNames() map[string]*Font {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := map[string]*Font{}
+	for _, f := range byFile {
+		names[f.Family] = f
+	}
+	for name, f := range byName {
+		if !strings.EqualFold(name, f.Family) {
+			names[name] = f
+		}
+	}
+	for alias, target := range aliases {
+		if _, ok := byName[alias]; ok {
+			continue // echte Schrift gleichen Namens geladen
+		}
+		if f, ok := byName[strings.ToLower(target)]; ok {
+			names[alias] = f
+		}
+	}
+	return names
+}
+
+// CSS liefert @font-face-Regeln für alle Namen des Registers; prefix ist
+// der URL-Pfad, unter dem die Dateien ausgeliefert werden, z.B. "/fonts/"
+func CSS(prefix string) string {
+	names := faceNames()
+	keys := make([]string, 0, len(names))
+	for name := range names {
+		keys = append(keys, name)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, name := range keys {
+		fmt.Fprintf(&b, "@font-face { font-family: %q; src: url(%q); font-display: block; }\n",
+			name, prefix+url.PathEscape(names[name].File))
+	}
+	return b.String()
+}
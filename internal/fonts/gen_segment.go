@@ -0,0 +1,418 @@
+// internal/fonts/gen_segment.go
+
+//go:build ignore
+
+// gen_segment erzeugt die eingebetteten Segmentschriften (7 Segmente für
+// Ziffern, 14 für Buchstaben) als TrueType-Dateien in ttf/. Die Schriften
+// entstehen vollständig aus diesem Programm und unterliegen damit denselben
+// Bedingungen wie der übrige Quellcode.
+//
+//	go generate ./internal/fonts
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"unicode/utf16"
+)
+
+const (
+	unitsPerEm = 1000
+	ascender   = 900
+	descender  = -200
+	advance    = 600 // alle Zeichen gleich breit, damit die Uhr nicht springt
+	capHeight  = 700
+	left       = 70
+	right      = 530
+	gap        = 14
+)
+
+type point struct{ x, y float64 }
+
+type contour []point
+
+// segment liefert die Kontur eines Segments von a nach b (Mittellinie) mit
+// angeschrägten Enden
+func segment(a, b point, t float64) contour {
+	dx, dy := b.x-a.x, b.y-a.y
+	l := math.Hypot(dx, dy)
+	ux, uy := dx/l, dy/l // Richtung
+	nx, ny := -uy, ux    // Normale
+	h := t / 2
+	return contour{
+		a,
+		{a.x + ux*h + nx*h, a.y + uy*h + ny*h},
+		{b.x - ux*h + nx*h, b.y - uy*h + ny*h},
+		b,
+		{b.x - ux*h - nx*h, b.y - uy*h - ny*h},
+		{a.x + ux*h - nx*h, a.y + uy*h - ny*h},
+	}
+}
+
+// bar ist ein Segment mit geraden Enden, für die Diagonalen
+func bar(a, b point, t float64) contour {
+	dx, dy := b.x-a.x, b.y-a.y
+	l := math.Hypot(dx, dy)
+	nx, ny := -dy/l*t/2, dx/l*t/2
+	return contour{{a.x + nx, a.y + ny}, {b.x + nx, b.y + ny}, {b.x - nx, b.y - ny}, {a.x - nx, a.y - ny}}
+}
+
+func square(cx, cy, s float64) contour {
+	h := s / 2
+	return contour{{cx - h, cy - h}, {cx - h, cy + h}, {cx + h, cy + h}, {cx + h, cy - h}}
+}
+
+// segments liefert alle 14 Segmente für die Strichstärke t
+func segments(t float64) map[byte]contour {
+	lx, rx := left+t/2, right-t/2
+	by, ty := t/2, capHeight-t/2
+	my, cx := float64(capHeight)/2, float64(left+right)/2
+	d := t * 0.85 // Diagonalen etwas schmaler
+	return map[byte]contour{
+		'a': segment(point{lx + gap, ty}, point{rx - gap, ty}, t),
+		'b': segment(point{rx, ty - gap}, point{rx, my + gap}, t),
+		'c': segment(point{rx, my - gap}, point{rx, by + gap}, t),
+		'd': segment(point{rx - gap, by}, point{lx + gap, by}, t),
+		'e': segment(point{lx, by + gap}, point{lx, my - gap}, t),
+		'f': segment(point{lx, my + gap}, point{lx, ty - gap}, t),
+		'g': segment(point{lx + gap, my}, point{cx - gap, my}, t), // Mitte links
+		'G': segment(point{cx + gap, my}, point{rx - gap, my}, t), // Mitte rechts
+		'M': segment(point{lx + gap, my}, point{rx - gap, my}, t), // Mitte durchgehend
+		'h': bar(point{lx + t, ty - t}, point{cx - t*0.6, my + t*0.6}, d),
+		'i': segment(point{cx, ty - gap}, point{cx, my + gap}, t),
+		'j': bar(point{rx - t, ty - t}, point{cx + t*0.6, my + t*0.6}, d),
+		'k': bar(point{lx + t, by + t}, point{cx - t*0.6, my - t*0.6}, d),
+		'l': segment(point{cx, my - gap}, point{cx, by + gap}, t),
+		'm': bar(point{rx - t, by + t}, point{cx + t*0.6, my - t*0.6}, d),
+	}
+}
+
+// Segmentbelegung je Zeichen, Kleinbuchstaben verwenden dieselben Glyphen
+var glyphSegments = map[rune]string{
+	'0': "abcdef", '1': "bc", '2': "abMed", '3': "abcdM", '4': "fMbc",
+	'5': "afMcd", '6': "afedcM", '7': "abc", '8': "abcdefM", '9': "abcdfM",
+	'A': "abcefM", 'B': "abcdGil", 'C': "adef", 'D': "abcdil", 'E': "adefg",
+	'F': "aefg", 'G': "acdefG", 'H': "bcefM", 'I': "adil", 'J': "bcde",
+	'K': "efgjm", 'L': "def", 'M': "bcefhj", 'N': "bcefhm", 'O': "abcdef",
+	'P': "abefM", 'Q': "abcdefm", 'R': "abefMm", 'S': "afMcd", 'T': "ail",
+	'U': "bcdef", 'V': "efkj", 'W': "bcefkm", 'X': "hjkm", 'Y': "hjl", 'Z': "adjk",
+	'-': "M", '+': "gGil", '/': "jk", '\\': "hm", '_': "d", '=': "Md",
+	'(': "adef", ')': "abcd", '[': "adef", ']': "abcd", '\'': "i", '"': "fi",
+	'<': "jm", '>': "hk", '*': "gGhijklm", '?': "abGl",
+	'Ä': "abcefM", 'Ö': "abcdef", 'Ü': "bcdef",
+}
+
+type glyph struct {
+	contours []contour
+	advance  int
+}
+
+func buildGlyphs(t float64) (map[rune]glyph, glyph) {
+	segs := segments(t)
+	glyphs := map[rune]glyph{}
+	for r, s := range glyphSegments {
+		var g glyph
+		g.advance = advance
+		for i := 0; i < len(s); i++ {
+			g.contours = append(g.contours, segs[s[i]])
+		}
+		glyphs[r] = g
+	}
+
+	// Umlaute: zwei Punkte über dem Großbuchstaben
+	dots := []contour{square(200, capHeight+90, t), square(400, capHeight+90, t)}
+	for _, r := range "ÄÖÜ" {
+		g := glyphs[r]
+		g.contours = append(g.contours, dots...)
+		glyphs[r] = g
+	}
+	glyphs['ß'] = glyphs['B']
+
+	mid := float64(capHeight) / 2
+	glyphs[':'] = glyph{contours: []contour{square(150, mid-140, t), square(150, mid+140, t)}, advance: 300}
+	glyphs['.'] = glyph{contours: []contour{square(150, t/2, t)}, advance: 300}
+	glyphs[','] = glyph{contours: []contour{square(150, t/2, t), bar(point{150, 0}, point{110, -100}, t*0.6)}, advance: 300}
+	glyphs['!'] = glyph{contours: []contour{segment(point{300, capHeight - t/2}, point{300, 220}, t), square(300, t/2, t)}, advance: advance}
+	glyphs[' '] = glyph{advance: advance}
+
+	for r := 'a'; r <= 'z'; r++ {
+		glyphs[r] = glyphs[r-'a'+'A']
+	}
+	for _, p := range [][2]rune{{'ä', 'Ä'}, {'ö', 'Ö'}, {'ü', 'Ü'}} {
+		glyphs[p[0]] = glyphs[p[1]]
+	}
+
+	// .notdef: leeres Rechteck
+	notdef := glyph{advance: advance, contours: []contour{
+		{{left, 0}, {left, capHeight}, {right, capHeight}, {right, 0}},
+		{{left + 60, 60}, {right - 60, 60}, {right - 60, capHeight - 60}, {left + 60, capHeight - 60}},
+	}}
+	return glyphs, notdef
+}
+
+// clockwise bringt eine äußere Kontur in die von TrueType erwartete
+// Drehrichtung (im Uhrzeigersinn)
+func clockwise(c contour) contour {
+	var area float64
+	for i := range c {
+		p, q := c[i], c[(i+1)%len(c)]
+		area += p.x*q.y - q.x*p.y
+	}
+	if area > 0 {
+		out := make(contour, len(c))
+		for i := range c {
+			out[i] = c[len(c)-1-i]
+		}
+		return out
+	}
+	return c
+}
+
+type bbox struct{ xMin, yMin, xMax, yMax int16 }
+
+func encodeGlyph(g glyph, outer bool) ([]byte, bbox, int) {
+	if len(g.contours) == 0 {
+		return nil, bbox{}, 0
+	}
+	var buf bytes.Buffer
+	var ends []uint16
+	var pts [][2]int16
+	for i, c := range g.contours {
+		// bei .notdef ist die zweite Kontur das Loch und läuft andersherum
+		if outer || i == 0 {
+			c = clockwise(c)
+		}
+		for _, p := range c {
+			pts = append(pts, [2]int16{int16(math.Round(p.x)), int16(math.Round(p.y))})
+		}
+		ends = append(ends, uint16(len(pts)-1))
+	}
+	b := bbox{math.MaxInt16, math.MaxInt16, math.MinInt16, math.MinInt16}
+	for _, p := range pts {
+		b.xMin, b.xMax = min(b.xMin, p[0]), max(b.xMax, p[0])
+		b.yMin, b.yMax = min(b.yMin, p[1]), max(b.yMax, p[1])
+	}
+
+	w := func(v any) { binary.Write(&buf, binary.BigEndian, v) }
+	w(int16(len(g.contours)))
+	w(b)
+	w(ends)
+	w(uint16(0)) // keine Hinting-Anweisungen
+	for range pts {
+		buf.WriteByte(0x01) // Punkt auf der Kurve, Koordinaten als int16-Delta
+	}
+	var last [2]int16
+	for _, p := range pts {
+		w(p[0] - last[0])
+		last[0] = p[0]
+	}
+	for _, p := range pts {
+		w(p[1] - last[1])
+		last[1] = p[1]
+	}
+	for buf.Len()%4 != 0 {
+		buf.WriteByte(0)
+	}
+	return buf.Bytes(), b, len(pts)
+}
+
+type table struct {
+	tag  string
+	data []byte
+}
+
+func be(v ...any) []byte {
+	var buf bytes.Buffer
+	for _, x := range v {
+		binary.Write(&buf, binary.BigEndian, x)
+	}
+	return buf.Bytes()
+}
+
+func checksum(b []byte) uint32 {
+	for len(b)%4 != 0 {
+		b = append(b, 0)
+	}
+	var sum uint32
+	for i := 0; i < len(b); i += 4 {
+		sum += binary.BigEndian.Uint32(b[i:])
+	}
+	return sum
+}
+
+func nameTable(family, style string) []byte {
+	records := []struct {
+		id   uint16
+		text string
+	}{
+		{0, "Erzeugt von scoreboard-manager (internal/fonts/gen_segment.go)"},
+		{1, family},
+		{2, style},
+		{3, family + " " + style + " 1.0"},
+		{4, family + " " + style},
+		{5, "Version 1.0"},
+		{6, filepathSafe(family) + "-" + style},
+	}
+	var strs bytes.Buffer
+	var recs bytes.Buffer
+	for _, r := range records {
+		var enc []byte
+		for _, u := range utf16.Encode([]rune(r.text)) {
+			enc = append(enc, byte(u>>8), byte(u))
+		}
+		recs.Write(be(uint16(3), uint16(1), uint16(0x0409), r.id, uint16(len(enc)), uint16(strs.Len())))
+		strs.Write(enc)
+	}
+	head := be(uint16(0), uint16(len(records)), uint16(6+recs.Len()))
+	return append(append(head, recs.Bytes()...), strs.Bytes()...)
+}
+
+func filepathSafe(s string) string {
+	var out []rune
+	for _, r := range s {
+		if r != ' ' {
+			out = append(out, r)
+		}
+	}
+	return string(out)
+}
+
+func cmapTable(codes []rune, index map[rune]uint16) []byte {
+	// Format 4, ein Abschnitt je Zeichen plus Abschluss 0xFFFF
+	segCount := len(codes) + 1
+	var ends, starts, deltas, offsets []uint16
+	for _, r := range codes {
+		ends = append(ends, uint16(r))
+		starts = append(starts, uint16(r))
+		deltas = append(deltas, index[r]-uint16(r))
+		offsets = append(offsets, 0)
+	}
+	ends, starts, deltas, offsets = append(ends, 0xFFFF), append(starts, 0xFFFF), append(deltas, 1), append(offsets, 0)
+
+	entrySelector := uint16(math.Floor(math.Log2(float64(segCount))))
+	searchRange := uint16(2 << entrySelector)
+	body := be(uint16(segCount*2), searchRange, entrySelector, uint16(segCount*2)-searchRange,
+		ends, uint16(0), starts, deltas, offsets)
+	sub := append(be(uint16(4), uint16(6+len(body)), uint16(0)), body...)
+
+	return append(be(uint16(0), uint16(2), uint16(0), uint16(3), uint32(20), uint16(3), uint16(1), uint32(20)), sub...)
+}
+
+func build(family, style string, t float64, bold bool) []byte {
+	glyphs, notdef := buildGlyphs(t)
+
+	var codes []rune
+	for r := range glyphs {
+		codes = append(codes, r)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+
+	// Glyph 0 ist .notdef, danach je Zeichen eine Glyphe (auch bei gleicher Form)
+	order := []glyph{notdef}
+	index := map[rune]uint16{}
+	for _, r := range codes {
+		index[r] = uint16(len(order))
+		order = append(order, glyphs[r])
+	}
+
+	var glyf bytes.Buffer
+	var loca []uint32
+	var hmtx bytes.Buffer
+	total := bbox{math.MaxInt16, math.MaxInt16, math.MinInt16, math.MinInt16}
+	maxPoints, maxContours := 0, 0
+	for i, g := range order {
+		loca = append(loca, uint32(glyf.Len()))
+		data, b, n := encodeGlyph(g, i != 0)
+		glyf.Write(data)
+		if data != nil {
+			total.xMin, total.yMin = min(total.xMin, b.xMin), min(total.yMin, b.yMin)
+			total.xMax, total.yMax = max(total.xMax, b.xMax), max(total.yMax, b.yMax)
+			maxPoints, maxContours = max(maxPoints, n), max(maxContours, len(g.contours))
+		}
+		hmtx.Write(be(uint16(g.advance), b.xMin))
+	}
+	loca = append(loca, uint32(glyf.Len()))
+
+	// jede Stärke ist eine eigene Familie mit Stil "Regular"
+	macStyle, weight, fsSelection := uint16(0), uint16(400), uint16(0x40)
+	if bold {
+		weight = 700
+	}
+	numGlyphs := uint16(len(order))
+
+	head := be(uint32(0x00010000), uint32(0x00010000), uint32(0), uint32(0x5F0F3CF5), uint16(0x000B), uint16(unitsPerEm),
+		int64(0), int64(0), total, macStyle, uint16(8), int16(2), int16(1), int16(0))
+	hhea := be(uint32(0x00010000), int16(ascender), int16(descender), int16(0), uint16(advance),
+		total.xMin, int16(0), total.xMax, int16(1), int16(0), int16(0), [4]int16{}, int16(0), numGlyphs)
+	maxp := be(uint32(0x00010000), numGlyphs, uint16(maxPoints), uint16(maxContours), uint16(0), uint16(0),
+		uint16(2), uint16(0), uint16(0), uint16(0), uint16(0), uint16(0), uint16(0), uint16(0), uint16(0))
+	os2 := be(uint16(4), int16(advance), weight, uint16(5), uint16(0),
+		int16(650), int16(700), int16(0), int16(140), int16(650), int16(700), int16(0), int16(480),
+		int16(t), int16(capHeight/2), int16(0), [10]byte{}, uint32(3), uint32(0), uint32(0), uint32(0),
+		[4]byte{'N', 'O', 'N', 'E'}, fsSelection, uint16(codes[0]), uint16(codes[len(codes)-1]),
+		int16(ascender), int16(descender), int16(0), uint16(ascender), uint16(-descender),
+		uint32(1), uint32(0), int16(capHeight), int16(capHeight), uint16(0), uint16(' '), uint16(0))
+	post := be(uint32(0x00030000), int32(0), int16(-100), int16(50), uint32(0), uint32(0), uint32(0), uint32(0), uint32(0))
+
+	tables := []table{
+		{"OS/2", os2},
+		{"cmap", cmapTable(codes, index)},
+		{"glyf", glyf.Bytes()},
+		{"head", head},
+		{"hhea", hhea},
+		{"hmtx", hmtx.Bytes()},
+		{"loca", be(loca)},
+		{"maxp", maxp},
+		{"name", nameTable(family, style)},
+		{"post", post},
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].tag < tables[j].tag })
+
+	n := uint16(len(tables))
+	entrySelector := uint16(math.Floor(math.Log2(float64(n))))
+	searchRange := uint16(16 << entrySelector)
+	var out bytes.Buffer
+	out.Write(be(uint32(0x00010000), n, searchRange, entrySelector, n*16-searchRange))
+
+	offset := uint32(12 + 16*len(tables))
+	var headOffset uint32
+	for _, tb := range tables {
+		if tb.tag == "head" {
+			headOffset = offset
+		}
+		out.Write([]byte(tb.tag))
+		out.Write(be(checksum(tb.data), offset, uint32(len(tb.data))))
+		offset += uint32((len(tb.data) + 3) &^ 3)
+	}
+	for _, tb := range tables {
+		out.Write(tb.data)
+		for i := len(tb.data); i%4 != 0; i++ {
+			out.WriteByte(0)
+		}
+	}
+
+	font := out.Bytes()
+	binary.BigEndian.PutUint32(font[headOffset+8:], 0xB1B0AFBA-checksum(font))
+	return font
+}
+
+func main() {
+	for _, f := range []struct {
+		file, family string
+		thickness    float64
+		bold         bool
+	}{
+		{"ScoreboardSegment.ttf", "Scoreboard Segment", 80, false},
+		{"ScoreboardSegment-Bold.ttf", "Scoreboard Segment Bold", 115, true},
+	} {
+		style := "Regular"
+		if err := os.WriteFile(filepath.Join("ttf", f.file), build(f.family, style, f.thickness, f.bold), 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
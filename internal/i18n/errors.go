@@ -0,0 +1,63 @@
+// internal/i18n/errors.go
+
+package i18n
+
+import "errors"
+
+// Error ist eine Fehlermeldung mit Schlüssel; übersetzt wird erst bei der
+// Ausgabe, damit z.B. die API den Schlüssel mitliefern und ein Client den
+// Text selbst übersetzen kann. Fehler unter den Args werden mit %v
+// ausgegeben und lassen sich mit errors.Is/As finden.
+type Error struct {
+	Key  string
+	Args []any
+}
+
+// Errorf erzeugt einen Fehler mit Schlüssel
+func Errorf(key string, args ...any) error {
+	return &Error{Key: key, Args: args}
+}
+
+func (e *Error) Error() string {
+	return T(e.Key, e.Args...)
+}
+
+// In liefert die Meldung in einer bestimmten Sprache
+func (e *Error) In(locale string) string {
+	return In(locale, e.Key, e.Args...)
+}
+
+func (e *Error) Unwrap() []error {
+	var errs []error
+	for _, a := range e.Args {
+		if err, ok := a.(error); ok {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// Key liefert den Schlüssel des ersten übersetzbaren Fehlers in der Kette
+func Key(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Key
+	}
+	return ""
+}
+
+// Msg ist ein Text, der wie Error erst bei der Ausgabe übersetzt wird, z.B.
+// als Teil einer Fehlermeldung
+type Msg struct {
+	Key  string
+	Args []any
+}
+
+// M erzeugt einen Text mit Schlüssel
+func M(key string, args ...any) Msg {
+	return Msg{Key: key, Args: args}
+}
+
+func (m Msg) String() string {
+	return T(m.Key, m.Args...)
+}
@@ -0,0 +1,134 @@
+// internal/i18n/i18n.go
+
+// Package i18n übersetzt die Texte der Oberflächen und Fehlermeldungen. Die
+// Kataloge liegen als TOML-Dateien in locales/, eine Datei pro Sprache
+// (de.toml, en.toml); für eine weitere Sprache genügt eine weitere Datei.
+//
+// Ein Schlüssel ist Abschnitt und Name mit Punkt verbunden, z.B.
+// "admin.msg.select_team"; Texte sind fmt-Formate. Fehlt ein Text in der
+// gewählten Sprache, gilt der deutsche, fehlt auch der, der Schlüssel.
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Fallback ist die Sprache, in der alle Texte vorhanden sein müssen
+const Fallback = "de"
+
+//go:embed locales/*.toml
+var files embed.FS
+
+var (
+	mu       sync.RWMutex
+	current  = Fallback
+	catalogs = mustLoad()
+)
+
+// mustLoad liest die eingebetteten Kataloge; ein Fehler ist ein Fehler im
+// Programm, nicht in der Umgebung
+func mustLoad() map[string]map[string]string {
+	entries, err := files.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+	all := map[string]map[string]string{}
+	for _, e := range entries {
+		var raw map[string]any
+		if _, err := toml.DecodeFS(files, path.Join("locales", e.Name()), &raw); err != nil {
+			panic(fmt.Sprintf("Sprachkatalog %s: %v", e.Name(), err))
+		}
+		c := map[string]string{}
+		flatten("", raw, c)
+		all[strings.TrimSuffix(e.Name(), ".toml")] = c
+	}
+	if all[Fallback] == nil {
+		panic("Sprachkatalog " + Fallback + ".toml fehlt")
+	}
+	return all
+}
+
+func flatten(prefix string, raw map[string]any, into map[string]string) {
+	for k, v := range raw {
+		switch v := v.(type) {
+		case map[string]any:
+			flatten(prefix+k+".", v, into)
+		case string:
+			into[prefix+k] = v
+		}
+	}
+}
+
+// Locales liefert die verfügbaren Sprachen, sortiert
+func Locales() []string {
+	var locales []string
+	for l := range catalogs {
+		locales = append(locales, l)
+	}
+	slices.Sort(locales)
+	return locales
+}
+
+// Normalize macht aus "en-US", "en_US.UTF-8" usw. die Sprache des Katalogs
+// ("en"); leer, wenn es keinen passenden Katalog gibt
+func Normalize(locale string) string {
+	l := strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(l, "-_."); i >= 0 {
+		l = l[:i]
+	}
+	if _, ok := catalogs[l]; ok {
+		return l
+	}
+	return ""
+}
+
+// SetLocale wählt die Sprache für T und Fehlermeldungen
+func SetLocale(locale string) error {
+	l := Normalize(locale)
+	if l == "" {
+		return Errorf("error.locale", locale, strings.Join(Locales(), ", "))
+	}
+	mu.Lock()
+	current = l
+	mu.Unlock()
+	return nil
+}
+
+// Locale liefert die gewählte Sprache
+func Locale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// T liefert den Text zu key in der gewählten Sprache, mit args formatiert
+func T(key string, args ...any) string {
+	return In(Locale(), key, args...)
+}
+
+// In liefert den Text zu key in einer bestimmten Sprache
+func In(locale, key string, args ...any) string {
+	text, ok := catalogs[locale][key]
+	if !ok {
+		if text, ok = catalogs[Fallback][key]; !ok {
+			text = key
+		}
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// Has meldet, ob es den Schlüssel im deutschen Katalog gibt
+func Has(key string) bool {
+	_, ok := catalogs[Fallback][key]
+	return ok
+}
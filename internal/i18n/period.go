@@ -0,0 +1,67 @@
+// internal/i18n/period.go
+
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// periodKinds sind die Arten von Spielabschnitten; ihre Namen stehen in den
+// Katalogen unter [period]. Das Perioden-Label einer Sportart ist damit
+// Daten ("Halbzeit") und wird für die Anzeige der Art zugeordnet.
+var periodKinds = []string{"half", "third", "quarter", "period", "inning", "set", "overtime"}
+
+// ordinalRules für Sprachen, deren Ordnungszahlen kein festes Muster haben;
+// alle anderen nutzen "format.ordinal" aus ihrem Katalog
+var ordinalRules = map[string]func(n int) string{
+	"en": func(n int) string {
+		suffix := "th"
+		if n%100 < 11 || n%100 > 13 {
+			switch n % 10 {
+			case 1:
+				suffix = "st"
+			case 2:
+				suffix = "nd"
+			case 3:
+				suffix = "rd"
+			}
+		}
+		return fmt.Sprintf("%d%s", n, suffix)
+	},
+}
+
+// Ordinal liefert die Ordnungszahl, z.B. "2." bzw. "2nd"
+func Ordinal(n int) string {
+	locale := Locale()
+	if rule, ok := ordinalRules[locale]; ok {
+		return rule(n)
+	}
+	return In(locale, "format.ordinal", n)
+}
+
+// PeriodKind ordnet ein Perioden-Label einer Art zu ("Halbzeit" und "Half"
+// werden zu "half"); leer, wenn das Label in keinem Katalog vorkommt
+func PeriodKind(label string) string {
+	label = strings.TrimSpace(label)
+	for _, kind := range periodKinds {
+		for _, c := range catalogs {
+			if name, ok := c["period."+kind]; ok && strings.EqualFold(name, label) {
+				return kind
+			}
+		}
+	}
+	return ""
+}
+
+// PeriodName liefert z.B. "1. Halbzeit" bzw. "1st Half". Unbekannte Labels
+// werden unverändert übernommen, ohne Label bleibt die Ordnungszahl.
+func PeriodName(label string, n int) string {
+	if strings.TrimSpace(label) == "" {
+		return Ordinal(n)
+	}
+	if kind := PeriodKind(label); kind != "" {
+		label = T("period." + kind)
+	}
+	return T("format.period", Ordinal(n), label)
+}
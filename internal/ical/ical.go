@@ -0,0 +1,342 @@
+// internal/ical/ical.go
+//
+// Lesen und Schreiben von iCalendar-Dateien (RFC 5545), soweit sie für
+// Spielpläne gebraucht werden: ein Kalender mit VEVENTs, ohne
+// Wiederholungen, Alarme oder Zeitzonendefinitionen.
+
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	_ "time/tzdata" // TZID=Europe/Berlin auch unter Windows ohne Zonendatenbank
+	"unicode/utf8"
+
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+)
+
+// Status eines Termins
+const (
+	StatusConfirmed = "CONFIRMED"
+	StatusTentative = "TENTATIVE"
+	StatusCancelled = "CANCELLED"
+)
+
+// PropSport ist die eigene Eigenschaft für die Sportart, damit ein
+// exportierter Spielplan ohne Angabe der Sportart wieder importiert werden kann
+const PropSport = "X-SCOREBOARD-SPORTART"
+
+// Calendar ist ein Kalender mit seinen Terminen
+type Calendar struct {
+	Name   string    // X-WR-CALNAME, der Name beim Abonnieren
+	Stamp  time.Time // DTSTAMP der Termine, Standard: jetzt
+	Events []*Event
+}
+
+// Event ist ein Termin (VEVENT)
+type Event struct {
+	UID         string
+	Start       time.Time
+	End         time.Time // leer: ohne DTEND
+	AllDay      bool      // nur Datum, Start ist Mitternacht in der Zeitzone von Parse
+	Summary     string
+	Location    string
+	Description string
+	Categories  []string
+	Status      string // StatusConfirmed, ...
+	Sport       string // PropSport
+}
+
+// Teams teilt den Titel "Heim – Gast" in die beiden Teamnamen. Erkannt
+// werden Gedankenstrich, Bindestrich mit Leerzeichen, "vs" und "gegen".
+func (e *Event) Teams() (home, away string, ok bool) {
+	for _, sep := range []string{" – ", " — ", " - ", " vs. ", " vs ", " gegen ", " : "} {
+		if home, away, ok = strings.Cut(e.Summary, sep); ok {
+			return strings.TrimSpace(home), strings.TrimSpace(away), home != "" && away != ""
+		}
+	}
+	return "", "", false
+}
+
+// Write schreibt den Kalender mit CRLF und nach 75 Bytes gefalteten Zeilen
+func Write(w io.Writer, cal *Calendar) error {
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeFolded(bw, name+":"+value)
+	}
+	stamp := cal.Stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//KernTom//scoreboard-manager//DE")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if cal.Name != "" {
+		line("X-WR-CALNAME", escape(cal.Name))
+	}
+	for _, e := range cal.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", escape(e.UID))
+		line("DTSTAMP", formatUTC(stamp))
+		if e.AllDay {
+			line("DTSTART;VALUE=DATE", e.Start.Format(dateLayout))
+		} else {
+			line("DTSTART", formatUTC(e.Start))
+			if !e.End.IsZero() {
+				line("DTEND", formatUTC(e.End))
+			}
+		}
+		line("SUMMARY", escape(e.Summary))
+		if e.Location != "" {
+			line("LOCATION", escape(e.Location))
+		}
+		if e.Description != "" {
+			line("DESCRIPTION", escape(e.Description))
+		}
+		if len(e.Categories) > 0 {
+			cats := make([]string, len(e.Categories))
+			for i, c := range e.Categories {
+				cats[i] = escape(c)
+			}
+			line("CATEGORIES", strings.Join(cats, ","))
+		}
+		if e.Status != "" {
+			line("STATUS", e.Status)
+		}
+		if e.Sport != "" {
+			line(PropSport, escape(e.Sport))
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405"
+)
+
+func formatUTC(t time.Time) string {
+	return t.UTC().Format(dateTimeLayout) + "Z"
+}
+
+// writeFolded faltet nach höchstens 75 Bytes, ohne UTF-8-Zeichen zu teilen;
+// Folgezeilen beginnen mit einem Leerzeichen
+func writeFolded(w *bufio.Writer, s string) {
+	limit := 75
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.WriteString(s[:cut] + "\r\n ")
+		s = s[cut:]
+		limit = 74
+	}
+	w.WriteString(s + "\r\n")
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func escape(s string) string { return escaper.Replace(s) }
+
+func unescape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// property ist eine entfaltete Inhaltszeile NAME;PARAM=WERT:WERT
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Parse liest einen Kalender. Zeiten ohne Zeitzone und ganztägige Termine
+// gelten in loc, ebenso Zeiten mit unbekannter TZID.
+func Parse(r io.Reader, loc *time.Location) (*Calendar, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	props, err := readProperties(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(props) == 0 || props[0].name != "BEGIN" || !strings.EqualFold(props[0].value, "VCALENDAR") {
+		return nil, i18n.Errorf("error.ical.not_ical")
+	}
+
+	cal := &Calendar{}
+	var ev *Event
+	depth := 0 // verschachtelte Komponenten im Termin, z.B. VALARM
+	for _, p := range props {
+		switch {
+		case p.name == "BEGIN" && strings.EqualFold(p.value, "VEVENT") && ev == nil:
+			ev = &Event{}
+		case p.name == "BEGIN" && ev != nil:
+			depth++
+		case p.name == "END" && ev != nil && depth > 0:
+			depth--
+		case p.name == "END" && strings.EqualFold(p.value, "VEVENT") && ev != nil:
+			cal.Events = append(cal.Events, ev)
+			ev = nil
+		case ev == nil || depth > 0:
+			if p.name == "X-WR-CALNAME" && ev == nil {
+				cal.Name = unescape(p.value)
+			}
+		default:
+			if err := ev.set(p, loc); err != nil {
+				return nil, i18n.Errorf("error.ical.event", len(cal.Events)+1, err)
+			}
+		}
+	}
+	if ev != nil {
+		return nil, i18n.Errorf("error.ical.unterminated")
+	}
+	return cal, nil
+}
+
+func (e *Event) set(p property, loc *time.Location) error {
+	var err error
+	switch p.name {
+	case "UID":
+		e.UID = unescape(p.value)
+	case "DTSTART":
+		e.Start, e.AllDay, err = parseTime(p, loc)
+	case "DTEND":
+		e.End, _, err = parseTime(p, loc)
+	case "SUMMARY":
+		e.Summary = strings.TrimSpace(unescape(p.value))
+	case "LOCATION":
+		e.Location = strings.TrimSpace(unescape(p.value))
+	case "DESCRIPTION":
+		e.Description = unescape(p.value)
+	case "CATEGORIES":
+		for _, c := range splitList(p.value) {
+			if c = strings.TrimSpace(unescape(c)); c != "" {
+				e.Categories = append(e.Categories, c)
+			}
+		}
+	case "STATUS":
+		e.Status = strings.ToUpper(p.value)
+	case PropSport:
+		e.Sport = unescape(p.value)
+	}
+	return err
+}
+
+func parseTime(p property, loc *time.Location) (time.Time, bool, error) {
+	v := strings.TrimSpace(p.value)
+	if strings.EqualFold(p.params["VALUE"], "DATE") || len(v) == len(dateLayout) {
+		t, err := time.ParseInLocation(dateLayout, v, loc)
+		if err != nil {
+			return time.Time{}, false, i18n.Errorf("error.ical.date", v)
+		}
+		return t, true, nil
+	}
+	if strings.HasSuffix(v, "Z") {
+		loc = time.UTC
+		v = strings.TrimSuffix(v, "Z")
+	} else if tzid := strings.Trim(p.params["TZID"], `"`); tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	t, err := time.ParseInLocation(dateTimeLayout, v, loc)
+	if err != nil {
+		return time.Time{}, false, i18n.Errorf("error.ical.time", p.value)
+	}
+	return t, false, nil
+}
+
+// splitList teilt an Kommas, die nicht mit \ maskiert sind
+func splitList(s string) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case ',':
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// readProperties entfaltet die Zeilen und zerlegt sie in Eigenschaften
+func readProperties(r io.Reader) ([]property, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	var lines []string
+	for sc.Scan() {
+		l := strings.TrimSuffix(sc.Text(), "\r")
+		if len(lines) == 0 {
+			l = strings.TrimPrefix(l, "\uFEFF")
+		}
+		if (strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += l[1:]
+			continue
+		}
+		if strings.TrimSpace(l) != "" {
+			lines = append(lines, l)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	props := make([]property, 0, len(lines))
+	for _, l := range lines {
+		p, err := parseProperty(l)
+		if err != nil {
+			return nil, err
+		}
+		props = append(props, p)
+	}
+	return props, nil
+}
+
+// parseProperty trennt Name, Parameter und Wert; Doppelpunkte in
+// Parameterwerten in Anführungszeichen gehören nicht zum Trenner
+func parseProperty(l string) (property, error) {
+	colon, quoted := -1, false
+	for i, c := range l {
+		if c == '"' {
+			quoted = !quoted
+		} else if c == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return property{}, i18n.Errorf("error.ical.line", l)
+	}
+	head := strings.Split(l[:colon], ";")
+	p := property{name: strings.ToUpper(head[0]), params: map[string]string{}, value: l[colon+1:]}
+	for _, param := range head[1:] {
+		k, v, _ := strings.Cut(param, "=")
+		p.params[strings.ToUpper(k)] = v
+	}
+	return p, nil
+}
@@ -0,0 +1,100 @@
+// internal/ical/schedule.go
+
+package ical
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// defaultDuration gilt für Sportarten ohne Periodenangaben
+const defaultDuration = 2 * time.Hour
+
+// Schedule erstellt den Kalender der Spiele eines Wettbewerbs, ohne
+// Wettbewerb den aller Spiele
+func Schedule(competition string) (*Calendar, error) {
+	var matches []*models.Match
+	var err error
+	if competition != "" {
+		matches, err = database.LoadMatchesByCompetition(competition)
+	} else {
+		matches, err = database.LoadMatches()
+	}
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(matches, func(a, b *models.Match) int { return a.GameTime.Compare(b.GameTime) })
+
+	sports, err := database.LoadSports()
+	if err != nil {
+		return nil, err
+	}
+	durations := map[string]time.Duration{}
+	for _, s := range sports {
+		if s.PeriodsCount > 0 && s.PeriodDuration > 0 {
+			// Spielzeit plus eine halbe Stunde für Pausen und Nachspielzeit
+			durations[s.Sportart] = time.Duration(s.PeriodsCount*s.PeriodDuration+30) * time.Minute
+		}
+	}
+	fields, err := database.LoadFields(0)
+	if err != nil {
+		return nil, err
+	}
+	locations := map[int]string{}
+	for _, f := range fields {
+		locations[f.ID] = f.VenueName + " " + f.Name
+	}
+
+	cal := &Calendar{Name: cmp.Or(competition, i18n.T("ical.schedule"))}
+	for _, m := range matches {
+		cal.Events = append(cal.Events, matchEvent(m, cmp.Or(durations[m.Sportart], defaultDuration), locations))
+	}
+	return cal, nil
+}
+
+func matchEvent(m *models.Match, duration time.Duration, locations map[int]string) *Event {
+	open := i18n.T("format.team_open")
+	e := &Event{
+		UID:      m.ICalUID,
+		Start:    m.GameTime,
+		End:      m.GameTime.Add(duration),
+		Summary:  cmp.Or(m.Team1.NameFor(m.Competition), open) + " – " + cmp.Or(m.Team2.NameFor(m.Competition), open),
+		Location: cmp.Or(locations[m.FieldID], m.Field),
+		Status:   StatusConfirmed,
+		Sport:    m.Sportart,
+	}
+	if m.Competition != "" {
+		e.Categories = []string{m.Competition}
+	}
+	switch m.Status {
+	case models.MatchPostponed:
+		e.Status = StatusTentative
+	case models.MatchAbandoned:
+		e.Status = StatusCancelled
+	}
+
+	var desc []string
+	if m.Competition != "" {
+		desc = append(desc, i18n.T("ical.competition", m.Competition))
+	}
+	if m.Group != "" {
+		desc = append(desc, i18n.T("ical.group", m.Group))
+	}
+	if m.Round > 0 {
+		desc = append(desc, i18n.T("ical.round", m.Round))
+	}
+	switch {
+	case m.HasResult():
+		desc = append(desc, i18n.T("ical.result", *m.ScoreHome, *m.ScoreAway))
+	case m.Status == models.MatchPostponed:
+		desc = append(desc, i18n.T("ical.postponed"))
+	}
+	e.Description = strings.Join(desc, "\n")
+	return e
+}
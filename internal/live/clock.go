@@ -0,0 +1,60 @@
+// internal/live/clock.go
+
+package live
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// FormatClock liefert den Uhrtext gemäß Gameclock-Modus des Templates und
+// ob die reguläre Periodendauer überschritten ist (Nachspielzeit)
+func FormatClock(t *models.TemplateSettings, period int, elapsed time.Duration) (string, bool) {
+	duration := time.Duration(t.PeriodDuration) * time.Minute
+	overtime := duration > 0 && elapsed > duration
+
+	switch t.GameclockMode {
+	case models.GameclockUpMinutes:
+		// Fußball-Zählweise: laufende Minute, ab der zweiten Periode fortlaufend
+		offset := (period - 1) * t.PeriodDuration
+		if overtime {
+			extra := int((elapsed-duration)/time.Minute) + 1
+			return fmt.Sprintf("%d+%d'", offset+t.PeriodDuration, extra), true
+		}
+		return fmt.Sprintf("%d'", offset+int(elapsed/time.Minute)+1), false
+
+	case models.GameclockDownMMSS:
+		if overtime {
+			return "+" + formatMMSS(elapsed-duration), true
+		}
+		return formatMMSS(duration - elapsed), false
+
+	default:
+		return formatMMSS(elapsed), overtime
+	}
+}
+
+// PeriodText liefert z.B. "2. Halbzeit" bzw. "Pause" und "Ende" außerhalb
+// des Spielbetriebs, in der eingestellten Sprache ("2nd Half", "Break")
+func PeriodText(t *models.TemplateSettings, period int, status string) string {
+	switch status {
+	case models.MatchHalftime:
+		return i18n.T("period.break")
+	case models.MatchFinished:
+		return i18n.T("period.end")
+	case models.MatchAbandoned:
+		return i18n.T("period.abandoned")
+	}
+	return i18n.PeriodName(t.PeriodLabel, period)
+}
+
+func formatMMSS(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	secs := int(d / time.Second)
+	return fmt.Sprintf("%02d:%02d", secs/60, secs%60)
+}
@@ -0,0 +1,400 @@
+// internal/live/engine.go
+
+package live
+
+import (
+	"sync"
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// Engine steuert ein einzelnes Live-Spiel: Spielstand, Perioden und die
+// Spieluhr. Eine eigene Goroutine verteilt den Stand im Sekundentakt an
+// alle Abonnenten. Alle Methoden sind nebenläufig aufrufbar.
+type Engine struct {
+	mu       sync.Mutex
+	match    *models.Match // nach NewEngine unverändert; Status und Stand stehen in state
+	template *models.TemplateSettings
+	state    models.LiveState
+	events   []models.MatchEvent
+
+	elapsed   time.Duration // Spielzeit der aktuellen Periode bis startedAt
+	startedAt time.Time     // Zeitpunkt des letzten Uhrstarts, nur gültig wenn Running
+
+	subs   map[int]chan models.LiveState
+	nextID int
+
+	now  func() time.Time
+	tick time.Duration
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewEngine erzeugt die Engine für ein Spiel; Start startet die Uhr-Goroutine
+func NewEngine(match *models.Match, template *models.TemplateSettings) *Engine {
+	e := &Engine{
+		match:    match,
+		template: template,
+		subs:     map[int]chan models.LiveState{},
+		now:      time.Now,
+		tick:     time.Second,
+		done:     make(chan struct{}),
+	}
+
+	status := match.Status
+	if status == "" {
+		status = models.MatchScheduled
+	}
+	e.state = models.LiveState{
+		MatchID:     match.ID,
+		FieldID:     match.FieldID,
+		Status:      status,
+		Period:      1,
+		TemplateID:  template.ID,
+		Competition: match.Competition,
+	}
+	if match.Team1 != nil {
+		e.state.HomeName = match.Team1.NameFor(match.Competition)
+		e.state.HomeNames = match.Team1.NameVariants(match.Competition)
+	}
+	if match.Team2 != nil {
+		e.state.AwayName = match.Team2.NameFor(match.Competition)
+		e.state.AwayNames = match.Team2.NameVariants(match.Competition)
+	}
+	if match.ScoreHome != nil {
+		e.state.HomeScore = *match.ScoreHome
+	}
+	if match.ScoreAway != nil {
+		e.state.AwayScore = *match.ScoreAway
+	}
+	e.refreshLocked()
+	return e
+}
+
+// Resume übernimmt einen gesicherten Stand (siehe database.SaveLiveCheckpoint).
+// Die Uhr bleibt angehalten, bis sie wieder gestartet wird.
+func (e *Engine) Resume(state models.LiveState, events []models.MatchEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.state.Status = state.Status
+	e.state.HomeScore = state.HomeScore
+	e.state.AwayScore = state.AwayScore
+	e.state.Period = state.Period
+	e.elapsed = state.Elapsed
+	e.state.Running = false
+	e.events = append([]models.MatchEvent(nil), events...)
+	e.state.EventCount = len(e.events)
+	e.refreshLocked()
+}
+
+// Start startet die Uhr-Goroutine
+func (e *Engine) Start() {
+	e.wg.Add(1)
+	go e.run()
+}
+
+// Stop beendet die Goroutine und schließt alle Abonnements
+func (e *Engine) Stop() {
+	e.mu.Lock()
+	select {
+	case <-e.done:
+		e.mu.Unlock()
+		return
+	default:
+	}
+	close(e.done)
+	e.mu.Unlock()
+
+	e.wg.Wait()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for id, ch := range e.subs {
+		close(ch)
+		delete(e.subs, id)
+	}
+}
+
+func (e *Engine) run() {
+	defer e.wg.Done()
+	ticker := time.NewTicker(e.tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.done:
+			return
+		case <-ticker.C:
+			e.mu.Lock()
+			if e.state.Running {
+				e.publishLocked()
+			}
+			e.mu.Unlock()
+		}
+	}
+}
+
+// Match liefert eine Kopie des Spiels, wie es übernommen wurde; Status und
+// Spielstand liefert Snapshot
+func (e *Engine) Match() *models.Match {
+	m := *e.match
+	return &m
+}
+
+// Template liefert das Anzeige-Template des Spiels
+func (e *Engine) Template() *models.TemplateSettings {
+	return e.template
+}
+
+// Snapshot liefert den aktuellen Stand
+func (e *Engine) Snapshot() models.LiveState {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.refreshLocked()
+	return e.state
+}
+
+// Events liefert eine Kopie aller bisherigen Ereignisse
+func (e *Engine) Events() []models.MatchEvent {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]models.MatchEvent(nil), e.events...)
+}
+
+// Subscribe liefert einen Kanal mit Standänderungen. Langsame Empfänger
+// verpassen Zwischenstände, bekommen aber immer den neuesten Stand.
+// Der Kanal wird mit cancel bzw. Stop geschlossen.
+func (e *Engine) Subscribe() (<-chan models.LiveState, func()) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	ch := make(chan models.LiveState, 1)
+	id := e.nextID
+	e.nextID++
+
+	select {
+	case <-e.done:
+		close(ch)
+		return ch, func() {}
+	default:
+	}
+
+	e.subs[id] = ch
+	e.refreshLocked()
+	ch <- e.state
+
+	cancel := func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		if c, ok := e.subs[id]; ok {
+			close(c)
+			delete(e.subs, id)
+		}
+	}
+	return ch, cancel
+}
+
+// Kickoff setzt das Spiel live und startet die Uhr
+func (e *Engine) Kickoff() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := e.setStatusLocked(models.MatchLive); err != nil {
+		return err
+	}
+	e.startClockLocked()
+	e.publishLocked()
+	return nil
+}
+
+// StartClock startet die angehaltene Uhr
+func (e *Engine) StartClock() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.state.Status != models.MatchLive {
+		return i18n.Errorf("error.clock_not_live")
+	}
+	e.startClockLocked()
+	e.publishLocked()
+	return nil
+}
+
+// StopClock hält die Uhr an
+func (e *Engine) StopClock() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.stopClockLocked()
+	e.publishLocked()
+}
+
+// SetClock setzt die gespielte Zeit der aktuellen Periode
+func (e *Engine) SetClock(elapsed time.Duration) error {
+	if elapsed < 0 {
+		return i18n.Errorf("error.clock_negative")
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.elapsed = elapsed
+	if e.state.Running {
+		e.startedAt = e.now()
+	}
+	e.addEventLocked(models.MatchEvent{Type: models.EventClock, Value: int(elapsed / time.Second)})
+	e.publishLocked()
+	return nil
+}
+
+// AddScore ändert den Spielstand einer Seite um delta (negativ für Korrekturen)
+func (e *Engine) AddScore(side string, delta int) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.state.Status == models.MatchFinished || e.state.Status == models.MatchAbandoned {
+		return i18n.Errorf("error.match_status", e.state.Status)
+	}
+
+	score := &e.state.HomeScore
+	switch side {
+	case models.SlotHome:
+	case models.SlotAway:
+		score = &e.state.AwayScore
+	default:
+		return i18n.Errorf("error.unknown_side", side)
+	}
+	if *score+delta < 0 {
+		return i18n.Errorf("error.score_negative")
+	}
+	*score += delta
+
+	e.addEventLocked(models.MatchEvent{Type: models.EventScore, Side: side, Value: delta})
+	e.publishLocked()
+	return nil
+}
+
+// Halftime unterbricht das Spiel nach einer Periode (Pause bzw. Viertelpause)
+func (e *Engine) Halftime() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.state.Period >= e.template.PeriodsCount && e.template.PeriodsCount > 0 {
+		return i18n.Errorf("error.last_period")
+	}
+	if err := e.setStatusLocked(models.MatchHalftime); err != nil {
+		return err
+	}
+	e.stopClockLocked()
+	e.publishLocked()
+	return nil
+}
+
+// NextPeriod beginnt nach der Pause die nächste Periode mit zurückgesetzter Uhr
+func (e *Engine) NextPeriod() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := e.setStatusLocked(models.MatchLive); err != nil {
+		return err
+	}
+	e.state.Period++
+	e.elapsed = 0
+	e.addEventLocked(models.MatchEvent{Type: models.EventPeriod, Value: e.state.Period})
+	e.startClockLocked()
+	e.publishLocked()
+	return nil
+}
+
+// Finish beendet das Spiel
+func (e *Engine) Finish() error {
+	return e.end(models.MatchFinished, nil)
+}
+
+// FinishWith beendet das Spiel und ruft vorher save mit dem Endstand auf,
+// z.B. um ihn zu speichern. Ist der Statuswechsel nicht erlaubt, wird save
+// nicht aufgerufen; liefert save einen Fehler, bleibt das Spiel unverändert.
+// Bis save zurückkehrt, ist der Stand gesperrt.
+func (e *Engine) FinishWith(save func(models.LiveState) error) error {
+	return e.end(models.MatchFinished, save)
+}
+
+// Abandon bricht das Spiel ab
+func (e *Engine) Abandon() error {
+	return e.end(models.MatchAbandoned, nil)
+}
+
+func (e *Engine) end(status string, save func(models.LiveState) error) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := models.ValidateTransition(e.state.Status, status); err != nil {
+		return err
+	}
+	if save != nil {
+		e.refreshLocked()
+		if err := save(e.state); err != nil {
+			return err
+		}
+	}
+	if err := e.setStatusLocked(status); err != nil {
+		return err
+	}
+	e.stopClockLocked()
+	e.publishLocked()
+	return nil
+}
+
+func (e *Engine) setStatusLocked(status string) error {
+	if err := models.ValidateTransition(e.state.Status, status); err != nil {
+		return err
+	}
+	e.state.Status = status
+	e.addEventLocked(models.MatchEvent{Type: models.EventStatus, Detail: status})
+	return nil
+}
+
+func (e *Engine) startClockLocked() {
+	if e.state.Running {
+		return
+	}
+	e.state.Running = true
+	e.startedAt = e.now()
+}
+
+func (e *Engine) stopClockLocked() {
+	if !e.state.Running {
+		return
+	}
+	e.elapsed += e.now().Sub(e.startedAt)
+	e.state.Running = false
+}
+
+func (e *Engine) addEventLocked(ev models.MatchEvent) {
+	ev.MatchID = e.match.ID
+	ev.Period = e.state.Period
+	ev.Elapsed = e.currentElapsedLocked()
+	ev.At = e.now()
+	e.events = append(e.events, ev)
+	e.state.EventCount = len(e.events)
+}
+
+func (e *Engine) currentElapsedLocked() time.Duration {
+	if e.state.Running {
+		return e.elapsed + e.now().Sub(e.startedAt)
+	}
+	return e.elapsed
+}
+
+// refreshLocked aktualisiert die abgeleiteten Felder (Uhr, Periode)
+func (e *Engine) refreshLocked() {
+	e.state.Elapsed = e.currentElapsedLocked()
+	e.state.ClockText, e.state.Overtime = FormatClock(e.template, e.state.Period, e.state.Elapsed)
+	e.state.PeriodText = PeriodText(e.template, e.state.Period, e.state.Status)
+	e.state.UpdatedAt = e.now()
+}
+
+// publishLocked verschickt den aktuellen Stand an alle Abonnenten, ohne zu blockieren
+func (e *Engine) publishLocked() {
+	e.refreshLocked()
+	for _, ch := range e.subs {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- e.state
+	}
+}
@@ -0,0 +1,311 @@
+// internal/live/engine_test.go
+
+package live
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// fakeClock ersetzt time.Now der Engine, damit die Spieluhr ohne Warten läuft
+type fakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	c.mu.Lock()
+	c.t = c.t.Add(d)
+	c.mu.Unlock()
+}
+
+func testMatch(id, field int) *models.Match {
+	return &models.Match{
+		ID:      id,
+		FieldID: field,
+		Status:  models.MatchScheduled,
+		Team1:   &models.Team{ID: 1, Name: "Munich Cowboys"},
+		Team2:   &models.Team{ID: 2, Name: "Berlin Adler"},
+	}
+}
+
+func testTemplate() *models.TemplateSettings {
+	return &models.TemplateSettings{
+		ID:             1,
+		PeriodLabel:    "Viertel",
+		PeriodsCount:   4,
+		PeriodDuration: 12,
+		GameclockMode:  models.GameclockUpMMSS,
+	}
+}
+
+func newTestEngine(t *testing.T) (*Engine, *fakeClock) {
+	t.Helper()
+	clock := &fakeClock{t: time.Date(2025, 6, 1, 15, 0, 0, 0, time.UTC)}
+	e := NewEngine(testMatch(1, 1), testTemplate())
+	e.now = clock.Now
+	e.tick = time.Millisecond
+	e.Start()
+	t.Cleanup(e.Stop)
+	return e, clock
+}
+
+func TestEngineTransitions(t *testing.T) {
+	tests := []struct {
+		name    string
+		actions []func(*Engine) error
+		status  string
+		period  int
+		wantErr bool
+	}{
+		{"kickoff", []func(*Engine) error{(*Engine).Kickoff}, models.MatchLive, 1, false},
+		{"pause und nächste Periode", []func(*Engine) error{(*Engine).Kickoff, (*Engine).Halftime, (*Engine).NextPeriod}, models.MatchLive, 2, false},
+		{"beenden", []func(*Engine) error{(*Engine).Kickoff, (*Engine).Finish}, models.MatchFinished, 1, false},
+		{"abbrechen vor Beginn", []func(*Engine) error{(*Engine).Abandon}, models.MatchAbandoned, 1, false},
+		{"beenden ohne Kickoff", []func(*Engine) error{(*Engine).Finish}, models.MatchScheduled, 1, true},
+		{"pause ohne Kickoff", []func(*Engine) error{(*Engine).Halftime}, models.MatchScheduled, 1, true},
+		{"kickoff nach Ende", []func(*Engine) error{(*Engine).Kickoff, (*Engine).Finish, (*Engine).Kickoff}, models.MatchFinished, 1, true},
+		{"pause in letzter Periode", []func(*Engine) error{
+			(*Engine).Kickoff,
+			(*Engine).Halftime, (*Engine).NextPeriod,
+			(*Engine).Halftime, (*Engine).NextPeriod,
+			(*Engine).Halftime, (*Engine).NextPeriod,
+			(*Engine).Halftime,
+		}, models.MatchLive, 4, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, _ := newTestEngine(t)
+			var err error
+			for _, action := range tt.actions {
+				if err = action(e); err != nil {
+					break
+				}
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Fehler = %v, erwartet Fehler: %v", err, tt.wantErr)
+			}
+			st := e.Snapshot()
+			if st.Status != tt.status || st.Period != tt.period {
+				t.Errorf("Status %q, Periode %d; erwartet %q, %d", st.Status, st.Period, tt.status, tt.period)
+			}
+		})
+	}
+}
+
+func TestEngineAddScore(t *testing.T) {
+	tests := []struct {
+		name       string
+		side       string
+		deltas     []int
+		home, away int
+		wantErr    bool
+	}{
+		{"heim", models.SlotHome, []int{6, 1}, 7, 0, false},
+		{"gast", models.SlotAway, []int{3}, 0, 3, false},
+		{"korrektur", models.SlotHome, []int{6, -6}, 0, 0, false},
+		{"negativ", models.SlotAway, []int{-1}, 0, 0, true},
+		{"unbekannte Seite", "mitte", []int{1}, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, _ := newTestEngine(t)
+			var err error
+			for _, d := range tt.deltas {
+				if err = e.AddScore(tt.side, d); err != nil {
+					break
+				}
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Fehler = %v, erwartet Fehler: %v", err, tt.wantErr)
+			}
+			if st := e.Snapshot(); st.HomeScore != tt.home || st.AwayScore != tt.away {
+				t.Errorf("Stand %d:%d, erwartet %d:%d", st.HomeScore, st.AwayScore, tt.home, tt.away)
+			}
+		})
+	}
+
+	t.Run("nach Ende", func(t *testing.T) {
+		e, _ := newTestEngine(t)
+		if err := e.Abandon(); err != nil {
+			t.Fatal(err)
+		}
+		if err := e.AddScore(models.SlotHome, 1); err == nil {
+			t.Error("Punkte nach Spielende wurden angenommen")
+		}
+	})
+}
+
+func TestEngineClock(t *testing.T) {
+	e, clock := newTestEngine(t)
+	if err := e.StartClock(); err == nil {
+		t.Error("Uhr lief vor dem Kickoff")
+	}
+	if err := e.Kickoff(); err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []struct {
+		name    string
+		do      func()
+		want    string
+		running bool
+	}{
+		{"läuft", func() { clock.Add(90 * time.Second) }, "01:30", true},
+		{"angehalten", func() { e.StopClock(); clock.Add(time.Minute) }, "01:30", false},
+		{"gesetzt", func() { _ = e.SetClock(5 * time.Minute) }, "05:00", false},
+		{"weiter", func() { _ = e.StartClock(); clock.Add(10 * time.Second) }, "05:10", true},
+		{"nächste Periode", func() { _ = e.Halftime(); clock.Add(time.Minute); _ = e.NextPeriod() }, "00:00", true},
+	}
+	for _, s := range steps {
+		s.do()
+		st := e.Snapshot()
+		if st.ClockText != s.want || st.Running != s.running {
+			t.Errorf("%s: Uhr %q (läuft %v), erwartet %q (läuft %v)", s.name, st.ClockText, st.Running, s.want, s.running)
+		}
+	}
+	if err := e.SetClock(-time.Second); err == nil {
+		t.Error("negative Spielzeit wurde angenommen")
+	}
+}
+
+func TestEngineSubscribe(t *testing.T) {
+	e, _ := newTestEngine(t)
+	ch, cancel := e.Subscribe()
+	if st := <-ch; st.Status != models.MatchScheduled {
+		t.Fatalf("erster Stand hat Status %q", st.Status)
+	}
+
+	if err := e.AddScore(models.SlotHome, 3); err != nil {
+		t.Fatal(err)
+	}
+	if st := <-ch; st.HomeScore != 3 {
+		t.Errorf("Stand %d, erwartet 3", st.HomeScore)
+	}
+
+	cancel()
+	if _, ok := <-ch; ok {
+		t.Error("Kanal nach cancel nicht geschlossen")
+	}
+	cancel() // zweimal aufrufen ist erlaubt
+
+	e.Stop()
+	if ch, _ := e.Subscribe(); ch != nil {
+		if _, ok := <-ch; ok {
+			t.Error("Abonnement nach Stop liefert noch Stände")
+		}
+	}
+}
+
+func TestEngineFinishWith(t *testing.T) {
+	errSave := errors.New("Datenbank nicht erreichbar")
+	tests := []struct {
+		name    string
+		kickoff bool
+		saveErr error
+		saved   bool
+		status  string
+	}{
+		{"gespeichert", true, nil, true, models.MatchFinished},
+		{"ohne Kickoff", false, nil, false, models.MatchScheduled},
+		{"Speichern fehlgeschlagen", true, errSave, true, models.MatchLive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, _ := newTestEngine(t)
+			if tt.kickoff {
+				if err := e.Kickoff(); err != nil {
+					t.Fatal(err)
+				}
+				_ = e.AddScore(models.SlotAway, 2)
+			}
+			var saved *models.LiveState
+			err := e.FinishWith(func(st models.LiveState) error {
+				saved = &st
+				return tt.saveErr
+			})
+			if (saved != nil) != tt.saved {
+				t.Errorf("save aufgerufen: %v, erwartet %v", saved != nil, tt.saved)
+			}
+			if saved != nil && saved.AwayScore != 2 {
+				t.Errorf("save bekam Stand %d:%d, erwartet 0:2", saved.HomeScore, saved.AwayScore)
+			}
+			if tt.saveErr != nil && !errors.Is(err, tt.saveErr) {
+				t.Errorf("Fehler = %v, erwartet %v", err, tt.saveErr)
+			}
+			if st := e.Snapshot(); st.Status != tt.status {
+				t.Errorf("Status %q, erwartet %q", st.Status, tt.status)
+			}
+		})
+	}
+}
+
+func TestEngineMatchIsCopy(t *testing.T) {
+	e, _ := newTestEngine(t)
+	if err := e.Kickoff(); err != nil {
+		t.Fatal(err)
+	}
+	m := e.Match()
+	m.Status = models.MatchAbandoned
+	if st := e.Snapshot(); st.Status != models.MatchLive {
+		t.Errorf("Änderung an Match() wirkt auf die Engine: %q", st.Status)
+	}
+	if e.Match().Status != models.MatchScheduled {
+		t.Errorf("Match() liefert geänderten Status %q", e.Match().Status)
+	}
+}
+
+// TestEngineConcurrent ist für go test -race gedacht: alle Methoden werden
+// gleichzeitig aus mehreren Goroutinen aufgerufen
+func TestEngineConcurrent(t *testing.T) {
+	e, clock := newTestEngine(t)
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ch, cancel := e.Subscribe()
+			defer cancel()
+			for range 50 {
+				_ = e.Kickoff()
+				_ = e.AddScore(models.SlotHome, 1)
+				_ = e.AddScore(models.SlotAway, 1)
+				clock.Add(time.Second)
+				if i%2 == 0 {
+					e.StopClock()
+				} else {
+					_ = e.StartClock()
+				}
+				_ = e.Snapshot()
+				_ = e.Events()
+				if m := e.Match(); m.ID != 1 || m.Status != models.MatchScheduled {
+					t.Errorf("Match() liefert %d/%q", m.ID, m.Status)
+				}
+				select {
+				case <-ch:
+				default:
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	st := e.Snapshot()
+	if st.HomeScore != 400 || st.AwayScore != 400 {
+		t.Errorf("Stand %d:%d, erwartet 400:400", st.HomeScore, st.AwayScore)
+	}
+	if st.Status != models.MatchLive {
+		t.Errorf("Status %q, erwartet %q", st.Status, models.MatchLive)
+	}
+}
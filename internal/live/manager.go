@@ -0,0 +1,112 @@
+// internal/live/manager.go
+
+package live
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// Manager verwaltet die Live-Spiele aller Felder. Pro Feld läuft höchstens
+// ein Spiel; ad-hoc-Spiele ohne Feld laufen unter der Feld-ID 0.
+type Manager struct {
+	mu      sync.RWMutex
+	engines map[int]*Engine
+}
+
+// NewManager erzeugt einen leeren Manager
+func NewManager() *Manager {
+	return &Manager{engines: map[int]*Engine{}}
+}
+
+// Start startet ein Spiel auf seinem Feld. Läuft dort bereits ein anderes
+// Spiel, wird ein Fehler geliefert.
+func (m *Manager) Start(match *models.Match, template *models.TemplateSettings) (*Engine, error) {
+	if match == nil || template == nil {
+		return nil, i18n.Errorf("error.live_start")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.engines[match.FieldID]; ok {
+		if e.Match().ID == match.ID {
+			return e, nil
+		}
+		return nil, i18n.Errorf("error.field_busy", match.FieldID, e.Match().ID)
+	}
+
+	e := NewEngine(match, template)
+	e.Start()
+	m.engines[match.FieldID] = e
+	return e, nil
+}
+
+// Get liefert das Live-Spiel eines Feldes
+func (m *Manager) Get(fieldID int) (*Engine, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	e, ok := m.engines[fieldID]
+	return e, ok
+}
+
+// ByMatch sucht das Live-Spiel zu einer Spiel-ID
+func (m *Manager) ByMatch(matchID int) (*Engine, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, e := range m.engines {
+		if e.Match().ID == matchID {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+// Stop beendet das Live-Spiel eines Feldes und gibt die Engine zurück
+func (m *Manager) Stop(fieldID int) (*Engine, bool) {
+	m.mu.Lock()
+	e, ok := m.engines[fieldID]
+	delete(m.engines, fieldID)
+	m.mu.Unlock()
+
+	if ok {
+		e.Stop()
+	}
+	return e, ok
+}
+
+// StopAll beendet alle Live-Spiele, z.B. beim Herunterfahren
+func (m *Manager) StopAll() []*Engine {
+	m.mu.Lock()
+	engines := make([]*Engine, 0, len(m.engines))
+	for id, e := range m.engines {
+		engines = append(engines, e)
+		delete(m.engines, id)
+	}
+	m.mu.Unlock()
+
+	for _, e := range engines {
+		e.Stop()
+	}
+	return engines
+}
+
+// List liefert den Stand aller Live-Spiele, nach Feld sortiert
+func (m *Manager) List() []models.LiveState {
+	m.mu.RLock()
+	engines := make([]*Engine, 0, len(m.engines))
+	for _, e := range m.engines {
+		engines = append(engines, e)
+	}
+	m.mu.RUnlock()
+
+	states := make([]models.LiveState, 0, len(engines))
+	for _, e := range engines {
+		states = append(states, e.Snapshot())
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].FieldID < states[j].FieldID })
+	return states
+}
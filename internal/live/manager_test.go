@@ -0,0 +1,120 @@
+// internal/live/manager_test.go
+
+package live
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+func TestManagerStart(t *testing.T) {
+	m := NewManager()
+	t.Cleanup(func() { m.StopAll() })
+
+	first, err := m.Start(testMatch(1, 1), testTemplate())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		match    *models.Match
+		template *models.TemplateSettings
+		same     bool
+		wantErr  bool
+	}{
+		{"gleiches Spiel", testMatch(1, 1), testTemplate(), true, false},
+		{"Feld belegt", testMatch(2, 1), testTemplate(), false, true},
+		{"anderes Feld", testMatch(3, 2), testTemplate(), false, false},
+		{"ad-hoc ohne Feld", testMatch(4, 0), testTemplate(), false, false},
+		{"ohne Template", testMatch(5, 3), nil, false, true},
+		{"ohne Spiel", nil, testTemplate(), false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := m.Start(tt.match, tt.template)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Fehler = %v, erwartet Fehler: %v", err, tt.wantErr)
+			}
+			if (e == first) != tt.same {
+				t.Errorf("gleiche Engine: %v, erwartet %v", e == first, tt.same)
+			}
+		})
+	}
+
+	states := m.List()
+	if len(states) != 3 {
+		t.Fatalf("%d Live-Spiele, erwartet 3", len(states))
+	}
+	for i, field := range []int{0, 1, 2} {
+		if states[i].FieldID != field {
+			t.Errorf("List()[%d] ist Feld %d, erwartet %d", i, states[i].FieldID, field)
+		}
+	}
+
+	if e, ok := m.ByMatch(3); !ok || e.Match().FieldID != 2 {
+		t.Error("ByMatch findet Spiel 3 nicht")
+	}
+	if _, ok := m.Stop(1); !ok {
+		t.Error("Stop(1) findet kein Spiel")
+	}
+	if _, ok := m.Get(1); ok {
+		t.Error("Feld 1 nach Stop noch belegt")
+	}
+	if _, err := m.Start(testMatch(2, 1), testTemplate()); err != nil {
+		t.Errorf("Feld 1 nach Stop nicht frei: %v", err)
+	}
+}
+
+// TestManagerConcurrent ist für go test -race gedacht: Spiele werden
+// gleichzeitig gestartet, gesteuert, abonniert, gelistet und beendet
+func TestManagerConcurrent(t *testing.T) {
+	m := NewManager()
+
+	var wg sync.WaitGroup
+	for field := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 20 {
+				e, err := m.Start(testMatch(field*100+i, field), testTemplate())
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				ch, cancel := e.Subscribe()
+				_ = e.Kickoff()
+				_ = e.AddScore(models.SlotHome, 1)
+				<-ch
+				_ = m.List()
+				if _, ok := m.ByMatch(e.Match().ID); !ok {
+					t.Errorf("Spiel %d nicht gefunden", e.Match().ID)
+				}
+				cancel()
+				m.Stop(field)
+			}
+		}()
+	}
+
+	// gleichzeitig lesen und abonnieren
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 200 {
+			for _, st := range m.List() {
+				if e, ok := m.Get(st.FieldID); ok {
+					_, cancel := e.Subscribe()
+					cancel()
+				}
+			}
+		}
+	}()
+
+	wg.Wait()
+	<-done
+	if n := len(m.StopAll()); n != 0 {
+		t.Errorf("%d Spiele nach dem Test noch live", n)
+	}
+}
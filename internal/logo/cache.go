@@ -0,0 +1,119 @@
+// internal/logo/cache.go
+
+package logo
+
+import (
+	"bytes"
+	"container/list"
+	"image"
+	"log"
+	"sync"
+
+	xdraw "golang.org/x/image/draw"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+)
+
+// CacheSize begrenzt den Speicher für dekodierte und skalierte Logos. Der
+// Cache ist gemeinsam für Overlay, Videoausgabe und Vorschaubilder; Einträge,
+// die am längsten nicht benutzt wurden, fallen zuerst heraus.
+const CacheSize = 64 << 20
+
+// cacheKey: ein dekodiertes Logo (Hash, 0×0) oder eine skalierte Fassung
+// davon (Hash, Breite × Höhe)
+type cacheKey struct {
+	hash string
+	w, h int
+}
+
+type cacheEntry struct {
+	key  cacheKey
+	img  image.Image
+	size int
+}
+
+var cache = struct {
+	sync.Mutex
+	order *list.List // vorne: zuletzt benutzt
+	items map[cacheKey]*list.Element
+	size  int
+}{order: list.New(), items: map[cacheKey]*list.Element{}}
+
+func cacheGet(key cacheKey) (image.Image, bool) {
+	cache.Lock()
+	defer cache.Unlock()
+	el, ok := cache.items[key]
+	if !ok {
+		return nil, false
+	}
+	cache.order.MoveToFront(el)
+	return el.Value.(*cacheEntry).img, true
+}
+
+func cachePut(key cacheKey, img image.Image) {
+	b := img.Bounds()
+	size := b.Dx() * b.Dy() * 4
+
+	cache.Lock()
+	defer cache.Unlock()
+	if el, ok := cache.items[key]; ok {
+		cache.order.MoveToFront(el)
+		return
+	}
+	cache.items[key] = cache.order.PushFront(&cacheEntry{key, img, size})
+	cache.size += size
+	for cache.size > CacheSize && cache.order.Len() > 1 {
+		el := cache.order.Back()
+		e := el.Value.(*cacheEntry)
+		cache.order.Remove(el)
+		delete(cache.items, e.key)
+		cache.size -= e.size
+	}
+}
+
+// Image liefert das dekodierte Logo zum Hash aus der Datenbank, nil bei
+// leerem Hash oder unlesbarer Datei. Da der Hash den Inhalt bestimmt, ist
+// ein Eintrag nie veraltet.
+func Image(hash string) image.Image {
+	if hash == "" {
+		return nil
+	}
+	key := cacheKey{hash: hash}
+	if img, ok := cacheGet(key); ok {
+		return img
+	}
+	data, err := database.LoadLogo(hash)
+	if err != nil {
+		log.Printf("logo: %v", err)
+		return nil
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		log.Printf("logo %s: %v", hash, err)
+		return nil
+	}
+	cachePut(key, img)
+	return img
+}
+
+// Scaled liefert src auf w×h skaliert; hash ist der Inhalts-Hash von src
+// (siehe database.LogoHash). So wird jedes Logo pro Zielgröße nur einmal
+// skaliert statt in jedem Frame. Ohne hash wird nicht zwischengespeichert.
+func Scaled(hash string, src image.Image, w, h int) image.Image {
+	b := src.Bounds()
+	if w == b.Dx() && h == b.Dy() {
+		return src
+	}
+	key := cacheKey{hash: hash, w: w, h: h}
+	if hash != "" {
+		if img, ok := cacheGet(key); ok {
+			return img
+		}
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), src, b, xdraw.Src, nil)
+	if hash != "" {
+		cachePut(key, dst)
+	}
+	return dst
+}
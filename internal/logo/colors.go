@@ -0,0 +1,111 @@
+// internal/logo/colors.go
+
+package logo
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"sort"
+)
+
+// Grenzen der Farbanalyse
+const (
+	minAlpha       = 0x80 // halbtransparente Kantenpixel zählen nicht
+	nearWhite      = 0xE6 // alle Kanäle darüber: Hintergrund bzw. Papierweiß
+	nearBlack      = 0x1E // alle Kanäle darunter: Konturen
+	minColorDist   = 80   // Mindestabstand der Zweitfarbe zur Hauptfarbe (RGB)
+	colorBucketBit = 4    // Bits je Kanal beim Zusammenfassen ähnlicher Farben
+)
+
+type bucket struct {
+	n       int
+	r, g, b int // Summen für den Mittelwert
+}
+
+func (b bucket) color() color.NRGBA {
+	return color.NRGBA{uint8(b.r / b.n), uint8(b.g / b.n), uint8(b.b / b.n), 0xFF}
+}
+
+// Colors ermittelt Haupt- und Zweitfarbe eines Logos als "#RRGGBB".
+// Transparente sowie nahezu weiße oder schwarze Pixel werden ignoriert,
+// außer das Logo besteht nur daraus. Die Zweitfarbe muss sich deutlich von
+// der Hauptfarbe unterscheiden und ist sonst leer.
+func Colors(img image.Image) (primary, secondary string) {
+	if img == nil {
+		return "", ""
+	}
+	buckets := count(img, true)
+	if len(buckets) == 0 {
+		buckets = count(img, false)
+	}
+	if len(buckets) == 0 {
+		return "", ""
+	}
+	// bei Gleichstand nach Farbwert, damit das Ergebnis stabil ist
+	sort.Slice(buckets, func(i, j int) bool {
+		if buckets[i].n != buckets[j].n {
+			return buckets[i].n > buckets[j].n
+		}
+		return hex(buckets[i].color()) < hex(buckets[j].color())
+	})
+
+	first := buckets[0].color()
+	primary = hex(first)
+	for _, b := range buckets[1:] {
+		if c := b.color(); distanceSq(first, c) >= minColorDist*minColorDist {
+			return primary, hex(c)
+		}
+	}
+	return primary, ""
+}
+
+// count fasst die deckenden Pixel zu Farbgruppen zusammen
+func count(img image.Image, skipNeutral bool) []bucket {
+	byKey := map[uint32]*bucket{}
+	bounds := img.Bounds()
+	shift := 8 - colorBucketBit
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if c.A < minAlpha {
+				continue
+			}
+			if skipNeutral && (isNear(c, nearWhite, true) || isNear(c, nearBlack, false)) {
+				continue
+			}
+			key := uint32(c.R>>shift)<<16 | uint32(c.G>>shift)<<8 | uint32(c.B>>shift)
+			b := byKey[key]
+			if b == nil {
+				b = &bucket{}
+				byKey[key] = b
+			}
+			b.n++
+			b.r += int(c.R)
+			b.g += int(c.G)
+			b.b += int(c.B)
+		}
+	}
+	buckets := make([]bucket, 0, len(byKey))
+	for _, b := range byKey {
+		buckets = append(buckets, *b)
+	}
+	return buckets
+}
+
+func isNear(c color.NRGBA, limit uint8, above bool) bool {
+	if above {
+		return c.R > limit && c.G > limit && c.B > limit
+	}
+	return c.R < limit && c.G < limit && c.B < limit
+}
+
+// distanceSq ist der quadrierte Abstand im RGB-Raum
+func distanceSq(a, b color.NRGBA) int {
+	dr, dg, db := int(a.R)-int(b.R), int(a.G)-int(b.G), int(a.B)-int(b.B)
+	return dr*dr + dg*dg + db*db
+}
+
+func hex(c color.NRGBA) string {
+	return fmt.Sprintf("#%02X%02X%02X", c.R, c.G, c.B)
+}
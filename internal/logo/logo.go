@@ -0,0 +1,241 @@
+// internal/logo/logo.go
+//
+// Einlesen von Teamlogos: PNG, JPEG, GIF, WebP, BMP und SVG werden geprüft,
+// um transparente Ränder gekürzt und als PNG in einheitlicher Höchstgröße
+// abgelegt. Das Original bleibt zusätzlich erhalten, z.B. für einen späteren
+// Neuaufbau mit anderer Größe.
+
+package logo
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"regexp"
+	"strings"
+
+	"github.com/srwiley/oksvg"
+	"github.com/srwiley/rasterx"
+	_ "golang.org/x/image/bmp"
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+)
+
+// Grenzen für hochgeladene Dateien
+const (
+	MaxFileSize  = 10 << 20   // Bytes der Originaldatei
+	MaxDimension = 8192       // Pixel je Kante vor dem Skalieren
+	MaxPixels    = 25_000_000 // Breite × Höhe, schützt vor Dekompressionsbomben
+	MaxSize      = 512        // längste Kante des normalisierten Logos
+)
+
+// Unterstützte Formate, wie sie image.DecodeConfig meldet
+const (
+	FormatPNG  = "png"
+	FormatJPEG = "jpeg"
+	FormatGIF  = "gif"
+	FormatWebP = "webp"
+	FormatBMP  = "bmp"
+	FormatSVG  = "svg"
+)
+
+// Formats liefert die unterstützten Formate, z.B. für Hilfetexte
+func Formats() []string {
+	return []string{FormatPNG, FormatJPEG, FormatGIF, FormatWebP, FormatBMP, FormatSVG}
+}
+
+// Extensions liefert die Dateiendungen der unterstützten Formate
+func Extensions() []string {
+	return []string{".png", ".jpg", ".jpeg", ".gif", ".webp", ".bmp", ".svg"}
+}
+
+// Logo ist ein eingelesenes Logo
+type Logo struct {
+	Format     string // Format des Originals
+	Original   []byte
+	Normalized []byte // PNG ohne transparente Ränder, längste Kante höchstens MaxSize
+	Width      int    // Größe von Normalized
+	Height     int
+
+	PrimaryColor   string // vorherrschende Farben, siehe Colors
+	SecondaryColor string
+}
+
+// Ingest prüft eine Logodatei und erzeugt die normalisierte Fassung
+func Ingest(data []byte) (*Logo, error) {
+	if len(data) == 0 {
+		return nil, i18n.Errorf("error.logo.empty")
+	}
+	if len(data) > MaxFileSize {
+		return nil, i18n.Errorf("error.logo.file_size",
+			float64(len(data))/(1<<20), MaxFileSize>>20)
+	}
+
+	var (
+		img    image.Image
+		format string
+		err    error
+	)
+	if isSVG(data) {
+		format = FormatSVG
+		img, err = rasterizeSVG(data)
+	} else {
+		img, format, err = decodeRaster(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	img, err = trim(img)
+	if err != nil {
+		return nil, err
+	}
+	img = fit(img, MaxSize)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	b := img.Bounds()
+	l := &Logo{Format: format, Original: data, Normalized: buf.Bytes(), Width: b.Dx(), Height: b.Dy()}
+	l.PrimaryColor, l.SecondaryColor = Colors(img)
+	return l, nil
+}
+
+// decodeRaster prüft Format und Abmessungen, bevor die Pixel dekodiert werden
+func decodeRaster(data []byte) (image.Image, string, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", i18n.Errorf("error.logo.format", strings.Join(Formats(), ", "))
+	}
+	if err := checkSize(cfg.Width, cfg.Height); err != nil {
+		return nil, "", err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", i18n.Errorf("error.logo.corrupt", format, err)
+	}
+	return img, format, nil
+}
+
+func checkSize(w, h int) error {
+	if w <= 0 || h <= 0 {
+		return i18n.Errorf("error.logo.dimensions", w, h)
+	}
+	if w > MaxDimension || h > MaxDimension || w*h > MaxPixels {
+		return i18n.Errorf("error.logo.too_large",
+			w, h, MaxDimension, MaxPixels/1_000_000)
+	}
+	return nil
+}
+
+// isSVG erkennt SVG am Anfang der Datei; ein XML-Kopf oder Kommentar darf vorangehen
+func isSVG(data []byte) bool {
+	head := bytes.TrimLeft(bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF")), " \t\r\n")
+	if !bytes.HasPrefix(head, []byte("<")) {
+		return false
+	}
+	if len(head) > 4096 {
+		head = head[:4096]
+	}
+	return bytes.Contains(bytes.ToLower(head), []byte("<svg"))
+}
+
+// In SVG-Dateien abgelehnte Inhalte: Skripte, Entitäten (XXE, "Billion
+// Laughs") und Verweise auf fremde Dateien. Das Original wird auch im
+// Overlay ausgeliefert, daher reicht es nicht, sie beim Rastern zu ignorieren.
+var svgForbidden = []struct {
+	re  *regexp.Regexp
+	why string // Schlüssel des Grundes
+}{
+	{regexp.MustCompile(`(?i)<!(doctype|entity)`), "error.logo.svg_dtd"},
+	{regexp.MustCompile(`(?i)<\s*(script|foreignobject|iframe|embed|object)\b`), "error.logo.svg_script"},
+	{regexp.MustCompile(`(?i)\son[a-z]+\s*=`), "error.logo.svg_handler"},
+	{regexp.MustCompile(`(?i)javascript:`), "error.logo.svg_javascript"},
+	{regexp.MustCompile(`(?i)href\s*=\s*["']\s*[^"'#\s]`), "error.logo.svg_external"},
+	{regexp.MustCompile(`(?i)url\(\s*["']?\s*[^"')#\s]`), "error.logo.svg_external"},
+}
+
+// rasterizeSVG zeichnet ein SVG in MaxSize, Seitenverhältnis laut viewBox
+func rasterizeSVG(data []byte) (img image.Image, err error) {
+	for _, f := range svgForbidden {
+		if f.re.Match(data) {
+			return nil, i18n.Errorf("error.logo.svg_forbidden", i18n.M(f.why))
+		}
+	}
+
+	// oksvg ist bei kaputten Dateien nicht immer robust
+	defer func() {
+		if r := recover(); r != nil {
+			img, err = nil, i18n.Errorf("error.logo.svg_corrupt", r)
+		}
+	}()
+
+	icon, err := oksvg.ReadIconStream(bytes.NewReader(data), oksvg.IgnoreErrorMode)
+	if err != nil {
+		return nil, i18n.Errorf("error.logo.svg_corrupt", err)
+	}
+	vw, vh := icon.ViewBox.W, icon.ViewBox.H
+	if vw <= 0 || vh <= 0 {
+		return nil, i18n.Errorf("error.logo.svg_size")
+	}
+	w, h := MaxSize, MaxSize
+	if vw > vh {
+		h = max(int(float64(MaxSize)*vh/vw+0.5), 1)
+	} else {
+		w = max(int(float64(MaxSize)*vw/vh+0.5), 1)
+	}
+
+	rgba := image.NewRGBA(image.Rect(0, 0, w, h))
+	icon.SetTarget(0, 0, float64(w), float64(h))
+	icon.Draw(rasterx.NewDasher(w, h, rasterx.NewScannerGV(w, h, rgba, rgba.Bounds())), 1)
+	return rgba, nil
+}
+
+// trim entfernt vollständig transparente Ränder
+func trim(img image.Image) (image.Image, error) {
+	b := img.Bounds()
+	box := image.Rectangle{}
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if _, _, _, a := img.At(x, y).RGBA(); a != 0 {
+				box = box.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	if box.Empty() {
+		return nil, i18n.Errorf("error.logo.transparent")
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, box.Dx(), box.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, box.Min, draw.Src)
+	return dst, nil
+}
+
+// fit verkleinert ein Bild unter Beibehaltung des Seitenverhältnisses, bis
+// die längste Kante höchstens size Pixel misst; kleinere bleiben unverändert
+func fit(img image.Image, size int) image.Image {
+	b := img.Bounds()
+	w, h := Fit(b.Dx(), b.Dy(), size)
+	if w == b.Dx() && h == b.Dy() {
+		return img
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, b, xdraw.Src, nil)
+	return dst
+}
+
+// Fit rechnet w×h so um, dass die längste Kante höchstens size misst
+func Fit(w, h, size int) (int, int) {
+	if w <= size && h <= size {
+		return w, h
+	}
+	if w >= h {
+		return size, max(h*size/w, 1)
+	}
+	return max(w*size/h, 1), size
+}
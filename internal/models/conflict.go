@@ -0,0 +1,53 @@
+// internal/models/conflict.go
+
+package models
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+)
+
+// Konfliktauflösung beim Import von Datensicherungen und Template-Paketen,
+// wenn es einen Eintrag gleichen Namens schon gibt
+const (
+	ConflictSkip    = "skip"    // vorhandenen Eintrag behalten und weiterverwenden
+	ConflictReplace = "replace" // vorhandenen Eintrag überschreiben
+	ConflictRename  = "rename"  // zusätzlich anlegen, als "Name (2)"
+	ConflictFail    = "fail"    // abbrechen
+)
+
+// ConflictPolicies liefert alle Varianten der Konfliktauflösung
+func ConflictPolicies() []string {
+	return []string{ConflictSkip, ConflictReplace, ConflictRename, ConflictFail}
+}
+
+// ValidateConflictPolicy prüft, ob policy eine bekannte Konfliktauflösung ist
+func ValidateConflictPolicy(policy string) error {
+	if !slices.Contains(ConflictPolicies(), policy) {
+		return i18n.Errorf("error.conflict_policy", policy, strings.Join(ConflictPolicies(), ", "))
+	}
+	return nil
+}
+
+// UniqueName hängt " (2)", " (3)", ... an, bis taken den Namen nicht mehr
+// kennt; bei Dateinamen wie "logo.ttf" vor der Endung
+func UniqueName(name string, taken func(string) bool) string {
+	if !taken(name) {
+		return name
+	}
+	ext := ""
+	if strings.Contains(name, ".") && !strings.Contains(name, " ") {
+		ext = filepath.Ext(name)
+	}
+	base := strings.TrimSuffix(name, ext)
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s (%d)%s", base, i, ext)
+		if !taken(candidate) {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// internal/models/inheritance.go
+
+package models
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+)
+
+// Felder, die nie vererbt werden
+var ownFields = []string{"ID", "Name", "ParentID", "Overrides", "Theme", "Assets"}
+
+// InheritableFields liefert die Felder, die ein Template vom Eltern-Template
+// erben bzw. über Overrides überschreiben kann
+func InheritableFields() []string {
+	var fields []string
+	typ := reflect.TypeOf(TemplateSettings{})
+	for i := 0; i < typ.NumField(); i++ {
+		if name := typ.Field(i).Name; !slices.Contains(ownFields, name) {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// Overridden gibt an, ob das Template das Feld selbst festlegt
+func (t *TemplateSettings) Overridden(field string) bool {
+	return t.ParentID == 0 || slices.Contains(t.Overrides, field)
+}
+
+// Override markiert ein Feld als überschrieben; ohne Eltern-Template ist
+// ohnehin jedes Feld eigen
+func (t *TemplateSettings) Override(field string) {
+	if t.ParentID != 0 && !slices.Contains(t.Overrides, field) {
+		t.Overrides = append(t.Overrides, field)
+	}
+}
+
+// Inherit liefert eine Kopie von t, in der alle nicht überschriebenen Felder
+// aus parent stammen. Theme-Variablen und Dateien werden zusammengeführt,
+// bei gleichem Namen gewinnt das abgeleitete Template.
+func (t *TemplateSettings) Inherit(parent *TemplateSettings) *TemplateSettings {
+	out := *t
+	src := reflect.ValueOf(parent).Elem()
+	dst := reflect.ValueOf(&out).Elem()
+	for _, field := range InheritableFields() {
+		if !t.Overridden(field) {
+			dst.FieldByName(field).Set(src.FieldByName(field))
+		}
+	}
+
+	out.Theme = maps.Clone(parent.Theme)
+	if out.Theme == nil {
+		out.Theme = map[string]string{}
+	}
+	maps.Copy(out.Theme, t.Theme)
+
+	out.Assets = slices.Clone(t.Assets)
+	for _, a := range parent.Assets {
+		if out.Asset(a.Name) == nil {
+			out.Assets = append(out.Assets, a)
+		}
+	}
+	return &out
+}
+
+// ThemeRef liefert den Variablennamen, wenn s auf eine Theme-Variable verweist
+func ThemeRef(s string) (string, bool) {
+	name, ok := strings.CutPrefix(s, "$")
+	return name, ok && name != ""
+}
+
+// Theme-Variablen, die je Spiel aus den Teamfarben gefüllt werden, z.B.
+// "$home_primary" als Hintergrund eines Elements
+const (
+	ThemeHomePrimary   = "home_primary"
+	ThemeHomeSecondary = "home_secondary"
+	ThemeAwayPrimary   = "away_primary"
+	ThemeAwaySecondary = "away_secondary"
+)
+
+// teamThemeDefaults gelten, wenn weder Team noch Template die Farbe vorgeben
+var teamThemeDefaults = map[string]string{
+	ThemeHomePrimary:   "#FFFFFF",
+	ThemeHomeSecondary: "#000000",
+	ThemeAwayPrimary:   "#FFFFFF",
+	ThemeAwaySecondary: "#000000",
+}
+
+// TeamThemeVars liefert die Namen der Teamfarb-Variablen
+func TeamThemeVars() []string {
+	return []string{ThemeHomePrimary, ThemeHomeSecondary, ThemeAwayPrimary, ThemeAwaySecondary}
+}
+
+// SetTeamColors belegt die Teamfarb-Variablen für ein Spiel. Fehlt einem
+// Team eine Farbe, bleibt der Wert aus dem Theme des Templates bzw. der
+// Standard stehen.
+func (t *TemplateSettings) SetTeamColors(home, away *Team) {
+	t.Theme = maps.Clone(t.Theme)
+	if t.Theme == nil {
+		t.Theme = map[string]string{}
+	}
+	for _, c := range []struct {
+		team      *Team
+		primary   string
+		secondary string
+	}{{home, ThemeHomePrimary, ThemeHomeSecondary}, {away, ThemeAwayPrimary, ThemeAwaySecondary}} {
+		if c.team == nil {
+			continue
+		}
+		if c.team.PrimaryColor != "" {
+			t.Theme[c.primary] = c.team.PrimaryColor
+		}
+		if c.team.SecondaryColor != "" {
+			t.Theme[c.secondary] = c.team.SecondaryColor
+		}
+	}
+}
+
+// ApplyTheme ersetzt Verweise wie "$primary" in allen Farbfeldern und
+// Layout-Elementen durch den Wert der Theme-Variable
+func (t *TemplateSettings) ApplyTheme() error {
+	resolve := func(s *string) error {
+		name, ok := ThemeRef(*s)
+		if !ok {
+			return nil
+		}
+		v, ok := t.Theme[name]
+		if !ok {
+			v, ok = teamThemeDefaults[name]
+		}
+		if !ok {
+			return i18n.Errorf("error.theme_variable", name)
+		}
+		*s = v
+		return nil
+	}
+
+	v := reflect.ValueOf(t).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if name := v.Type().Field(i).Name; strings.HasSuffix(name, "Color") {
+			if err := resolve(v.Field(i).Addr().Interface().(*string)); err != nil {
+				return i18n.Errorf("error.wrap.named", name, err)
+			}
+		}
+	}
+
+	// Elemente gehören ggf. dem Eltern-Template und werden daher kopiert
+	elements := make([]*LayoutElement, len(t.Elements))
+	for i, e := range t.Elements {
+		c := *e
+		if err := resolve(&c.Color); err != nil {
+			return i18n.Errorf("error.wrap.element", i+1, c.Type, err)
+		}
+		elements[i] = &c
+	}
+	if t.Elements != nil {
+		t.Elements = elements
+	}
+	return nil
+}
+
+// ApplyEdits übernimmt alle Felder, die in edited von shown (dem angezeigten,
+// aufgelösten Stand) abweichen, und markiert sie als überschrieben.
+// Unveränderte Felder behalten ihren gespeicherten Wert, also auch
+// Verweise auf Theme-Variablen und die Vererbung.
+func (t *TemplateSettings) ApplyEdits(edited, shown *TemplateSettings) {
+	dst := reflect.ValueOf(t).Elem()
+	e := reflect.ValueOf(edited).Elem()
+	s := reflect.ValueOf(shown).Elem()
+	for _, field := range append(InheritableFields(), "Name") {
+		if reflect.DeepEqual(e.FieldByName(field).Interface(), s.FieldByName(field).Interface()) {
+			continue
+		}
+		dst.FieldByName(field).Set(e.FieldByName(field))
+		if field != "Name" {
+			t.Override(field)
+		}
+	}
+}
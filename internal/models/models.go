@@ -1,7 +1,10 @@
 package models
 
 import (
+	"strings"
 	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
 )
 
 // TemplateSettings speichert die globalen Anzeigeoptionen
@@ -34,14 +37,171 @@ type TemplateSettings struct {
 	ExtraTimeFontColor  string
 	Name                string
 	BackgroundFontColor string
+
+	// Freies Layout; leer bedeutet das klassische Layout (Uhr, Spielstand, Periode)
+	Elements []*LayoutElement
+
+	// Mitgelieferte Schriften und Bilder, z.B. aus einem importierten Paket
+	Assets []*TemplateAsset
+
+	// Vererbung: ohne Eintrag in Overrides gilt der Wert des Eltern-Templates
+	ParentID  int               // 0 = eigenständiges Template
+	Overrides []string          // überschriebene Felder, z.B. "ScoreFontColor"
+	Theme     map[string]string // Theme-Variablen, z.B. "primary" → "#004B87"; Farben verweisen mit "$primary"
+}
+
+// TemplateAsset ist eine Schrift- oder Bilddatei, die zu einem Template gehört
+type TemplateAsset struct {
+	ID         int
+	TemplateID int
+	Name       string // Dateiname, eindeutig je Template, z.B. "vereinslogo.png"
+	Kind       string // AssetFont oder AssetImage
+	Data       []byte
+}
+
+// Arten von Template-Dateien
+const (
+	AssetFont  = "font"
+	AssetImage = "image"
+)
+
+// Font ist eine in der Datenbank hinterlegte Schriftdatei, die allen
+// Templates zur Verfügung steht (siehe package fonts)
+type Font struct {
+	ID   int
+	Name string // Dateiname, eindeutig, z.B. "DS-DIGI.TTF"
+	Data []byte
+}
+
+// Asset sucht eine Datei des Templates anhand ihres Namens
+func (t *TemplateSettings) Asset(name string) *TemplateAsset {
+	for _, a := range t.Assets {
+		if strings.EqualFold(a.Name, name) {
+			return a
+		}
+	}
+	return nil
+}
+
+// LayoutElement ist ein frei positioniertes Element eines Templates.
+// Koordinaten sind Pixel relativ zur linken oberen Ecke des Scoreboards.
+type LayoutElement struct {
+	ID         int
+	TemplateID int
+	Type       string // ElementClock, ElementScoreHome, ...
+	X          int
+	Y          int
+	Width      int
+	Height     int
+	Align      string // AlignLeft, AlignCenter oder AlignRight
+	FontFamily string
+	FontSize   int
+	Color      string // Text- bzw. Füllfarbe, z.B. "#FFFFFF"
+	Visibility string // VisibleAlways, VisibleLive, ...
+	Text       string // nur bei ElementText
+	Asset      string // nur bei ElementImage: Name der Bilddatei im Template
+}
+
+// Elementtypen des freien Layouts
+const (
+	ElementText      = "text"
+	ElementRect      = "rect"
+	ElementClock     = "clock"
+	ElementPeriod    = "period"
+	ElementScoreHome = "score_home"
+	ElementScoreAway = "score_away"
+	ElementSeparator = "separator"
+	ElementHomeName  = "home_name"
+	ElementAwayName  = "away_name"
+	ElementHomeLogo  = "home_logo"
+	ElementAwayLogo  = "away_logo"
+	ElementImage     = "image"
+)
+
+// ElementTypes liefert alle Elementtypen
+func ElementTypes() []string {
+	return []string{ElementText, ElementRect, ElementClock, ElementPeriod, ElementScoreHome, ElementScoreAway,
+		ElementSeparator, ElementHomeName, ElementAwayName, ElementHomeLogo, ElementAwayLogo, ElementImage}
+}
+
+// Ausrichtung von Texten innerhalb des Elements
+const (
+	AlignLeft   = "left"
+	AlignCenter = "center"
+	AlignRight  = "right"
+)
+
+// Sichtbarkeitsregeln der Layout-Elemente
+const (
+	VisibleAlways      = "always"
+	VisibleGameclock   = "gameclock"    // wie ShowGameclock bzw. ShowClock
+	VisiblePeriod      = "period"       // wie ShowPeriod
+	VisibleLive        = "live"         // Spiel läuft oder ist in der Pause
+	VisibleHalftime    = "halftime"     // nur in der Pause
+	VisibleFinished    = "finished"     // nur nach Spielende
+	VisibleOvertime    = "overtime"     // nur in der Nachspielzeit
+	VisibleNotOvertime = "not_overtime" // außer in der Nachspielzeit
+)
+
+// Visibilities liefert alle Sichtbarkeitsregeln
+func Visibilities() []string {
+	return []string{VisibleAlways, VisibleGameclock, VisiblePeriod, VisibleLive, VisibleHalftime,
+		VisibleFinished, VisibleOvertime, VisibleNotOvertime}
 }
 
+// Visible wertet die Sichtbarkeitsregel für Template und Spielstand aus
+func (e *LayoutElement) Visible(t *TemplateSettings, s LiveState) bool {
+	switch e.Visibility {
+	case "", VisibleAlways:
+		return true
+	case VisibleGameclock:
+		return t.ShowGameclock || t.ShowClock
+	case VisiblePeriod:
+		return t.ShowPeriod
+	case VisibleLive:
+		return s.Status == MatchLive || s.Status == MatchHalftime
+	case VisibleHalftime:
+		return s.Status == MatchHalftime
+	case VisibleFinished:
+		return s.Status == MatchFinished
+	case VisibleOvertime:
+		return s.Overtime
+	case VisibleNotOvertime:
+		return !s.Overtime
+	}
+	return false
+}
+
+// Gameclock-Modi, wie sie im Template gespeichert werden
+const (
+	GameclockUpMMSS    = "Aufwärts (MM:SS)"
+	GameclockUpMinutes = "Aufwärts (Fußball-Minuten)"
+	GameclockDownMMSS  = "Abwärts (MM:SS)"
+)
+
 // Team speichert Infos zu einem Team
 type Team struct {
 	ID       int
 	Name     string
 	Sportart string
-	LogoData []byte // Pfad zum Logo
+	LogoHash string // Verweis auf das normalisierte Logo (PNG), siehe package logo
+
+	LogoOriginalHash string // Verweis auf die hochgeladene Datei im Originalformat
+
+	// Namensvarianten für schmale Anzeigen, siehe NameVariants
+	ShortName    string            // z.B. "Cowboys"
+	Abbreviation string            // Kürzel, z.B. "MUC"
+	AltNames     map[string]string // abweichender Name je Wettbewerb
+
+	// Teamfarben "#RRGGBB", beim Hochladen aus dem Logo ermittelt und
+	// danach frei änderbar; in Templates als "$home_primary" usw.
+	PrimaryColor   string
+	SecondaryColor string
+
+	// Logodaten werden nicht mitgeladen (siehe database.LoadLogo); gesetzt
+	// ersetzen sie beim Speichern das Logo, der Export füllt sie aus
+	LogoData     []byte
+	LogoOriginal []byte
 }
 
 // SportartDefinition speichert Perioden- und Zeitregeln je Sportart
@@ -53,6 +213,49 @@ type SportartDefinition struct {
 	PeriodDuration int
 	ClockFormat    string // "MM:SS" oder "Minuten"
 	ClockDirection string // "Up" oder "Down"
+	PointsWin      int
+	PointsDraw     int
+	PointsLoss     int
+	RankingMode    string // RankingPoints oder RankingWinPercentage
+	TieBreakers    string // kommagetrennt, z.B. "head_to_head,goal_difference,goals_for"
+}
+
+// Wertungsarten für die Tabelle
+const (
+	RankingPoints        = "points"
+	RankingWinPercentage = "win_percentage"
+)
+
+// Tie-Breaker bei Punkt- bzw. Quotengleichheit
+const (
+	TieBreakHeadToHead     = "head_to_head"
+	TieBreakGoalDifference = "goal_difference"
+	TieBreakGoalsFor       = "goals_for"
+)
+
+// StandingsRules liefert die Tabellenregeln der Sportart
+func (s *SportartDefinition) StandingsRules() StandingsRules {
+	rules := StandingsRules{
+		PointsWin:   s.PointsWin,
+		PointsDraw:  s.PointsDraw,
+		PointsLoss:  s.PointsLoss,
+		RankingMode: s.RankingMode,
+	}
+	for _, tb := range strings.Split(s.TieBreakers, ",") {
+		if tb = strings.TrimSpace(tb); tb != "" {
+			rules.TieBreakers = append(rules.TieBreakers, tb)
+		}
+	}
+	return rules
+}
+
+// StandingsRules beschreibt, wie eine Tabelle berechnet und sortiert wird
+type StandingsRules struct {
+	PointsWin   int
+	PointsDraw  int
+	PointsLoss  int
+	RankingMode string
+	TieBreakers []string
 }
 
 type Match struct {
@@ -62,4 +265,188 @@ type Match struct {
 	TemplateSettings *TemplateSettings
 	GameTime         time.Time
 	Sportart         string
+	Competition      string
+	Status           string // MatchScheduled, MatchLive, ...
+	ScoreHome        *int   // Endstand, nil solange kein Ergebnis vorliegt
+	ScoreAway        *int
+
+	// Turnierdaten, bei normalen Ligaspielen leer
+	Round              int
+	Group              string // Gruppe im Round Robin, z.B. "A"
+	Bracket            string // BracketWinners, BracketLosers oder BracketFinal
+	Field              string // Anzeigename des Spielfelds
+	FieldID            int    // 0, wenn das Spiel keinem Feld zugeordnet ist
+	NextMatchID        int    // Spiel, in das der Sieger einzieht
+	NextMatchSlot      string // SlotHome oder SlotAway
+	LoserNextMatchID   int    // nur Double Elimination
+	LoserNextMatchSlot string
+
+	ICalUID string // Kennung im Kalender: aus dem Import oder beim Anlegen vergeben
+}
+
+// Venue ist ein Spielort mit einem oder mehreren Feldern
+type Venue struct {
+	ID      int
+	Name    string
+	Address string
+	Fields  []*Field
+}
+
+// Field ist ein Spielfeld mit eigener Anzeige. Das Template legt über
+// X/Y/Width/Height fest, auf welchem Ausgang das Scoreboard erscheint.
+type Field struct {
+	ID         int
+	VenueID    int
+	Name       string
+	TemplateID int
+	VenueName  string // nur beim Laden befüllt
+}
+
+// Bracket-Bezeichnungen für K.-o.-Turniere
+const (
+	BracketWinners = "winners"
+	BracketLosers  = "losers"
+	BracketFinal   = "final"
+)
+
+// Position eines Teams im Folgespiel
+const (
+	SlotHome = "home"
+	SlotAway = "away"
+)
+
+// Lebenszyklus eines Spiels
+const (
+	MatchScheduled = "scheduled"
+	MatchLive      = "live"
+	MatchHalftime  = "halftime"
+	MatchFinished  = "finished"
+	MatchPostponed = "postponed"
+	MatchAbandoned = "abandoned"
+)
+
+// matchTransitions listet die erlaubten Statuswechsel; beendete und
+// abgebrochene Spiele sind Endzustände
+var matchTransitions = map[string][]string{
+	MatchScheduled: {MatchLive, MatchPostponed, MatchAbandoned},
+	MatchLive:      {MatchHalftime, MatchFinished, MatchAbandoned},
+	MatchHalftime:  {MatchLive, MatchFinished, MatchAbandoned},
+	MatchPostponed: {MatchScheduled, MatchAbandoned},
+	MatchFinished:  {},
+	MatchAbandoned: {},
+}
+
+// MatchStatuses liefert alle gültigen Status in Ablaufreihenfolge
+func MatchStatuses() []string {
+	return []string{MatchScheduled, MatchLive, MatchHalftime, MatchFinished, MatchPostponed, MatchAbandoned}
+}
+
+// ValidateTransition prüft, ob ein Spiel von from nach to wechseln darf
+func ValidateTransition(from, to string) error {
+	allowed, ok := matchTransitions[from]
+	if !ok {
+		return i18n.Errorf("error.unknown_status", from)
+	}
+	if _, ok := matchTransitions[to]; !ok {
+		return i18n.Errorf("error.unknown_status", to)
+	}
+	for _, s := range allowed {
+		if s == to {
+			return nil
+		}
+	}
+	return i18n.Errorf("error.transition", from, to)
+}
+
+// MatchAuditEntry protokolliert Änderungen an bereits beendeten Spielen
+type MatchAuditEntry struct {
+	ID        int
+	MatchID   int
+	Action    string
+	Reason    string
+	Actor     string
+	Details   string
+	ChangedAt time.Time
+}
+
+// HasResult gibt an, ob für das Match ein Endstand gespeichert ist
+func (m *Match) HasResult() bool {
+	return m.ScoreHome != nil && m.ScoreAway != nil
 }
+
+// IsFinal gibt an, ob das Spiel beendet ist und für Tabellen zählt
+func (m *Match) IsFinal() bool {
+	return m.Status == MatchFinished && m.HasResult()
+}
+
+// StandingsRow ist eine Zeile der Tabelle
+type StandingsRow struct {
+	Rank          int
+	Team          *Team
+	Played        int
+	Won           int
+	Drawn         int
+	Lost          int
+	GoalsFor      int
+	GoalsAgainst  int
+	Points        int
+	WinPercentage float64
+}
+
+// GoalDifference liefert die Tordifferenz (bzw. Punktdifferenz im Football)
+func (r *StandingsRow) GoalDifference() int {
+	return r.GoalsFor - r.GoalsAgainst
+}
+
+// StandingsTable ist die fertig sortierte Tabelle eines Wettbewerbs
+type StandingsTable struct {
+	Competition string
+	Sportart    string
+	RankingMode string
+	Rows        []*StandingsRow
+}
+
+// LiveState ist der aktuelle Stand eines laufenden Spiels, wie ihn
+// Anzeigen, Overlays und Renderer darstellen
+type LiveState struct {
+	MatchID     int
+	FieldID     int
+	Status      string
+	HomeName    string
+	AwayName    string
+	HomeNames   []string // Namensvarianten, längste zuerst; der Renderer wählt die passende
+	AwayNames   []string
+	HomeScore   int
+	AwayScore   int
+	Period      int
+	PeriodText  string // z.B. "1. Halbzeit"
+	Elapsed     time.Duration
+	ClockText   string // formatiert gemäß GameclockMode, z.B. "12:34" oder "45+2'"
+	Running     bool
+	Overtime    bool // Nachspielzeit bzw. abgelaufene Uhr
+	UpdatedAt   time.Time
+	EventCount  int
+	TemplateID  int
+	Competition string
+}
+
+// MatchEvent protokolliert eine Aktion während eines Live-Spiels
+type MatchEvent struct {
+	ID      int
+	MatchID int
+	Type    string // EventScore, EventPeriod, EventStatus, EventClock
+	Side    string // SlotHome oder SlotAway, nur bei Punkten
+	Value   int
+	Detail  string // z.B. der neue Status
+	Period  int
+	Elapsed time.Duration
+	At      time.Time
+}
+
+// Ereignistypen im Live-Spiel
+const (
+	EventScore  = "score"
+	EventPeriod = "period"
+	EventStatus = "status"
+	EventClock  = "clock"
+)
@@ -0,0 +1,102 @@
+// internal/models/teams.go
+
+package models
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+)
+
+// AbbreviationLength ist die Länge des Teamkürzels, z.B. "MUC"
+const AbbreviationLength = 3
+
+// NameFor liefert den Namen des Teams in einem Wettbewerb; ohne eigenen
+// Eintrag gilt Name
+func (t *Team) NameFor(competition string) string {
+	if n := t.AltNames[competition]; competition != "" && n != "" {
+		return n
+	}
+	return t.Name
+}
+
+// NameVariants liefert die Namen für die Anzeige vom längsten zum kürzesten:
+// Name im Wettbewerb, Kurzname, Kürzel. Leere und doppelte fallen weg.
+func (t *Team) NameVariants(competition string) []string {
+	var names []string
+	for _, n := range []string{t.NameFor(competition), t.ShortName, t.Abbreviation} {
+		if n = strings.TrimSpace(n); n != "" && !slices.Contains(names, n) {
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+// ParseAltNames liest Namen je Wettbewerb im Format
+// "Wettbewerb=Name,Wettbewerb=Name"; ein leerer Name bleibt leer erhalten
+func ParseAltNames(spec string) (map[string]string, error) {
+	names := map[string]string{}
+	for _, kv := range strings.Split(spec, ",") {
+		if strings.TrimSpace(kv) == "" {
+			continue
+		}
+		competition, name, ok := strings.Cut(kv, "=")
+		if competition = strings.TrimSpace(competition); !ok || competition == "" {
+			return nil, i18n.Errorf("error.team_alt_name", kv)
+		}
+		names[competition] = strings.TrimSpace(name)
+	}
+	return names, nil
+}
+
+// FormatAltNames ist das Gegenstück zu ParseAltNames, sortiert nach Wettbewerb
+func FormatAltNames(names map[string]string) string {
+	parts := make([]string, 0, len(names))
+	for _, c := range slices.Sorted(maps.Keys(names)) {
+		parts = append(parts, c+"="+names[c])
+	}
+	return strings.Join(parts, ",")
+}
+
+// NormalizeTeamNames entfernt überzählige Leerzeichen und leere
+// Wettbewerbsnamen; das Kürzel wird großgeschrieben
+func NormalizeTeamNames(t *Team) {
+	t.Name = strings.TrimSpace(t.Name)
+	t.ShortName = strings.TrimSpace(t.ShortName)
+	t.Abbreviation = strings.ToUpper(strings.TrimSpace(t.Abbreviation))
+	var alt map[string]string
+	for c, n := range t.AltNames {
+		if c, n = strings.TrimSpace(c), strings.TrimSpace(n); c != "" && n != "" {
+			if alt == nil {
+				alt = map[string]string{}
+			}
+			alt[c] = n
+		}
+	}
+	t.AltNames = alt
+}
+
+// ValidateTeamNames prüft Name, Kurzname und Kürzel eines Teams
+func ValidateTeamNames(t *Team) error {
+	if strings.TrimSpace(t.Name) == "" {
+		return i18n.Errorf("error.team_name_missing")
+	}
+	if t.Abbreviation != "" {
+		if utf8.RuneCountInString(t.Abbreviation) != AbbreviationLength {
+			return i18n.Errorf("error.team_abbr_length", t.Abbreviation, AbbreviationLength)
+		}
+		for _, r := range t.Abbreviation {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				return i18n.Errorf("error.team_abbr_chars", t.Abbreviation)
+			}
+		}
+	}
+	if utf8.RuneCountInString(t.ShortName) > utf8.RuneCountInString(t.Name) {
+		return i18n.Errorf("error.team_short_name", t.ShortName)
+	}
+	return nil
+}
@@ -0,0 +1,424 @@
+// internal/overlay/overlay.go
+
+package overlay
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"image/png"
+	"log"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/fonts"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/live"
+	"github.com/KernTom/scoreboard-manager/internal/logo"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+	"github.com/KernTom/scoreboard-manager/internal/render"
+)
+
+// Server liefert die Overlay-Seiten für OBS & Co. (Browserquelle) und
+// verteilt den Live-Stand per Server-Sent Events
+type Server struct {
+	live *live.Manager
+}
+
+// New erzeugt den Overlay-Server für die Live-Spiele des Managers
+func New(manager *live.Manager) *Server {
+	return &Server{live: manager}
+}
+
+// Register trägt die Routen /overlay/{field} und /overlay/{field}/events sowie
+// das Schriftregister unter /fonts.css und /fonts/{file} ein
+func (s *Server) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /overlay/{field}", s.page)
+	mux.HandleFunc("GET /overlay/{field}/events", s.events)
+	mux.HandleFunc("GET /overlay/{field}/frame.png", s.frame)
+	mux.HandleFunc("GET /overlay/{field}/logo/{side}", s.teamLogo)
+	mux.HandleFunc("GET /overlay/{field}/asset/{name}", s.asset)
+	mux.HandleFunc("GET /fonts.css", s.fontsCSS)
+	mux.HandleFunc("GET /fonts/{file}", s.fontFile)
+}
+
+// frame ist eine SSE-Nachricht: Stand samt Template, da pro Feld
+// nacheinander Spiele mit unterschiedlichen Templates laufen können
+type frame struct {
+	State    models.LiveState
+	Template *models.TemplateSettings
+	Items    []render.Item // nur bei freiem Layout
+	Fonts    []fontRef     // mitgelieferte Schriften des Templates
+}
+
+// fontRef verweist auf eine Schrift, die der Browser über /asset/ lädt
+type fontRef struct {
+	Family string
+	File   string
+}
+
+func fieldID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue("field"))
+	if err != nil || id < 0 {
+		http.Error(w, i18n.T("error.invalid_field"), http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
+
+func (s *Server) page(w http.ResponseWriter, r *http.Request) {
+	id, ok := fieldID(w, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := pageTemplate.Execute(w, map[string]any{"Field": id, "Locale": i18n.Locale()}); err != nil {
+		log.Printf("overlay: %v", err)
+	}
+}
+
+// frame liefert den aktuellen Stand als PNG, mit ?width=N als Vorschaubild
+func (s *Server) frame(w http.ResponseWriter, r *http.Request) {
+	id, ok := fieldID(w, r)
+	if !ok {
+		return
+	}
+	e, ok := s.live.Get(id)
+	if !ok {
+		http.Error(w, i18n.T("error.field_idle", id), http.StatusNotFound)
+		return
+	}
+
+	m := e.Match()
+	f := render.Frame{State: e.Snapshot()}
+	if t, err := database.LoadTeam(m.Team1.ID); err == nil {
+		f.HomeLogo, f.HomeLogoHash = logo.Image(t.LogoHash), t.LogoHash
+	}
+	if t, err := database.LoadTeam(m.Team2.ID); err == nil {
+		f.AwayLogo, f.AwayLogoHash = logo.Image(t.LogoHash), t.LogoHash
+	}
+
+	img, err := render.Render(e.Template(), f)
+	if err == nil && r.URL.Query().Has("width") {
+		width, _ := strconv.Atoi(r.URL.Query().Get("width"))
+		img, err = render.Thumbnail(img, width)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "no-cache")
+	if err := png.Encode(w, img); err != nil {
+		log.Printf("overlay: %v", err)
+	}
+}
+
+// teamLogo liefert das Logo des Heim- bzw. Gastteams (side "home" oder "away");
+// der Hash dient als ETag, da er den Inhalt eindeutig bestimmt
+func (s *Server) teamLogo(w http.ResponseWriter, r *http.Request) {
+	id, ok := fieldID(w, r)
+	if !ok {
+		return
+	}
+	e, ok := s.live.Get(id)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	team := e.Match().Team1
+	if r.PathValue("side") == models.SlotAway {
+		team = e.Match().Team2
+	}
+	t, err := database.LoadTeam(team.ID)
+	if err != nil || t.LogoHash == "" {
+		http.NotFound(w, r)
+		return
+	}
+	etag := `"` + t.LogoHash + `"`
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	data, err := database.LoadLogo(t.LogoHash)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", http.DetectContentType(data))
+	w.Write(data)
+}
+
+// asset liefert eine mitgelieferte Schrift oder ein Bild des aktuellen Templates
+func (s *Server) asset(w http.ResponseWriter, r *http.Request) {
+	id, ok := fieldID(w, r)
+	if !ok {
+		return
+	}
+	e, ok := s.live.Get(id)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	a := e.Template().Asset(r.PathValue("name"))
+	if a == nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", http.DetectContentType(a.Data))
+	w.Write(a.Data)
+}
+
+// fontsCSS liefert @font-face-Regeln für das Schriftregister, damit der
+// Browser dieselben Dateien verwendet wie der PNG-Renderer
+func (s *Server) fontsCSS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/css; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	fmt.Fprint(w, fonts.CSS("/fonts/"))
+}
+
+// fontFile liefert eine Schriftdatei des Registers
+func (s *Server) fontFile(w http.ResponseWriter, r *http.Request) {
+	f, ok := fonts.ByFile(r.PathValue("file"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "font/"+strings.TrimPrefix(strings.ToLower(path.Ext(f.File)), "."))
+	w.Write(f.Data)
+}
+
+// Abstände des Standardlayouts, siehe #board und #teams im Stylesheet
+const (
+	boardPadding = 16
+	teamsGap     = 16
+)
+
+// boardNames wählt für das Standardlayout die Namensvarianten, die neben
+// dem Spielstand in die Breite des Templates passen
+func boardNames(t *models.TemplateSettings, st models.LiveState) (home, away string) {
+	width := t.Width - 2*boardPadding - 2*teamsGap
+	for _, s := range []struct {
+		text, family string
+		size         int
+	}{
+		{strconv.Itoa(st.HomeScore), t.ScoreFontFamily, t.ScoreFontSize},
+		{" : ", t.SeparatorFontFamily, t.SeparatorFontSize},
+		{strconv.Itoa(st.AwayScore), t.ScoreFontFamily, t.ScoreFontSize},
+	} {
+		if w, err := render.TextWidth(s.text, s.family, s.size); err == nil {
+			width -= w
+		}
+	}
+	width /= 2
+	home = render.FitName(st.HomeNames, st.HomeName, t.ScoreFontFamily, t.ScoreFontSize, width)
+	away = render.FitName(st.AwayNames, st.AwayName, t.ScoreFontFamily, t.ScoreFontSize, width)
+	return home, away
+}
+
+// newFrame baut die SSE-Nachricht; die Dateien selbst lädt der Browser einzeln
+func newFrame(t *models.TemplateSettings, st models.LiveState, items []render.Item) frame {
+	if len(items) == 0 {
+		st.HomeName, st.AwayName = boardNames(t, st)
+	}
+	f := frame{State: st, Items: items}
+	tpl := *t
+	tpl.Assets = nil
+	f.Template = &tpl
+	for _, a := range t.Assets {
+		if a.Kind == models.AssetFont {
+			f.Fonts = append(f.Fonts, fontRef{Family: render.FontFamily(a.Name), File: a.Name})
+		}
+	}
+	return f
+}
+
+func (s *Server) events(w http.ResponseWriter, r *http.Request) {
+	id, ok := fieldID(w, r)
+	if !ok {
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, i18n.T("error.no_streaming"), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	e, ok := s.live.Get(id)
+	if !ok {
+		// kein Spiel auf dem Feld: der Browser versucht es nach retry erneut
+		fmt.Fprint(w, "retry: 3000\nevent: idle\ndata: {}\n\n")
+		flusher.Flush()
+		return
+	}
+
+	states, cancel := e.Subscribe()
+	defer cancel()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case st, ok := <-states:
+			if !ok {
+				// Spiel wurde vom Feld genommen oder der Server fährt herunter
+				fmt.Fprint(w, "retry: 3000\nevent: idle\ndata: {}\n\n")
+				flusher.Flush()
+				return
+			}
+			items, err := render.Items(e.Template(), st)
+			if err != nil {
+				log.Printf("overlay: %v", err)
+				return
+			}
+			data, err := json.Marshal(newFrame(e.Template(), st, items))
+			if err != nil {
+				log.Printf("overlay: %v", err)
+				return
+			}
+			fmt.Fprintf(w, "event: state\ndata: %s\n\n", data)
+			flusher.Flush()
+		}
+	}
+}
+
+var pageTemplate = template.Must(template.New("overlay").Parse(`<!DOCTYPE html>
+<html lang="{{.Locale}}">
+<head>
+<meta charset="utf-8">
+<title>Scoreboard Feld {{.Field}}</title>
+<link rel="stylesheet" href="/fonts.css">
+<style>
+	html, body { margin: 0; background: transparent; overflow: hidden; }
+	#board { display: none; position: absolute; box-sizing: border-box; padding: 8px 16px;
+		font-family: "Segoe UI", sans-serif; color: #FFFFFF; text-align: center; }
+	#teams { display: flex; align-items: center; justify-content: center; gap: 16px; }
+	.name { flex: 1; }
+	#home { text-align: right; }
+	#away { text-align: left; }
+	#free { display: none; position: absolute; overflow: hidden; }
+	#free > * { position: absolute; box-sizing: border-box; display: flex; align-items: center; white-space: nowrap; overflow: hidden; }
+	#free > img { object-fit: contain; }
+</style>
+</head>
+<body>
+<div id="board">
+	<div id="clock"></div>
+	<div id="teams">
+		<span class="name" id="home"></span>
+		<span id="score"><span id="scoreHome"></span><span id="separator"> : </span><span id="scoreAway"></span></span>
+		<span class="name" id="away"></span>
+	</div>
+	<div id="period"></div>
+</div>
+<div id="free"></div>
+<script>
+const board = document.getElementById("board");
+const $ = id => document.getElementById(id);
+
+// family darf wie in CSS eine Ausweichkette sein: "DS-Digital, monospace".
+// Alle Namen werden zitiert, auch generische: fonts.css legt sie auf
+// dieselben Dateien wie der Renderer, statt der Schriften des Browsers.
+function font(el, family, size, color) {
+	if (family) el.style.fontFamily = family.split(",").map(f => f.trim().replace(/^["']|["']$/g, ""))
+		.filter(f => f).map(f => '"' + f + '"').concat("sans-serif").join(", ");
+	if (size) el.style.fontSize = size + "px";
+	if (color) el.style.color = color;
+}
+
+const justify = {left: "flex-start", center: "center", right: "flex-end"};
+
+// mitgelieferte Schriften einmalig laden
+const loadedFonts = new Set();
+function loadFonts(fonts) {
+	for (const f of fonts || []) {
+		if (loadedFonts.has(f.File)) continue;
+		loadedFonts.add(f.File);
+		const url = "/overlay/{{.Field}}/asset/" + encodeURIComponent(f.File);
+		new FontFace(f.Family, "url(" + url + ")").load().then(ff => document.fonts.add(ff), () => loadedFonts.delete(f.File));
+	}
+}
+
+// freies Layout: die Items sind serverseitig fertig aufgelöst
+function renderItems(f) {
+	const free = $("free"), t = f.Template;
+	free.style.left = t.X + "px";
+	free.style.top = t.Y + "px";
+	free.style.width = t.Width + "px";
+	free.style.height = t.Height + "px";
+	free.style.background = t.BackgroundFontColor || "#000000";
+
+	free.replaceChildren(...f.Items.map(it => {
+		let el;
+		if (it.Type === "home_logo" || it.Type === "away_logo") {
+			el = document.createElement("img");
+			el.src = "/overlay/{{.Field}}/logo/" + (it.Type === "home_logo" ? "home" : "away") + "?m=" + f.State.MatchID;
+			el.onerror = () => { el.style.visibility = "hidden"; };
+		} else if (it.Type === "image") {
+			el = document.createElement("img");
+			el.src = "/overlay/{{.Field}}/asset/" + encodeURIComponent(it.Asset);
+		} else {
+			el = document.createElement("div");
+			el.textContent = it.Text;
+			el.style.justifyContent = justify[it.Align] || "center";
+			if (it.Type === "rect") el.style.background = it.Color;
+			else font(el, it.FontFamily, it.FontSize, it.Color);
+		}
+		el.style.left = it.X + "px";
+		el.style.top = it.Y + "px";
+		el.style.width = it.Width + "px";
+		el.style.height = it.Height + "px";
+		return el;
+	}));
+	free.style.display = "block";
+}
+
+function render(f) {
+	const s = f.State, t = f.Template;
+	loadFonts(f.Fonts);
+	if (f.Items && f.Items.length) {
+		board.style.display = "none";
+		renderItems(f);
+		return;
+	}
+	$("free").style.display = "none";
+	board.style.left = t.X + "px";
+	board.style.top = t.Y + "px";
+	if (t.Width) board.style.width = t.Width + "px";
+	if (t.Height) board.style.height = t.Height + "px";
+	board.style.background = t.BackgroundFontColor || "#000000";
+
+	font($("clock"), t.ClockFontFamily, t.ClockFontSize, s.Overtime ? t.ExtraTimeFontColor : t.ClockFontColor);
+	font($("period"), t.PeriodFontFamily, t.PeriodFontSize, t.PeriodFontColor);
+	font($("score"), t.ScoreFontFamily, t.ScoreFontSize, t.ScoreFontColor);
+	font($("separator"), t.SeparatorFontFamily, t.SeparatorFontSize, t.SeparatorFontColor);
+	font($("home"), t.ScoreFontFamily, t.ScoreFontSize, t.ScoreFontColor);
+	font($("away"), t.ScoreFontFamily, t.ScoreFontSize, t.ScoreFontColor);
+
+	$("clock").style.display = (t.ShowGameclock || t.ShowClock) ? "" : "none";
+	$("clock").textContent = t.ShowGameclock ? s.ClockText : new Date().toLocaleTimeString({{.Locale}}, {hour: "2-digit", minute: "2-digit"});
+	$("period").style.display = t.ShowPeriod ? "" : "none";
+	$("period").textContent = s.PeriodText;
+	$("home").textContent = s.HomeName;
+	$("away").textContent = s.AwayName;
+	$("scoreHome").textContent = s.HomeScore;
+	$("scoreAway").textContent = s.AwayScore;
+	board.style.display = "block";
+}
+
+const source = new EventSource("/overlay/{{.Field}}/events");
+source.addEventListener("state", ev => render(JSON.parse(ev.data)));
+source.addEventListener("idle", () => { board.style.display = "none"; $("free").style.display = "none"; });
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,104 @@
+// internal/render/assets.go
+
+package render
+
+import (
+	"bytes"
+	"image"
+	"strings"
+	"sync"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/fonts"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// Schriften aus Templates werden unter ihrem Dateinamen ohne Endung als
+// Familie bekannt gemacht, z.B. "Vereinsschrift.ttf" → "Vereinsschrift"
+var templateFonts = map[string]*fonts.Font{}
+
+// decodierte Bilder je Asset; die Engine hält das Template eines Spiels
+// unverändert, daher reicht der Zeiger als Schlüssel
+var (
+	imageMu    sync.Mutex
+	imageCache = map[*models.TemplateAsset]decodedAsset{}
+)
+
+// decodedAsset ist ein Bild samt Inhalts-Hash für den Logo-Cache
+type decodedAsset struct {
+	img  image.Image
+	hash string
+}
+
+// FontFamily liefert den Familiennamen einer mitgelieferten Schriftdatei
+func FontFamily(assetName string) string {
+	return fonts.Family(assetName)
+}
+
+// ValidateAsset prüft, ob eine Datei als Schrift bzw. Bild lesbar ist
+func ValidateAsset(a *models.TemplateAsset) error {
+	switch a.Kind {
+	case models.AssetFont:
+		if _, err := fonts.Parse(a.Name, a.Data, ""); err != nil {
+			return err
+		}
+	case models.AssetImage:
+		if _, _, err := image.Decode(bytes.NewReader(a.Data)); err != nil {
+			return i18n.Errorf("error.wrap.image", a.Name, err)
+		}
+	default:
+		return i18n.Errorf("error.render.asset_kind", a.Name, a.Kind)
+	}
+	return nil
+}
+
+// registerFonts macht die Schriften des Templates für face bekannt. Ändert
+// sich eine Schrift gleichen Namens, werden ihre Faces neu erzeugt.
+func registerFonts(t *models.TemplateSettings) error {
+	fontMu.Lock()
+	defer fontMu.Unlock()
+
+	for _, a := range t.Assets {
+		if a.Kind != models.AssetFont {
+			continue
+		}
+		family := strings.ToLower(FontFamily(a.Name))
+		if f, ok := fonts.Lookup(family); ok && f.Source == fonts.SourceBuiltin && strings.EqualFold(f.Family, family) {
+			continue // eingebaute Schriften lassen sich nicht überschreiben
+		}
+		old, ok := templateFonts[family]
+		if ok && bytes.Equal(old.Data, a.Data) {
+			continue
+		}
+		f, err := fonts.Parse(a.Name, a.Data, "")
+		if err != nil {
+			return err
+		}
+		templateFonts[family] = f
+		for key := range faceCache {
+			if key.font == old {
+				delete(faceCache, key)
+			}
+		}
+	}
+	return nil
+}
+
+// assetImage liefert das decodierte Bild eines Assets samt Hash oder nil
+func assetImage(t *models.TemplateSettings, name string) (image.Image, string) {
+	a := t.Asset(name)
+	if a == nil || a.Kind != models.AssetImage {
+		return nil, ""
+	}
+
+	imageMu.Lock()
+	defer imageMu.Unlock()
+
+	d, ok := imageCache[a]
+	if !ok {
+		d = decodedAsset{img: DecodeLogo(a.Data), hash: database.LogoHash(a.Data)}
+		imageCache[a] = d
+	}
+	return d.img, d.hash
+}
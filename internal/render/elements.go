@@ -0,0 +1,215 @@
+// internal/render/elements.go
+
+package render
+
+import (
+	"image"
+	"image/color"
+	"strconv"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// Item ist ein sichtbares Element mit aufgelöstem Text, Schrift und Farbe.
+// Der Renderer und das Browser-Overlay zeichnen dieselben Items.
+type Item struct {
+	Type       string
+	X          int
+	Y          int
+	Width      int
+	Height     int
+	Align      string
+	FontFamily string
+	FontSize   int
+	Color      string
+	Text       string
+	Asset      string // nur bei Bildern
+}
+
+// Items wertet Sichtbarkeit und Vererbung der Layout-Elemente für einen Spielstand aus
+func Items(t *models.TemplateSettings, s models.LiveState) ([]Item, error) {
+	var items []Item
+	for _, e := range t.Elements {
+		if !e.Visible(t, s) {
+			continue
+		}
+		it := Item{Type: e.Type, X: e.X, Y: e.Y, Width: e.Width, Height: e.Height, Align: or(e.Align, models.AlignCenter), Color: e.Color}
+		switch e.Type {
+		case models.ElementRect:
+			it.Color = or(e.Color, "#000000")
+		case models.ElementHomeLogo, models.ElementAwayLogo:
+		case models.ElementImage:
+			it.Asset = e.Asset
+		default:
+			text, hex, err := elementText(e, t, s)
+			if err != nil {
+				return nil, err
+			}
+			it.Text, it.Color = text, hex
+			it.FontFamily, it.FontSize = elementFont(e, t)
+			if it.FontSize <= 0 {
+				it.FontSize = defaultSize
+			}
+			switch e.Type {
+			case models.ElementHomeName:
+				it.Text = FitName(s.HomeNames, it.Text, it.FontFamily, it.FontSize, it.Width)
+			case models.ElementAwayName:
+				it.Text = FitName(s.AwayNames, it.Text, it.FontFamily, it.FontSize, it.Width)
+			}
+		}
+		items = append(items, it)
+	}
+	return items, nil
+}
+
+// drawElements zeichnet das freie Layout in Listenreihenfolge
+func drawElements(img *image.RGBA, t *models.TemplateSettings, f Frame) error {
+	items, err := Items(t, f.State)
+	if err != nil {
+		return err
+	}
+	for _, it := range items {
+		box := image.Rect(it.X, it.Y, it.X+it.Width, it.Y+it.Height)
+
+		switch it.Type {
+		case models.ElementRect:
+			draw.Draw(img, box, image.NewUniform(parseColor(it.Color, color.Black)), image.Point{}, draw.Over)
+		case models.ElementHomeLogo:
+			if f.HomeLogo != nil {
+				drawLogo(img, f.HomeLogo, f.HomeLogoHash, box)
+			}
+		case models.ElementAwayLogo:
+			if f.AwayLogo != nil {
+				drawLogo(img, f.AwayLogo, f.AwayLogoHash, box)
+			}
+		case models.ElementImage:
+			if logo, hash := assetImage(t, it.Asset); logo != nil {
+				drawLogo(img, logo, hash, box)
+			}
+		default:
+			if err := drawText(img, box, it); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// elementText liefert Text und Farbe eines Textelements; ohne eigene
+// Schrift/Farbe gelten die passenden Template-Einstellungen
+func elementText(e *models.LayoutElement, t *models.TemplateSettings, s models.LiveState) (string, string, error) {
+	hex := e.Color
+	switch e.Type {
+	case models.ElementText:
+		return e.Text, hex, nil
+	case models.ElementClock:
+		if s.Overtime && t.ShowGameclock {
+			// Nachspielzeit hat immer Vorrang, sonst wäre sie nicht erkennbar
+			hex = t.ExtraTimeFontColor
+		}
+		return clockText(t, s), or(hex, t.ClockFontColor), nil
+	case models.ElementPeriod:
+		return periodText(t, s), or(hex, t.PeriodFontColor), nil
+	case models.ElementScoreHome:
+		return strconv.Itoa(s.HomeScore), or(hex, t.ScoreFontColor), nil
+	case models.ElementScoreAway:
+		return strconv.Itoa(s.AwayScore), or(hex, t.ScoreFontColor), nil
+	case models.ElementSeparator:
+		return or(e.Text, ":"), or(hex, t.SeparatorFontColor), nil
+	case models.ElementHomeName:
+		return s.HomeName, or(hex, t.ScoreFontColor), nil
+	case models.ElementAwayName:
+		return s.AwayName, or(hex, t.ScoreFontColor), nil
+	}
+	return "", "", i18n.Errorf("error.render.element_type", e.Type)
+}
+
+// elementFont liefert Schriftfamilie und -größe; fehlende Werte erbt das
+// Element vom passenden Template-Feld
+func elementFont(e *models.LayoutElement, t *models.TemplateSettings) (string, int) {
+	family, size := t.ScoreFontFamily, t.ScoreFontSize
+	switch e.Type {
+	case models.ElementClock:
+		family, size = t.ClockFontFamily, t.ClockFontSize
+	case models.ElementPeriod, models.ElementText:
+		family, size = t.PeriodFontFamily, t.PeriodFontSize
+	case models.ElementSeparator:
+		family, size = t.SeparatorFontFamily, t.SeparatorFontSize
+	}
+	if e.FontFamily != "" {
+		family = e.FontFamily
+	}
+	if e.FontSize > 0 {
+		size = e.FontSize
+	}
+	return family, size
+}
+
+// drawText zeichnet den Text vertikal zentriert und horizontal ausgerichtet
+// in die Box des Elements; überstehender Text wird abgeschnitten
+func drawText(img *image.RGBA, box image.Rectangle, it Item) error {
+	if it.Text == "" {
+		return nil
+	}
+	fc, err := face(it.FontFamily, it.FontSize)
+	if err != nil {
+		return err
+	}
+
+	box = box.Intersect(img.Bounds())
+	if box.Empty() {
+		return nil
+	}
+	width := font.MeasureString(fc, it.Text).Ceil()
+	x := box.Min.X
+	switch it.Align {
+	case models.AlignRight:
+		x = box.Max.X - width
+	case models.AlignLeft:
+	default:
+		x = box.Min.X + (box.Dx()-width)/2
+	}
+	baseline := box.Min.Y + (box.Dy()-lineHeight(fc))/2 + fc.Metrics().Ascent.Ceil()
+
+	d := font.Drawer{Dst: img.SubImage(box).(*image.RGBA), Src: image.NewUniform(parseColor(it.Color, color.White)), Face: fc, Dot: fixed.P(x, baseline)}
+	d.DrawString(it.Text)
+	return nil
+}
+
+// FitName wählt die längste Namensvariante, die in width Pixel passt; passt
+// keine, bleibt die kürzeste. Ohne Varianten gilt name.
+func FitName(variants []string, name, family string, size, width int) string {
+	if len(variants) == 0 {
+		return name
+	}
+	if width <= 0 {
+		return variants[0]
+	}
+	for _, v := range variants {
+		if w, err := TextWidth(v, family, size); err != nil || w <= width {
+			return v
+		}
+	}
+	return variants[len(variants)-1]
+}
+
+// TextWidth misst die Breite eines Textes in Pixel
+func TextWidth(text, family string, size int) (int, error) {
+	fc, err := face(family, size)
+	if err != nil {
+		return 0, err
+	}
+	return font.MeasureString(fc, text).Ceil(), nil
+}
+
+func or(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
@@ -0,0 +1,153 @@
+// internal/render/fonts.go
+
+package render
+
+import (
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+
+	"github.com/KernTom/scoreboard-manager/internal/fonts"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// SplitFamilies zerlegt eine Ausweichkette wie "DS-Digital, monospace"
+func SplitFamilies(spec string) []string {
+	var families []string
+	for _, f := range strings.Split(spec, ",") {
+		if f = strings.Trim(strings.TrimSpace(f), `"'`); f != "" {
+			families = append(families, f)
+		}
+	}
+	return families
+}
+
+const fallbackFamily = "Go"
+
+// resolveFamily liefert die erste zeichenbare Familie der Kette: Template-
+// Schriften vor dem Schriftregister, sonst Go Regular. Aufruf nur mit fontMu.
+func resolveFamily(spec string) *fonts.Font {
+	for _, f := range SplitFamilies(spec) {
+		if tf, ok := templateFonts[strings.ToLower(f)]; ok {
+			return tf
+		}
+		if rf, ok := fonts.Lookup(f); ok {
+			return rf
+		}
+	}
+	f, _ := fonts.Lookup(fallbackFamily)
+	return f
+}
+
+// Families liefert die Schriften, die der Renderer ohne Ausweichen zeichnen
+// kann: die des Schriftregisters, bekannte Systemschriften und die des Templates
+func Families(t *models.TemplateSettings) []string {
+	families := fonts.Families()
+	if t != nil {
+		for _, a := range t.Assets {
+			if a.Kind == models.AssetFont {
+				families = append(families, FontFamily(a.Name))
+			}
+		}
+	}
+	return families
+}
+
+// available prüft, ob eine Familie ohne Rückfall auf Go Regular zeichenbar ist
+func available(t *models.TemplateSettings, family string) bool {
+	if _, ok := fonts.Lookup(family); ok {
+		return true
+	}
+	if t != nil {
+		for _, a := range t.Assets {
+			if a.Kind == models.AssetFont && strings.EqualFold(FontFamily(a.Name), family) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ValidateFont prüft Schriftkette und Größe; mindestens eine Familie der
+// Kette muss verfügbar sein
+func ValidateFont(t *models.TemplateSettings, spec string, size int) error {
+	if size < 1 || size > maxFontSize {
+		return i18n.Errorf("error.render.font_size", size, maxFontSize)
+	}
+	families := SplitFamilies(spec)
+	if len(families) == 0 {
+		return i18n.Errorf("error.render.no_font")
+	}
+	for _, f := range families {
+		if available(t, f) {
+			return nil
+		}
+	}
+	return i18n.Errorf("error.render.font_unavailable",
+		spec, strings.Join(Families(t), ", "), spec+", sans-serif")
+}
+
+const maxFontSize = 500
+
+// ValidateFonts prüft die Schriften des Templates und seiner Layout-Elemente
+func ValidateFonts(t *models.TemplateSettings) error {
+	for _, f := range []struct {
+		name   string
+		family string
+		size   int
+	}{
+		{"Uhr", t.ClockFontFamily, t.ClockFontSize},
+		{"Periode", t.PeriodFontFamily, t.PeriodFontSize},
+		{"Spielstand", t.ScoreFontFamily, t.ScoreFontSize},
+		{"Trenner", t.SeparatorFontFamily, t.SeparatorFontSize},
+	} {
+		if err := ValidateFont(t, f.family, f.size); err != nil {
+			return i18n.Errorf("error.wrap.named", f.name, err)
+		}
+	}
+	for i, e := range t.Elements {
+		if e.FontFamily == "" && e.FontSize == 0 {
+			continue // erbt die Template-Schrift
+		}
+		family, size := elementFont(e, t)
+		if err := ValidateFont(t, family, size); err != nil {
+			return i18n.Errorf("error.wrap.element", i+1, e.Type, err)
+		}
+	}
+	return nil
+}
+
+var (
+	fontMu    sync.Mutex
+	faceCache = map[faceKey]font.Face{}
+)
+
+type faceKey struct {
+	font *fonts.Font
+	size int
+}
+
+// face liefert die Schrift in Pixelgröße size; Faces werden zwischengespeichert
+func face(family string, size int) (font.Face, error) {
+	if size <= 0 {
+		return nil, i18n.Errorf("error.render.invalid_font_size", size)
+	}
+	fontMu.Lock()
+	defer fontMu.Unlock()
+
+	key := faceKey{resolveFamily(family), size}
+	if f, ok := faceCache[key]; ok {
+		return f, nil
+	}
+
+	// 72 DPI: die Größe im Template entspricht der Pixelhöhe, wie im Overlay
+	f, err := opentype.NewFace(key.font.OpenType(), &opentype.FaceOptions{Size: float64(size), DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, err
+	}
+	faceCache[key] = f
+	return f, nil
+}
@@ -0,0 +1,241 @@
+// internal/render/golden_test.go
+//
+// Golden-Image-Test der Scoreboard-Layouts: feste Templates werden mit
+// festen Beispieldaten gerendert und mit den PNGs in testdata/golden
+// verglichen. Nach gewollten Layoutänderungen die Referenzbilder mit
+//
+//	go test ./internal/render -run Golden -update
+//
+// neu schreiben und mit einchecken. Bei Abweichungen liegt neben dem
+// Referenzbild ein .diff.png: Referenz abgedunkelt, Abweichungen rot.
+
+package render
+
+import (
+	"errors"
+	"flag"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/live"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+var update = flag.Bool("update", false, "Referenzbilder in testdata/golden neu schreiben")
+
+const (
+	goldenDir = "testdata/golden"
+	tolerance = 0.001 // erlaubter Anteil abweichender Pixel
+	threshold = 8     // erlaubte Abweichung je Farbkanal, z.B. für Kantenglättung
+)
+
+// goldenTemplate ist das klassische Layout mit den Vorgaben neuer Templates
+func goldenTemplate(name string) *models.TemplateSettings {
+	return &models.TemplateSettings{
+		ID: 1, Name: name, Width: 800, Height: 200,
+		Sportart: "American Football", PeriodLabel: "Viertel", PeriodsCount: 4, PeriodDuration: 12,
+		GameclockMode: models.GameclockDownMMSS,
+		ShowPeriod:    true, ShowGameclock: true,
+		ClockFontFamily: "Segoe UI", ClockFontSize: 32, ClockFontColor: "#FFFFFF",
+		PeriodFontFamily: "Segoe UI", PeriodFontSize: 20, PeriodFontColor: "#FFFFFF",
+		ScoreFontFamily: "Segoe UI", ScoreFontSize: 32, ScoreFontColor: "#FFFFFF",
+		SeparatorFontFamily: "Segoe UI", SeparatorFontSize: 28, SeparatorFontColor: "#FFFFFF",
+		ExtraTimeFontColor: "#FF0000", BackgroundFontColor: "#000000",
+	}
+}
+
+func goldenTemplates(t *testing.T) []*models.TemplateSettings {
+	t.Helper()
+	football := goldenTemplate("fussball-minuten")
+	football.Sportart, football.PeriodLabel, football.PeriodsCount, football.PeriodDuration = "Fußball", "Halbzeit", 2, 45
+	football.GameclockMode = models.GameclockUpMinutes
+	football.ClockFontFamily, football.ScoreFontFamily = "DS-Digital", "DS-Digital"
+	football.ScoreFontSize, football.ScoreFontColor = 48, "#FFCC00"
+	football.BackgroundFontColor = "#003366"
+
+	compact := goldenTemplate("ohne-periode")
+	compact.Width, compact.Height = 400, 120
+	compact.ShowPeriod = false
+	compact.GameclockMode = models.GameclockUpMMSS
+
+	templates := []*models.TemplateSettings{goldenTemplate("klassisch"), football, compact}
+	for _, preset := range Presets() {
+		tpl := goldenTemplate("preset-" + preset)
+		if preset == PresetStadium {
+			tpl.Width, tpl.Height = 960, 540
+		}
+		elements, err := Preset(preset, tpl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tpl.Elements = elements
+		templates = append(templates, tpl)
+	}
+	return templates
+}
+
+// goldenFrames sind die Beispieldaten: der Stand der Admin-Vorschau und ein
+// Spiel in der Nachspielzeit mit langen Namen und Logos
+func goldenFrames(t *models.TemplateSettings) map[string]Frame {
+	late := Frame{
+		State: models.LiveState{
+			Status:     models.MatchLive,
+			HomeName:   "Munich Cowboys",
+			AwayName:   "Berlin Adler",
+			HomeNames:  []string{"Munich Cowboys", "Cowboys", "MUC"},
+			AwayNames:  []string{"Berlin Adler", "Adler", "BER"},
+			HomeScore:  21,
+			AwayScore:  14,
+			Period:     t.PeriodsCount,
+			PeriodText: live.PeriodText(t, t.PeriodsCount, models.MatchLive),
+			ClockText:  "+02:13",
+			Running:    true,
+			Overtime:   true,
+			UpdatedAt:  time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		},
+		HomeLogo: testLogo(color.RGBA{R: 0x00, G: 0x4B, B: 0x87, A: 0xFF}),
+		AwayLogo: testLogo(color.RGBA{R: 0xC8, G: 0x10, B: 0x2E, A: 0xFF}),
+	}
+	transparent := SampleFrame(t)
+	transparent.Transparent = true
+	return map[string]Frame{"beispiel": SampleFrame(t), "nachspielzeit": late, "transparent": transparent}
+}
+
+// testLogo ist ein Kreis auf transparentem Grund
+func testLogo(c color.RGBA) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	for y := range 64 {
+		for x := range 64 {
+			if dx, dy := x-32, y-32; dx*dx+dy*dy <= 30*30 {
+				img.SetRGBA(x, y, c)
+			}
+		}
+	}
+	return img
+}
+
+func TestGolden(t *testing.T) {
+	for _, tpl := range goldenTemplates(t) {
+		for name, frame := range goldenFrames(tpl) {
+			t.Run(tpl.Name+"/"+name, func(t *testing.T) {
+				got, err := Render(tpl, frame)
+				if err != nil {
+					t.Fatal(err)
+				}
+				file := filepath.Join(goldenDir, tpl.Name+"-"+name+".png")
+				if *update {
+					if err := writePNG(file, got); err != nil {
+						t.Fatal(err)
+					}
+					return
+				}
+
+				want, err := readPNG(file)
+				if errors.Is(err, os.ErrNotExist) {
+					t.Fatalf("Referenzbild %s fehlt, mit -update erzeugen", file)
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				ratio, diff := compareImages(got, want)
+				if ratio > tolerance {
+					diffFile := file[:len(file)-len(".png")] + ".diff.png"
+					if err := writePNG(diffFile, diff); err != nil {
+						t.Fatal(err)
+					}
+					t.Errorf("%.4f%% der Pixel weichen von %s ab, siehe %s", ratio*100, file, diffFile)
+				}
+			})
+		}
+	}
+}
+
+func TestCompareImages(t *testing.T) {
+	base := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	shifted := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	shifted.SetRGBA(0, 0, color.RGBA{R: threshold, A: 0})
+	changed := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	changed.SetRGBA(0, 0, color.RGBA{R: 0xFF, A: 0xFF})
+
+	tests := []struct {
+		name      string
+		got, want image.Image
+		ratio     float64
+	}{
+		{"gleich", base, base, 0},
+		{"innerhalb der Schwelle", shifted, base, 0},
+		{"ein Pixel", changed, base, 0.01},
+		{"andere Größe", image.NewRGBA(image.Rect(0, 0, 5, 5)), base, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if ratio, _ := compareImages(tt.got, tt.want); ratio != tt.ratio {
+				t.Errorf("Abweichung %v, erwartet %v", ratio, tt.ratio)
+			}
+		})
+	}
+}
+
+// compareImages liefert den Anteil abweichender Pixel und ein Diff-Bild.
+// Unterschiedliche Größen gelten als vollständig abweichend.
+func compareImages(got, want image.Image) (float64, *image.RGBA) {
+	gb, wb := got.Bounds(), want.Bounds()
+	diff := image.NewRGBA(image.Rect(0, 0, gb.Dx(), gb.Dy()))
+	if gb.Size() != wb.Size() {
+		draw.Draw(diff, diff.Bounds(), image.NewUniform(color.RGBA{R: 0xFF, A: 0xFF}), image.Point{}, draw.Src)
+		return 1, diff
+	}
+
+	differing := 0
+	for y := range gb.Dy() {
+		for x := range gb.Dx() {
+			g := color.RGBAModel.Convert(got.At(gb.Min.X+x, gb.Min.Y+y)).(color.RGBA)
+			w := color.RGBAModel.Convert(want.At(wb.Min.X+x, wb.Min.Y+y)).(color.RGBA)
+			if channelDiff(g.R, w.R) > threshold || channelDiff(g.G, w.G) > threshold ||
+				channelDiff(g.B, w.B) > threshold || channelDiff(g.A, w.A) > threshold {
+				differing++
+				diff.SetRGBA(x, y, color.RGBA{R: 0xFF, A: 0xFF})
+				continue
+			}
+			diff.SetRGBA(x, y, color.RGBA{R: w.R / 4, G: w.G / 4, B: w.B / 4, A: 0xFF})
+		}
+	}
+	return float64(differing) / float64(gb.Dx()*gb.Dy()), diff
+}
+
+func channelDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func readPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return png.Decode(f)
+}
+
+func writePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
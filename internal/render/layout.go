@@ -0,0 +1,122 @@
+// internal/render/layout.go
+
+package render
+
+import (
+	"slices"
+
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// ValidateLayout prüft die Layout-Elemente eines Templates
+func ValidateLayout(t *models.TemplateSettings) error {
+	for i, e := range t.Elements {
+		if !slices.Contains(models.ElementTypes(), e.Type) {
+			return i18n.Errorf("error.render.layout_type", i+1, e.Type)
+		}
+		if e.Visibility != "" && !slices.Contains(models.Visibilities(), e.Visibility) {
+			return i18n.Errorf("error.render.layout_visibility", i+1, e.Visibility)
+		}
+		switch e.Align {
+		case "", models.AlignLeft, models.AlignCenter, models.AlignRight:
+		default:
+			return i18n.Errorf("error.render.layout_align", i+1, e.Align)
+		}
+		if e.Width <= 0 || e.Height <= 0 {
+			return i18n.Errorf("error.render.layout_size", i+1, e.Type)
+		}
+		if e.FontSize < 0 {
+			return i18n.Errorf("error.render.layout_font_size", i+1, e.Type, e.FontSize)
+		}
+		if e.Color != "" && parseColor(e.Color, nil) == nil {
+			return i18n.Errorf("error.render.layout_color", i+1, e.Type, e.Color)
+		}
+		if e.Type == models.ElementImage {
+			if a := t.Asset(e.Asset); a == nil || a.Kind != models.AssetImage {
+				return i18n.Errorf("error.render.layout_asset", i+1, e.Type, e.Asset)
+			}
+		}
+	}
+	return nil
+}
+
+// Vorlagen für das freie Layout
+const (
+	PresetBug     = "bug"     // einzeilige Bauchbinde: Heim 2 : 1 Gast | Uhr | Periode
+	PresetStacked = "stacked" // Teams untereinander, Uhr und Periode rechts
+	PresetStadium = "stadium" // Stadionanzeige: große Logos, Namen darunter
+)
+
+// Presets liefert die Namen aller Vorlagen
+func Presets() []string {
+	return []string{PresetBug, PresetStacked, PresetStadium}
+}
+
+// Preset erzeugt die Elemente einer Vorlage passend zur Templategröße.
+// Schriften und Farben bleiben leer und werden vom Template geerbt, nur die
+// Stadionanzeige setzt die Größe des Spielstands passend zur Fläche.
+func Preset(name string, t *models.TemplateSettings) ([]*models.LayoutElement, error) {
+	w, h := t.Width, t.Height
+	if w <= 0 || h <= 0 {
+		return nil, i18n.Errorf("error.render.size", w, h)
+	}
+	el := func(typ string, x, y, width, height int, align, visibility string) *models.LayoutElement {
+		return &models.LayoutElement{Type: typ, X: x, Y: y, Width: width, Height: height, Align: align, Visibility: visibility}
+	}
+
+	switch name {
+	case PresetBug:
+		// Spalten in Zwölfteln der Breite
+		u := w / 12
+		return []*models.LayoutElement{
+			el(models.ElementHomeName, 0, 0, 4*u, h, models.AlignRight, models.VisibleAlways),
+			el(models.ElementScoreHome, 4*u, 0, u, h, models.AlignRight, models.VisibleAlways),
+			el(models.ElementSeparator, 5*u, 0, u/2, h, models.AlignCenter, models.VisibleAlways),
+			el(models.ElementScoreAway, 5*u+u/2, 0, u, h, models.AlignLeft, models.VisibleAlways),
+			el(models.ElementAwayName, 6*u+u/2, 0, 3*u+u/2, h, models.AlignLeft, models.VisibleAlways),
+			el(models.ElementClock, 10*u, 0, 2*u, h/2, models.AlignCenter, models.VisibleGameclock),
+			el(models.ElementPeriod, 10*u, h/2, 2*u, h-h/2, models.AlignCenter, models.VisiblePeriod),
+		}, nil
+
+	case PresetStacked:
+		row := h / 2
+		logo := min(row, w/6)
+		scoreX := w * 11 / 20
+		return []*models.LayoutElement{
+			el(models.ElementHomeLogo, 0, 0, logo, row, models.AlignCenter, models.VisibleAlways),
+			el(models.ElementHomeName, logo, 0, scoreX-logo, row, models.AlignLeft, models.VisibleAlways),
+			el(models.ElementScoreHome, scoreX, 0, w/8, row, models.AlignCenter, models.VisibleAlways),
+			el(models.ElementAwayLogo, 0, row, logo, h-row, models.AlignCenter, models.VisibleAlways),
+			el(models.ElementAwayName, logo, row, scoreX-logo, h-row, models.AlignLeft, models.VisibleAlways),
+			el(models.ElementScoreAway, scoreX, row, w/8, h-row, models.AlignCenter, models.VisibleAlways),
+			el(models.ElementClock, scoreX+w/8, 0, w-scoreX-w/8, row, models.AlignCenter, models.VisibleGameclock),
+			el(models.ElementPeriod, scoreX+w/8, row, w-scoreX-w/8, h-row, models.AlignCenter, models.VisiblePeriod),
+		}, nil
+
+	case PresetStadium:
+		top, bottom := h/6, h/6
+		logo := min(h-top-bottom-h/6, w/5)
+		mid := top + logo
+		scoreW := w/2 - w/16*2 - logo - w/40
+		scoreHome := el(models.ElementScoreHome, w/16*2+logo, top, scoreW, logo, models.AlignRight, models.VisibleAlways)
+		scoreAway := el(models.ElementScoreAway, w/2+w/40, top, scoreW, logo, models.AlignLeft, models.VisibleAlways)
+		separator := el(models.ElementSeparator, w/2-w/40, top, w/20, logo, models.AlignCenter, models.VisibleAlways)
+		// zweistellige Spielstände müssen in die Breite passen
+		scoreHome.FontSize = min(logo*2/3, scoreW*4/5)
+		scoreAway.FontSize = scoreHome.FontSize
+		separator.FontSize = logo / 2
+		return []*models.LayoutElement{
+			el(models.ElementClock, 0, 0, w, top, models.AlignCenter, models.VisibleGameclock),
+			el(models.ElementHomeLogo, w/16, top, logo, logo, models.AlignCenter, models.VisibleAlways),
+			el(models.ElementAwayLogo, w-w/16-logo, top, logo, logo, models.AlignCenter, models.VisibleAlways),
+			el(models.ElementHomeName, 0, mid, w/16*2+logo, h/6, models.AlignCenter, models.VisibleAlways),
+			el(models.ElementAwayName, w-w/16*2-logo, mid, w/16*2+logo, h/6, models.AlignCenter, models.VisibleAlways),
+			scoreHome,
+			separator,
+			scoreAway,
+			el(models.ElementPeriod, 0, h-bottom, w, bottom, models.AlignCenter, models.VisiblePeriod),
+		}, nil
+	}
+	return nil, i18n.Errorf("error.render.preset", name)
+}
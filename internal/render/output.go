@@ -0,0 +1,157 @@
+// internal/render/output.go
+
+package render
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// Sink nimmt gerenderte Bilder einer Bildfolge entgegen
+type Sink interface {
+	WriteFrame(img *image.RGBA) error
+	Close() error
+}
+
+// Compose zeichnet das Scoreboard an seiner Template-Position (X, Y) auf eine
+// transparente Fläche, wie es das Overlay im Browser tut
+func Compose(canvas *image.RGBA, t *models.TemplateSettings, f Frame) error {
+	draw.Draw(canvas, canvas.Bounds(), image.Transparent, image.Point{}, draw.Src)
+	board, err := Render(t, f)
+	if err != nil {
+		return err
+	}
+	at := image.Pt(t.X, t.Y).Add(canvas.Bounds().Min)
+	draw.Draw(canvas, board.Bounds().Add(at), board, image.Point{}, draw.Over)
+	return nil
+}
+
+// pngSequence schreibt jedes Bild als frame_000001.png usw. in ein Verzeichnis
+type pngSequence struct {
+	dir     string
+	n       int
+	encoder png.Encoder
+}
+
+// NewPNGSequence legt dir an und schreibt die Bilder als nummerierte RGBA-PNGs
+func NewPNGSequence(dir string) (Sink, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &pngSequence{dir: dir, encoder: png.Encoder{CompressionLevel: png.BestSpeed}}, nil
+}
+
+func (s *pngSequence) WriteFrame(img *image.RGBA) error {
+	s.n++
+	f, err := os.Create(filepath.Join(s.dir, fmt.Sprintf("frame_%06d.png", s.n)))
+	if err != nil {
+		return err
+	}
+	if err := s.encoder.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (s *pngSequence) Close() error { return nil }
+
+// rawStream schreibt unkomprimiertes RGBA (8 Bit je Kanal, nicht
+// vormultipliziertes Alpha), z.B. für
+// ffmpeg -f rawvideo -pix_fmt rgba -s 1920x1080 -r 25 -i -
+type rawStream struct {
+	w   io.WriteCloser
+	buf []byte
+}
+
+// NewRawStream schreibt die Bilder als rohen RGBA-Datenstrom nach w (stdout, Named Pipe)
+func NewRawStream(w io.WriteCloser) Sink {
+	return &rawStream{w: w}
+}
+
+func (s *rawStream) WriteFrame(img *image.RGBA) error {
+	b := img.Bounds()
+	size := b.Dx() * b.Dy() * 4
+	if cap(s.buf) < size {
+		s.buf = make([]byte, size)
+	}
+	buf := s.buf[:size]
+
+	i := 0
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		row := img.Pix[img.PixOffset(b.Min.X, y):img.PixOffset(b.Max.X, y)]
+		for p := 0; p < len(row); p += 4 {
+			r, g, bl, a := row[p], row[p+1], row[p+2], row[p+3]
+			// image.RGBA ist vormultipliziert, Videoformate erwarten reines Alpha
+			if a != 0 && a != 0xFF {
+				r = uint8(uint16(r) * 0xFF / uint16(a))
+				g = uint8(uint16(g) * 0xFF / uint16(a))
+				bl = uint8(uint16(bl) * 0xFF / uint16(a))
+			}
+			buf[i], buf[i+1], buf[i+2], buf[i+3] = r, g, bl, a
+			i += 4
+		}
+	}
+	_, err := s.w.Write(buf)
+	return err
+}
+
+func (s *rawStream) Close() error { return s.w.Close() }
+
+// Run erzeugt fps Bilder pro Sekunde über next und schreibt sie in sink, bis
+// ctx endet oder frames Bilder geschrieben sind (0 = unbegrenzt). Bild n ist
+// zum Zeitpunkt n/fps nach dem Start fällig. Ist das Rendern oder der
+// Empfänger zu langsam, wird für jedes versäumte Bild das letzte erneut
+// geschrieben, damit ein Datenstrom mit fester Bildrate (ffmpeg -r) nicht
+// gegenüber der Uhr zurückbleibt.
+func Run(ctx context.Context, fps, frames int, next func() (*image.RGBA, error), sink Sink) error {
+	if fps <= 0 {
+		return i18n.Errorf("error.render.fps")
+	}
+	interval := time.Second / time.Duration(fps)
+	start := time.Now()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	var last *image.RGBA
+	for n := 0; frames == 0 || n < frames; n++ {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		// schon das nächste Bild fällig: das letzte wiederholen statt neu zu rendern
+		img := last
+		if last == nil || time.Since(start) < time.Duration(n+1)*interval {
+			var err error
+			if img, err = next(); err != nil {
+				return err
+			}
+			last = img
+		}
+		if err := sink.WriteFrame(img); err != nil {
+			return err
+		}
+
+		if wait := time.Until(start.Add(time.Duration(n+1) * interval)); wait > 0 {
+			timer.Reset(wait)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-timer.C:
+			}
+		}
+	}
+	return nil
+}
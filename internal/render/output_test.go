@@ -0,0 +1,79 @@
+// internal/render/output_test.go
+
+package render
+
+import (
+	"context"
+	"image"
+	"testing"
+	"time"
+)
+
+// slowSink zählt die Bilder und braucht für jedes every-te Bild delay
+type slowSink struct {
+	frames int
+	every  int
+	delay  time.Duration
+}
+
+func (s *slowSink) WriteFrame(*image.RGBA) error {
+	s.frames++
+	if s.every > 0 && s.frames%s.every == 0 {
+		time.Sleep(s.delay)
+	}
+	return nil
+}
+
+func (s *slowSink) Close() error { return nil }
+
+func TestRunKeepsFrameRate(t *testing.T) {
+	const fps = 100
+	tests := []struct {
+		name  string
+		every int
+		delay time.Duration
+	}{
+		{"schnell", 0, 0},
+		{"langsamer Empfänger", 5, 35 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &slowSink{every: tt.every, delay: tt.delay}
+			rendered := 0
+			canvas := image.NewRGBA(image.Rect(0, 0, 4, 4))
+			next := func() (*image.RGBA, error) {
+				rendered++
+				return canvas, nil
+			}
+
+			duration := 500 * time.Millisecond
+			ctx, cancel := context.WithTimeout(context.Background(), duration)
+			defer cancel()
+			start := time.Now()
+			if err := Run(ctx, fps, 0, next, sink); err != nil {
+				t.Fatal(err)
+			}
+
+			// Bilder entsprechen der vergangenen Zeit, nicht der Zahl der Ticks
+			want := int(time.Since(start) * fps / time.Second)
+			if sink.frames < want-3 || sink.frames > want+3 {
+				t.Errorf("%d Bilder in %v, erwartet etwa %d", sink.frames, time.Since(start), want)
+			}
+			if tt.every > 0 && rendered >= sink.frames {
+				t.Errorf("%d Bilder gerendert, keine Wiederholung bei %d geschriebenen", rendered, sink.frames)
+			}
+		})
+	}
+}
+
+func TestRunFrameLimit(t *testing.T) {
+	sink := &slowSink{}
+	canvas := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	err := Run(context.Background(), 1000, 10, func() (*image.RGBA, error) { return canvas, nil }, sink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sink.frames != 10 {
+		t.Errorf("%d Bilder, erwartet 10", sink.frames)
+	}
+}
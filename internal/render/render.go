@@ -0,0 +1,322 @@
+// internal/render/render.go
+
+package render
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	_ "image/png"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/live"
+	"github.com/KernTom/scoreboard-manager/internal/logo"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// Frame enthält die anzuzeigenden Daten eines Bildes
+type Frame struct {
+	State        models.LiveState
+	HomeLogo     image.Image // nil: stattdessen wird der Teamname gezeichnet
+	AwayLogo     image.Image
+	HomeLogoHash string // Team.LogoHash, Schlüssel der skalierten Logos im Cache
+	AwayLogoHash string
+	Transparent  bool // ohne Hintergrundfarbe, nur Texte und Logos
+}
+
+// defaultSize gilt für Templates ohne Schriftgröße (ältere Datensätze)
+const defaultSize = 24
+
+// drawMu schützt die Faces, die nicht nebenläufig benutzt werden dürfen
+var drawMu sync.Mutex
+
+// Render zeichnet das Scoreboard wie die Vorschau der Admin-Oberfläche:
+// Uhr oben, darunter Logo – Spielstand – Logo und die Periode unten
+func Render(t *models.TemplateSettings, f Frame) (*image.RGBA, error) {
+	if t.Width <= 0 || t.Height <= 0 {
+		return nil, i18n.Errorf("error.render.size", t.Width, t.Height)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, t.Width, t.Height))
+	if !f.Transparent {
+		draw.Draw(img, img.Bounds(), image.NewUniform(parseColor(t.BackgroundFontColor, color.Black)), image.Point{}, draw.Src)
+	}
+
+	drawMu.Lock()
+	defer drawMu.Unlock()
+
+	if err := registerFonts(t); err != nil {
+		return nil, err
+	}
+
+	if len(t.Elements) > 0 {
+		if err := drawElements(img, t, f); err != nil {
+			return nil, err
+		}
+		return img, nil
+	}
+
+	var rows []row
+
+	if t.ShowGameclock || t.ShowClock {
+		r, err := textRow(clockText(t, f.State), t.ClockFontFamily, t.ClockFontSize, clockColor(t, f.State))
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, r)
+	}
+
+	score, err := scoreRow(t, f)
+	if err != nil {
+		return nil, err
+	}
+	rows = append(rows, score)
+
+	if t.ShowPeriod {
+		r, err := textRow(periodText(t, f.State), t.PeriodFontFamily, t.PeriodFontSize, t.PeriodFontColor)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, r)
+	}
+
+	layout(img, rows)
+	return img, nil
+}
+
+// clockText liefert Spieluhr bzw. echte Uhrzeit
+func clockText(t *models.TemplateSettings, s models.LiveState) string {
+	text := s.ClockText
+	if t.ShowClock {
+		text = s.UpdatedAt.Format("15:04")
+	}
+	if text == "" {
+		text = "00:00"
+	}
+	return text
+}
+
+// clockColor färbt die Uhr in der Nachspielzeit um
+func clockColor(t *models.TemplateSettings, s models.LiveState) string {
+	if s.Overtime && t.ShowGameclock {
+		return t.ExtraTimeFontColor
+	}
+	return t.ClockFontColor
+}
+
+func periodText(t *models.TemplateSettings, s models.LiveState) string {
+	if s.PeriodText == "" {
+		return t.PeriodLabel
+	}
+	return s.PeriodText
+}
+
+// row ist eine Zeile des Scoreboards aus nebeneinander liegenden Teilen
+type row struct {
+	parts  []part
+	height int
+}
+
+type part struct {
+	text   string
+	face   font.Face
+	color  color.Color
+	image  image.Image
+	hash   string // Inhalts-Hash von image für logo.Scaled
+	width  int
+	ascent int
+}
+
+func textPart(text, family string, size int, hex string) (part, error) {
+	if size <= 0 {
+		size = defaultSize
+	}
+	fc, err := face(family, size)
+	if err != nil {
+		return part{}, err
+	}
+	m := fc.Metrics()
+	return part{
+		text:   text,
+		face:   fc,
+		color:  parseColor(hex, color.White),
+		width:  font.MeasureString(fc, text).Ceil(),
+		ascent: m.Ascent.Ceil(),
+	}, nil
+}
+
+func textRow(text, family string, size int, hex string) (row, error) {
+	p, err := textPart(text, family, size, hex)
+	if err != nil {
+		return row{}, err
+	}
+	return row{parts: []part{p}, height: lineHeight(p.face)}, nil
+}
+
+func lineHeight(fc font.Face) int {
+	m := fc.Metrics()
+	return (m.Ascent + m.Descent).Ceil()
+}
+
+// scoreRow baut "Logo  7 : 3  Logo"; fehlende Logos werden durch Namen ersetzt
+func scoreRow(t *models.TemplateSettings, f Frame) (row, error) {
+	s := f.State
+	home, err := textPart(strconv.Itoa(s.HomeScore), t.ScoreFontFamily, t.ScoreFontSize, t.ScoreFontColor)
+	if err != nil {
+		return row{}, err
+	}
+	sep, err := textPart(" : ", t.SeparatorFontFamily, t.SeparatorFontSize, t.SeparatorFontColor)
+	if err != nil {
+		return row{}, err
+	}
+	away, err := textPart(strconv.Itoa(s.AwayScore), t.ScoreFontFamily, t.ScoreFontSize, t.ScoreFontColor)
+	if err != nil {
+		return row{}, err
+	}
+
+	height := max(lineHeight(home.face), lineHeight(sep.face))
+	// Logos sind quadratisch, höchstens so hoch wie die Zeile bzw. ein Viertel der Breite
+	logoSize := min(max(height, t.Height/3), t.Width/4)
+
+	gap := part{width: max(t.Width/20, 8)}
+	// Namen teilen sich den Platz neben dem Spielstand
+	nameWidth := (t.Width - home.width - sep.width - away.width - 2*gap.width) / 2
+
+	side := func(logo image.Image, hash, name string, variants []string) (part, error) {
+		if logo != nil {
+			return part{image: logo, hash: hash, width: logoSize}, nil
+		}
+		name = FitName(variants, name, t.PeriodFontFamily, t.PeriodFontSize, nameWidth)
+		return textPart(name, t.PeriodFontFamily, t.PeriodFontSize, t.ScoreFontColor)
+	}
+	left, err := side(f.HomeLogo, f.HomeLogoHash, s.HomeName, s.HomeNames)
+	if err != nil {
+		return row{}, err
+	}
+	right, err := side(f.AwayLogo, f.AwayLogoHash, s.AwayName, s.AwayNames)
+	if err != nil {
+		return row{}, err
+	}
+	if left.image != nil || right.image != nil {
+		height = max(height, logoSize)
+	}
+
+	return row{parts: []part{left, gap, home, sep, away, gap, right}, height: height}, nil
+}
+
+// layout verteilt die Zeilen wie ein VBox-Layout gleichmäßig über die Höhe
+// und zentriert jede Zeile horizontal
+func layout(img *image.RGBA, rows []row) {
+	bounds := img.Bounds()
+	total := 0
+	for _, r := range rows {
+		total += r.height
+	}
+	spacing := max((bounds.Dy()-total)/(len(rows)+1), 0)
+
+	y := spacing
+	for _, r := range rows {
+		width := 0
+		for _, p := range r.parts {
+			width += p.width
+		}
+		x := (bounds.Dx() - width) / 2
+		for _, p := range r.parts {
+			switch {
+			case p.image != nil:
+				drawLogo(img, p.image, p.hash, image.Rect(x, y+(r.height-p.width)/2, x+p.width, y+(r.height+p.width)/2))
+			case p.face != nil:
+				baseline := y + (r.height-lineHeight(p.face))/2 + p.ascent
+				d := font.Drawer{Dst: img, Src: image.NewUniform(p.color), Face: p.face, Dot: fixed.P(x, baseline)}
+				d.DrawString(p.text)
+			}
+			x += p.width
+		}
+		y += r.height + spacing
+	}
+}
+
+// drawLogo skaliert das Logo seitenverhältnistreu in box; skalierte
+// Fassungen kommen über hash aus dem gemeinsamen Logo-Cache
+func drawLogo(dst *image.RGBA, src image.Image, hash string, box image.Rectangle) {
+	b := src.Bounds()
+	if b.Empty() || box.Empty() {
+		return
+	}
+	w, h := box.Dx(), box.Dy()
+	if b.Dx()*h > b.Dy()*w {
+		h = b.Dy() * w / b.Dx()
+	} else {
+		w = b.Dx() * h / b.Dy()
+	}
+	if w <= 0 || h <= 0 {
+		return
+	}
+	x := box.Min.X + (box.Dx()-w)/2
+	y := box.Min.Y + (box.Dy()-h)/2
+	draw.Draw(dst, image.Rect(x, y, x+w, y+h), logo.Scaled(hash, src, w, h), image.Point{}, draw.Over)
+}
+
+// Thumbnail verkleinert ein Bild auf höchstens maxWidth Pixel Breite
+func Thumbnail(src image.Image, maxWidth int) (*image.RGBA, error) {
+	if maxWidth <= 0 {
+		return nil, i18n.Errorf("error.render.width")
+	}
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w > maxWidth {
+		h = max(h*maxWidth/w, 1)
+		w = maxWidth
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.ApproxBiLinear.Scale(dst, dst.Bounds(), src, b, draw.Src, nil)
+	return dst, nil
+}
+
+// DecodeLogo dekodiert gespeicherte Logodaten; ungültige oder fehlende liefern nil
+func DecodeLogo(data []byte) image.Image {
+	if len(data) == 0 {
+		return nil
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+	return img
+}
+
+// SampleFrame liefert feste Beispieldaten, z.B. für Vorschaubilder
+func SampleFrame(t *models.TemplateSettings) Frame {
+	return Frame{State: models.LiveState{
+		HomeName:   "Heim",
+		AwayName:   "Gast",
+		HomeScore:  7,
+		AwayScore:  3,
+		Period:     1,
+		PeriodText: live.PeriodText(t, 1, models.MatchLive),
+		ClockText:  "00:00",
+		UpdatedAt:  time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+	}}
+}
+
+// parseColor liest Farbcodes wie "#FFFFFF"; ungültige liefern def
+func parseColor(s string, def color.Color) color.Color {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(s) != 6 {
+		return def
+	}
+	rgb, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return def
+	}
+	return color.RGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 0xFF}
+}
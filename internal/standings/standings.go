@@ -0,0 +1,205 @@
+// internal/standings/standings.go
+
+package standings
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// Load berechnet die Tabelle eines Wettbewerbs mit den Regeln der Sportart
+func Load(competition, sportart string) (*models.StandingsTable, error) {
+	sport, err := database.LoadSport(sportart)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := database.LoadMatchesByCompetition(competition)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []*models.Match
+	for _, m := range matches {
+		if m.Sportart == sportart {
+			filtered = append(filtered, m)
+		}
+	}
+
+	table := Compute(filtered, sport.StandingsRules())
+	table.Competition = competition
+	table.Sportart = sportart
+	return table, nil
+}
+
+// Compute berechnet die sortierte Tabelle aus den Matches.
+// Nicht beendete Matches werden ignoriert, die Teams erscheinen trotzdem mit 0 Spielen.
+func Compute(matches []*models.Match, rules models.StandingsRules) *models.StandingsTable {
+	rows := map[int]*models.StandingsRow{}
+	var order []int
+
+	row := func(team *models.Team) *models.StandingsRow {
+		r, ok := rows[team.ID]
+		if !ok {
+			r = &models.StandingsRow{Team: team}
+			rows[team.ID] = r
+			order = append(order, team.ID)
+		}
+		return r
+	}
+
+	var played []*models.Match
+	for _, m := range matches {
+		// Turnierspiele mit noch offenen Teams zählen nicht
+		if m.Team1 == nil || m.Team2 == nil || m.Team1.ID == 0 || m.Team2.ID == 0 {
+			continue
+		}
+		home := row(m.Team1)
+		away := row(m.Team2)
+		if !m.IsFinal() {
+			continue
+		}
+		played = append(played, m)
+		addResult(home, *m.ScoreHome, *m.ScoreAway, rules)
+		addResult(away, *m.ScoreAway, *m.ScoreHome, rules)
+	}
+
+	table := &models.StandingsTable{RankingMode: rules.RankingMode}
+	for _, id := range order {
+		r := rows[id]
+		r.WinPercentage = winPercentage(r.Won, r.Drawn, r.Played)
+		table.Rows = append(table.Rows, r)
+	}
+
+	// Grundsortierung nach Punkten bzw. Siegquote, danach Tie-Breaker je Gruppe
+	keys := sortKeys{}
+	table.Rows = rank(table.Rows, primaryKey(rules), rules, played, 0, keys)
+
+	for i, r := range table.Rows {
+		r.Rank = i + 1
+		if i > 0 && keys.tied(table.Rows[i-1], r) {
+			r.Rank = table.Rows[i-1].Rank
+		}
+	}
+	return table
+}
+
+func addResult(r *models.StandingsRow, scored, conceded int, rules models.StandingsRules) {
+	r.Played++
+	r.GoalsFor += scored
+	r.GoalsAgainst += conceded
+	switch {
+	case scored > conceded:
+		r.Won++
+		r.Points += rules.PointsWin
+	case scored == conceded:
+		r.Drawn++
+		r.Points += rules.PointsDraw
+	default:
+		r.Lost++
+		r.Points += rules.PointsLoss
+	}
+}
+
+func primaryKey(rules models.StandingsRules) func(*models.StandingsRow) float64 {
+	if rules.RankingMode == models.RankingWinPercentage {
+		return func(r *models.StandingsRow) float64 { return r.WinPercentage }
+	}
+	return func(r *models.StandingsRow) float64 { return float64(r.Points) }
+}
+
+// Unentschieden zählen als halber Sieg (NFL-Wertung)
+func winPercentage(won, drawn, played int) float64 {
+	if played == 0 {
+		return 0
+	}
+	return (float64(won) + 0.5*float64(drawn)) / float64(played)
+}
+
+// sortKeys hält je Team die Schlüssel, nach denen rank sortiert hat
+type sortKeys map[int][]float64
+
+// tied prüft, ob zwei benachbarte Zeilen nach allen Kriterien gleich sind.
+// Verglichen werden die Schlüssel aus rank, denn der direkte Vergleich
+// hängt davon ab, welche Teams gemeinsam punktgleich waren.
+func (k sortKeys) tied(a, b *models.StandingsRow) bool {
+	return slices.Equal(k[a.Team.ID], k[b.Team.ID])
+}
+
+// rank sortiert die Gruppe absteigend nach key und löst Gleichstände
+// rekursiv mit dem nächsten Tie-Breaker auf. Die verwendeten Schlüssel
+// landen in keys.
+func rank(group []*models.StandingsRow, key func(*models.StandingsRow) float64, rules models.StandingsRules, played []*models.Match, next int, keys sortKeys) []*models.StandingsRow {
+	sort.SliceStable(group, func(i, j int) bool {
+		ki, kj := key(group[i]), key(group[j])
+		if ki != kj {
+			return ki > kj
+		}
+		return group[i].Team.Name < group[j].Team.Name
+	})
+	for _, r := range group {
+		keys[r.Team.ID] = append(keys[r.Team.ID], key(r))
+	}
+
+	var result []*models.StandingsRow
+	for start := 0; start < len(group); {
+		end := start + 1
+		for end < len(group) && key(group[end]) == key(group[start]) {
+			end++
+		}
+		sub := group[start:end]
+		if len(sub) > 1 && next < len(rules.TieBreakers) {
+			subKey := tieBreakKey(rules.TieBreakers[next], sub, rules, played)
+			sub = rank(append([]*models.StandingsRow(nil), sub...), subKey, rules, played, next+1, keys)
+		}
+		result = append(result, sub...)
+		start = end
+	}
+	return result
+}
+
+func tieBreakKey(name string, group []*models.StandingsRow, rules models.StandingsRules, played []*models.Match) func(*models.StandingsRow) float64 {
+	switch name {
+	case models.TieBreakHeadToHead:
+		mini := headToHead(group, rules, played)
+		return func(r *models.StandingsRow) float64 { return mini[r.Team.ID] }
+	case models.TieBreakGoalDifference:
+		return func(r *models.StandingsRow) float64 { return float64(r.GoalDifference()) }
+	case models.TieBreakGoalsFor:
+		return func(r *models.StandingsRow) float64 { return float64(r.GoalsFor) }
+	default:
+		return func(*models.StandingsRow) float64 { return 0 }
+	}
+}
+
+// headToHead wertet nur die Spiele der punktgleichen Teams untereinander
+func headToHead(group []*models.StandingsRow, rules models.StandingsRules, played []*models.Match) map[int]float64 {
+	inGroup := map[int]bool{}
+	for _, r := range group {
+		inGroup[r.Team.ID] = true
+	}
+
+	mini := map[int]*models.StandingsRow{}
+	for _, r := range group {
+		mini[r.Team.ID] = &models.StandingsRow{Team: r.Team}
+	}
+	for _, m := range played {
+		if !inGroup[m.Team1.ID] || !inGroup[m.Team2.ID] {
+			continue
+		}
+		addResult(mini[m.Team1.ID], *m.ScoreHome, *m.ScoreAway, rules)
+		addResult(mini[m.Team2.ID], *m.ScoreAway, *m.ScoreHome, rules)
+	}
+
+	keys := map[int]float64{}
+	for id, r := range mini {
+		if rules.RankingMode == models.RankingWinPercentage {
+			keys[id] = winPercentage(r.Won, r.Drawn, r.Played)
+		} else {
+			keys[id] = float64(r.Points)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,138 @@
+// internal/standings/standings_test.go
+
+package standings
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// results baut beendete Spiele aus Zeilen wie "A 2:1 B"
+func results(t *testing.T, lines ...string) []*models.Match {
+	t.Helper()
+	teams := map[string]*models.Team{}
+	team := func(name string) *models.Team {
+		if teams[name] == nil {
+			teams[name] = &models.Team{ID: len(teams) + 1, Name: name}
+		}
+		return teams[name]
+	}
+
+	var matches []*models.Match
+	for _, l := range lines {
+		var home, away string
+		var sh, sa int
+		if _, err := fmt.Sscanf(l, "%s %d:%d %s", &home, &sh, &sa, &away); err != nil {
+			t.Fatalf("%q: %v", l, err)
+		}
+		matches = append(matches, &models.Match{
+			Team1: team(home), Team2: team(away),
+			ScoreHome: &sh, ScoreAway: &sa,
+			Status: models.MatchFinished,
+		})
+	}
+	return matches
+}
+
+// ranking gibt die Tabelle kompakt als "1 A, 2 B, ..." aus
+func ranking(table *models.StandingsTable) string {
+	var parts []string
+	for _, r := range table.Rows {
+		parts = append(parts, fmt.Sprintf("%d %s", r.Rank, r.Team.Name))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func TestCompute(t *testing.T) {
+	football := models.StandingsRules{PointsWin: 3, PointsDraw: 1, RankingMode: models.RankingPoints}
+	withBreakers := func(breakers ...string) models.StandingsRules {
+		r := football
+		r.TieBreakers = breakers
+		return r
+	}
+
+	tests := []struct {
+		name    string
+		rules   models.StandingsRules
+		matches []string
+		want    string
+	}{
+		{
+			name:    "Punkte",
+			rules:   football,
+			matches: []string{"A 1:0 B", "B 2:0 C", "A 0:0 C"},
+			want:    "1 A, 2 B, 3 C",
+		},
+		{
+			name:    "punktgleich ohne Tie-Breaker",
+			rules:   football,
+			matches: []string{"A 1:0 C", "B 3:0 C"},
+			want:    "1 A, 1 B, 3 C",
+		},
+		{
+			name:    "Tordifferenz",
+			rules:   withBreakers(models.TieBreakGoalDifference),
+			matches: []string{"A 1:0 C", "B 3:0 C"},
+			want:    "1 B, 2 A, 3 C",
+		},
+		{
+			name:    "direkter Vergleich vor Tordifferenz",
+			rules:   withBreakers(models.TieBreakHeadToHead, models.TieBreakGoalDifference),
+			matches: []string{"A 1:0 B", "B 5:0 C", "D 1:0 A", "D 2:0 C"},
+			want:    "1 D, 2 A, 3 B, 4 C",
+		},
+		{
+			name:    "Tordifferenz vor direktem Vergleich",
+			rules:   withBreakers(models.TieBreakGoalDifference, models.TieBreakHeadToHead),
+			matches: []string{"A 1:0 B", "B 5:0 C", "D 1:0 A", "D 2:0 C"},
+			want:    "1 D, 2 B, 3 A, 4 C",
+		},
+		{
+			// im Dreiervergleich sind alle gleich, paarweise hätte A gegen B gewonnen
+			name:    "Dreiervergleich ohne Sieger",
+			rules:   withBreakers(models.TieBreakHeadToHead, models.TieBreakGoalDifference, models.TieBreakGoalsFor),
+			matches: []string{"A 1:0 B", "B 1:0 C", "C 1:0 A"},
+			want:    "1 A, 1 B, 1 C",
+		},
+		{
+			name:    "Tore bei gleicher Tordifferenz",
+			rules:   withBreakers(models.TieBreakGoalDifference, models.TieBreakGoalsFor),
+			matches: []string{"A 3:2 C", "B 1:0 C"},
+			want:    "1 A, 2 B, 3 C",
+		},
+		{
+			// D hat nur ein Spiel, aber die beste Quote
+			name:    "Siegquote mit Unentschieden",
+			rules:   models.StandingsRules{RankingMode: models.RankingWinPercentage},
+			matches: []string{"A 1:1 B", "A 2:0 C", "B 3:0 C", "B 0:1 D"},
+			want:    "1 D, 2 A, 3 B, 4 C",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := Compute(results(t, tt.matches...), tt.rules)
+			if got := ranking(table); got != tt.want {
+				t.Errorf("Tabelle %q, erwartet %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeIgnoresOpenMatches(t *testing.T) {
+	matches := results(t, "A 1:0 B")
+	open := &models.Match{Team1: matches[0].Team1, Team2: &models.Team{ID: 9, Name: "C"}, Status: models.MatchScheduled}
+	tbd := &models.Match{Team1: matches[0].Team2, Team2: &models.Team{}, Status: models.MatchScheduled}
+
+	table := Compute(append(matches, open, tbd), models.StandingsRules{PointsWin: 3})
+	if got, want := ranking(table), "1 A, 2 B, 2 C"; got != want {
+		t.Errorf("Tabelle %q, erwartet %q", got, want)
+	}
+	for _, r := range table.Rows {
+		if r.Team.Name == "C" && r.Played != 0 {
+			t.Errorf("C hat %d Spiele, erwartet 0", r.Played)
+		}
+	}
+}
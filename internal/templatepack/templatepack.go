@@ -0,0 +1,342 @@
+// internal/templatepack/templatepack.go
+
+// Package templatepack exportiert Templates samt Layout-Elementen, Schriften
+// und Bildern als weitergebbare Datei und installiert solche Pakete wieder.
+//
+// Es gibt zwei gleichwertige Formen:
+//   - JSON: eine Datei, die Dateien stecken base64-codiert in "Assets"
+//   - ZIP: template.json als Manifest, die Dateien unter assets/<Name>
+package templatepack
+
+import (
+	"archive/zip"
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+	"github.com/KernTom/scoreboard-manager/internal/render"
+)
+
+const (
+	Format  = "scoreboard-template"
+	Version = 1 // aktuelle Formatversion; neuere Pakete werden abgelehnt
+
+	MaxAssetSize   = 10 << 20
+	MaxPackageSize = 50 << 20
+
+	manifestName = "template.json"
+	assetDir     = "assets/"
+)
+
+// Package ist der Inhalt einer Paketdatei. IDs werden beim Export entfernt
+// und beim Installieren neu vergeben.
+type Package struct {
+	Format   string
+	Version  int
+	Exported time.Time
+	Template *models.TemplateSettings // ohne Assets, die stehen in Assets
+	Assets   []Asset
+}
+
+// Asset ist eine mitgelieferte Schrift oder ein Bild
+type Asset struct {
+	Name   string
+	Kind   string // models.AssetFont oder models.AssetImage
+	SHA256 string
+	Data   []byte `json:",omitempty"` // nur in der JSON-Form
+}
+
+// New erstellt ein Paket aus einem Template; abgeleitete Templates vorher
+// mit database.FlattenTemplate auflösen
+func New(t *models.TemplateSettings) *Package {
+	tpl := *t
+	tpl.ID, tpl.ParentID, tpl.Overrides = 0, 0, nil
+	tpl.Assets = nil
+	tpl.Elements = make([]*models.LayoutElement, 0, len(t.Elements))
+	for _, e := range t.Elements {
+		c := *e
+		c.ID, c.TemplateID = 0, 0
+		tpl.Elements = append(tpl.Elements, &c)
+	}
+
+	p := &Package{Format: Format, Version: Version, Exported: time.Now().UTC().Truncate(time.Second), Template: &tpl}
+	for _, a := range t.Assets {
+		sum := sha256.Sum256(a.Data)
+		p.Assets = append(p.Assets, Asset{Name: a.Name, Kind: a.Kind, SHA256: hex.EncodeToString(sum[:]), Data: a.Data})
+	}
+	return p
+}
+
+// WriteJSON schreibt das Paket als einzelne JSON-Datei
+func (p *Package) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p)
+}
+
+// WriteZIP schreibt das Paket als ZIP-Archiv
+func (p *Package) WriteZIP(w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	manifest := *p
+	manifest.Assets = make([]Asset, len(p.Assets))
+	for i, a := range p.Assets {
+		a.Data = nil
+		manifest.Assets[i] = a
+	}
+	mw, err := zw.Create(manifestName)
+	if err != nil {
+		return err
+	}
+	if err := manifest.WriteJSON(mw); err != nil {
+		return err
+	}
+
+	for _, a := range p.Assets {
+		aw, err := zw.Create(assetDir + a.Name)
+		if err != nil {
+			return err
+		}
+		if _, err := aw.Write(a.Data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// Decode liest ein Paket in JSON- oder ZIP-Form und prüft es
+func Decode(data []byte) (*Package, error) {
+	if len(data) > MaxPackageSize {
+		return nil, i18n.Errorf("error.templatepack.size", MaxPackageSize>>20)
+	}
+
+	var p *Package
+	var err error
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		p, err = decodeZIP(data)
+	} else {
+		p, err = decodeManifest(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// decodeManifest prüft zuerst Format und Version, damit Dateien neuerer
+// Versionen eine verständliche Meldung statt "unknown field" liefern
+func decodeManifest(data []byte) (*Package, error) {
+	var head struct {
+		Format  string
+		Version int
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, i18n.Errorf("error.templatepack.not_a_package", err)
+	}
+	if head.Format != Format {
+		return nil, i18n.Errorf("error.templatepack.format", head.Format)
+	}
+	if head.Version < 1 || head.Version > Version {
+		return nil, i18n.Errorf("error.templatepack.version", head.Version, Version)
+	}
+
+	var p Package
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&p); err != nil {
+		return nil, i18n.Errorf("error.templatepack.invalid", err)
+	}
+	return &p, nil
+}
+
+func decodeZIP(data []byte) (*Package, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, i18n.Errorf("error.templatepack.zip", err)
+	}
+	files := map[string]*zip.File{}
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	mf, ok := files[manifestName]
+	if !ok {
+		return nil, i18n.Errorf("error.templatepack.no_manifest", manifestName)
+	}
+	manifest, err := readFile(mf)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decodeManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range p.Assets {
+		a := &p.Assets[i]
+		f, ok := files[assetDir+a.Name]
+		if !ok {
+			return nil, i18n.Errorf("error.templatepack.file_missing", a.Name)
+		}
+		if a.Data, err = readFile(f); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+func readFile(f *zip.File) ([]byte, error) {
+	if f.UncompressedSize64 > MaxAssetSize {
+		return nil, i18n.Errorf("error.templatepack.asset_size", f.Name, MaxAssetSize>>20)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	// die Größe im ZIP-Verzeichnis ist nicht vertrauenswürdig
+	data, err := io.ReadAll(io.LimitReader(rc, MaxAssetSize+1))
+	if err != nil {
+		return nil, i18n.Errorf("error.wrap.named", f.Name, err)
+	}
+	if len(data) > MaxAssetSize {
+		return nil, i18n.Errorf("error.templatepack.asset_size", f.Name, MaxAssetSize>>20)
+	}
+	return data, nil
+}
+
+// Validate prüft Format, Template, Dateien und Layout
+func (p *Package) Validate() error {
+	if p.Format != Format {
+		return i18n.Errorf("error.templatepack.format", p.Format)
+	}
+	if p.Version < 1 || p.Version > Version {
+		return i18n.Errorf("error.templatepack.version", p.Version, Version)
+	}
+	t := p.Template
+	if t == nil {
+		return i18n.Errorf("error.templatepack.no_template")
+	}
+	if strings.TrimSpace(t.Name) == "" {
+		return i18n.Errorf("error.templatepack.name")
+	}
+	if t.Width <= 0 || t.Height <= 0 {
+		return i18n.Errorf("error.render.size", t.Width, t.Height)
+	}
+
+	var names []string
+	for _, a := range p.Assets {
+		if a.Name == "" || a.Name != path.Base(a.Name) || strings.ContainsAny(a.Name, `\/:`) || strings.HasPrefix(a.Name, ".") {
+			return i18n.Errorf("error.templatepack.file_name", a.Name)
+		}
+		key := strings.ToLower(a.Name)
+		if slices.Contains(names, key) {
+			return i18n.Errorf("error.templatepack.file_duplicate", a.Name)
+		}
+		names = append(names, key)
+		if len(a.Data) > MaxAssetSize {
+			return i18n.Errorf("error.templatepack.file_size", a.Name, MaxAssetSize>>20)
+		}
+		if a.SHA256 != "" {
+			sum := sha256.Sum256(a.Data)
+			if !strings.EqualFold(a.SHA256, hex.EncodeToString(sum[:])) {
+				return i18n.Errorf("error.templatepack.checksum", a.Name)
+			}
+		}
+		if err := render.ValidateAsset(&models.TemplateAsset{Name: a.Name, Kind: a.Kind, Data: a.Data}); err != nil {
+			return err
+		}
+	}
+	resolved := p.TemplateSettings()
+	if err := resolved.ApplyTheme(); err != nil {
+		return err
+	}
+	if err := render.ValidateFonts(resolved); err != nil {
+		return err
+	}
+	return render.ValidateLayout(resolved)
+}
+
+// TemplateSettings liefert das Template samt Dateien, ohne IDs
+func (p *Package) TemplateSettings() *models.TemplateSettings {
+	t := *p.Template
+	t.ID, t.ParentID, t.Overrides = 0, 0, nil
+	t.Elements = nil
+	for _, e := range p.Template.Elements {
+		c := *e
+		c.ID, c.TemplateID = 0, 0
+		t.Elements = append(t.Elements, &c)
+	}
+	t.Assets = nil
+	for _, a := range p.Assets {
+		t.Assets = append(t.Assets, &models.TemplateAsset{Name: a.Name, Kind: a.Kind, Data: a.Data})
+	}
+	return &t
+}
+
+// Install legt das Template aus dem Paket an. Bei Namensgleichheit
+// entscheidet policy, ohne Angabe gilt models.ConflictRename; bei
+// models.ConflictSkip wird das vorhandene Template geliefert. IDs von
+// Template, Elementen und Dateien werden neu vergeben bzw. vom überschriebenen
+// Template übernommen.
+func Install(p *Package, policy string) (*models.TemplateSettings, error) {
+	policy = cmp.Or(policy, models.ConflictRename)
+	if err := models.ValidateConflictPolicy(policy); err != nil {
+		return nil, err
+	}
+	t := p.TemplateSettings()
+
+	err := database.InTx(func(tx *database.Tx) error {
+		existing, err := tx.LoadTemplates()
+		if err != nil {
+			return err
+		}
+		if old := findByName(existing, t.Name); old != nil {
+			switch policy {
+			case models.ConflictSkip:
+				t = old
+				return nil
+			case models.ConflictRename:
+				t.Name = models.UniqueName(t.Name, func(n string) bool { return findByName(existing, n) != nil })
+			case models.ConflictReplace:
+				t.ID = old.ID
+			case models.ConflictFail:
+				return i18n.Errorf("error.templatepack.exists", t.Name)
+			}
+		}
+		// alles oder nichts, kein halbes Template zurücklassen
+		if err := tx.SaveTemplate(t); err != nil {
+			return err
+		}
+		if err := tx.SaveTemplateElements(t.ID, t.Elements); err != nil {
+			return err
+		}
+		return tx.SaveTemplateAssets(t.ID, t.Assets)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func findByName(templates []*models.TemplateSettings, name string) *models.TemplateSettings {
+	for _, t := range templates {
+		if strings.EqualFold(t.Name, name) {
+			return t
+		}
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+// internal/tournament/advance.go
+
+package tournament
+
+import (
+	"cmp"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// FinalizeMatch speichert den Endstand, beendet das Spiel und trägt Sieger
+// bzw. Verlierer automatisch in die verknüpften Folgespiele ein. Nachträglich
+// eingetragene Ergebnisse geplanter Spiele durchlaufen dabei den Status "live".
+// Alles geschieht in einer Transaktion: scheitert das Eintragen ins
+// Folgespiel, bleibt auch das Spiel selbst unverändert.
+func FinalizeMatch(m *models.Match, scoreHome, scoreAway int) error {
+	if isKnockout(m) && scoreHome == scoreAway {
+		return i18n.Errorf("error.knockout_winner")
+	}
+
+	f := *m
+	f.ScoreHome = &scoreHome
+	f.ScoreAway = &scoreAway
+	err := database.InTx(func(tx *database.Tx) error {
+		if err := tx.SaveMatches(&f); err != nil {
+			return err
+		}
+		if f.Status == models.MatchScheduled {
+			if err := tx.SetMatchStatus(f.ID, models.MatchLive); err != nil {
+				return err
+			}
+		}
+		if err := tx.SetMatchStatus(f.ID, models.MatchFinished); err != nil {
+			return err
+		}
+		f.Status = models.MatchFinished
+		return advance(tx, &f)
+	})
+	if err != nil {
+		return err
+	}
+	*m = f
+	return nil
+}
+
+// CorrectResult korrigiert den Endstand eines bereits beendeten Spiels per
+// protokolliertem Override. Ein geänderter Sieger wird im Folgespiel ersetzt,
+// solange dieses noch nicht beendet ist.
+func CorrectResult(m *models.Match, scoreHome, scoreAway int, reason, actor string) error {
+	if isKnockout(m) && scoreHome == scoreAway {
+		return i18n.Errorf("error.knockout_winner")
+	}
+
+	f := *m
+	f.ScoreHome = &scoreHome
+	f.ScoreAway = &scoreAway
+	f.Status = models.MatchFinished
+	err := database.InTx(func(tx *database.Tx) error {
+		if err := tx.SaveMatchesOverride(&f, reason, actor); err != nil {
+			return err
+		}
+		return advance(tx, &f)
+	})
+	if err != nil {
+		return err
+	}
+	*m = f
+	return nil
+}
+
+// SaveMatch speichert Änderungen an m und wechselt auf status (leer: Status
+// bleibt). Beendete Spiele lassen sich nur mit reason ändern. Wer ein Spiel
+// beendet oder ein beendetes mit Endstand speichert, geht wie beim Eintragen
+// eines Ergebnisses über FinalizeMatch bzw. CorrectResult; alle anderen
+// Wechsel prüft database.SetMatchStatus.
+func SaveMatch(m *models.Match, status, reason, actor string) error {
+	status = cmp.Or(status, m.Status)
+	finish := status == models.MatchFinished
+	if finish && !m.HasResult() {
+		return i18n.Errorf("error.match_without_result")
+	}
+
+	if m.Status == models.MatchFinished {
+		if reason == "" {
+			return database.ErrMatchFinished
+		}
+		if finish {
+			return CorrectResult(m, *m.ScoreHome, *m.ScoreAway, reason, actor)
+		}
+		m.Status = status
+		return database.SaveMatchesOverride(m, reason, actor)
+	}
+	if finish {
+		return FinalizeMatch(m, *m.ScoreHome, *m.ScoreAway)
+	}
+
+	f := *m
+	err := database.InTx(func(tx *database.Tx) error {
+		if err := tx.SaveMatches(&f); err != nil {
+			return err
+		}
+		if status == f.Status {
+			return nil
+		}
+		if err := tx.SetMatchStatus(f.ID, status); err != nil {
+			return err
+		}
+		f.Status = status
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	*m = f
+	return nil
+}
+
+// Advance setzt Sieger und Verlierer eines beendeten Spiels in die Folgespiele
+func Advance(m *models.Match) error {
+	return database.InTx(func(tx *database.Tx) error {
+		return advance(tx, m)
+	})
+}
+
+func advance(tx *database.Tx, m *models.Match) error {
+	if !isKnockout(m) {
+		return nil
+	}
+	if !m.HasResult() {
+		return i18n.Errorf("error.no_result")
+	}
+	if *m.ScoreHome == *m.ScoreAway {
+		return i18n.Errorf("error.knockout_winner")
+	}
+
+	winner, loser := m.Team1, m.Team2
+	if *m.ScoreAway > *m.ScoreHome {
+		winner, loser = loser, winner
+	}
+
+	if isGrandFinal(m) && winner == m.Team1 {
+		// Der Sieger des Winner-Brackets ist ungeschlagen, das Rückspiel entfällt
+		return cancelReset(tx, m.NextMatchID)
+	}
+	if err := place(tx, m.NextMatchID, m.NextMatchSlot, winner); err != nil {
+		return err
+	}
+	return place(tx, m.LoserNextMatchID, m.LoserNextMatchSlot, loser)
+}
+
+func isKnockout(m *models.Match) bool {
+	return m.Bracket != "" || m.NextMatchID != 0 || m.LoserNextMatchID != 0
+}
+
+// isGrandFinal erkennt das erste Finale einer Double Elimination, dem das
+// Rückspiel (Bracket-Reset) folgt
+func isGrandFinal(m *models.Match) bool {
+	return m.Bracket == models.BracketFinal && m.NextMatchID != 0
+}
+
+// cancelReset sagt das Rückspiel ab; bereits eingetragene Teams bleiben stehen
+func cancelReset(tx *database.Tx, matchID int) error {
+	reset, err := tx.LoadMatch(matchID)
+	if err != nil {
+		return i18n.Errorf("error.next_match_load", matchID, err)
+	}
+	switch reset.Status {
+	case models.MatchAbandoned:
+		return nil
+	case models.MatchScheduled, models.MatchPostponed:
+		return tx.SetMatchStatus(matchID, models.MatchAbandoned)
+	}
+	return i18n.Errorf("error.next_match_finished", matchID)
+}
+
+func place(tx *database.Tx, matchID int, slot string, team *models.Team) error {
+	if matchID == 0 {
+		return nil
+	}
+
+	next, err := tx.LoadMatch(matchID)
+	if err != nil {
+		return i18n.Errorf("error.next_match_load", matchID, err)
+	}
+	switch next.Status {
+	case models.MatchFinished:
+		return i18n.Errorf("error.next_match_finished", matchID)
+	case models.MatchAbandoned:
+		return i18n.Errorf("error.next_match_abandoned", matchID)
+	}
+
+	if slot == models.SlotAway {
+		next.Team2 = team
+	} else {
+		next.Team1 = team
+	}
+	return tx.SaveMatches(next)
+}
@@ -0,0 +1,219 @@
+// internal/tournament/advance_test.go
+
+package tournament
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// openTestDB öffnet eine leere Datenbank und legt n Teams an
+func openTestDB(t *testing.T, n int) []*models.Team {
+	t.Helper()
+	if err := database.Open(filepath.Join(t.TempDir(), "test.db")); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(database.Close)
+
+	var teams []*models.Team
+	for i := 1; i <= n; i++ {
+		team := &models.Team{Name: fmt.Sprintf("Team %d", i), Sportart: "Fußball"}
+		if err := database.SaveTeam(team); err != nil {
+			t.Fatal(err)
+		}
+		teams = append(teams, team)
+	}
+	return teams
+}
+
+// savedPlan erzeugt mit generate einen Plan für teams und speichert ihn
+func savedPlan(t *testing.T, generate func([]*models.Team, Options) (*Plan, error), teams []*models.Team) *Plan {
+	t.Helper()
+	p, err := generate(teams, testOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Save(); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func reload(t *testing.T, m *models.Match) *models.Match {
+	t.Helper()
+	loaded, err := database.LoadMatch(m.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loaded
+}
+
+func TestFinalizeMatchAdvances(t *testing.T) {
+	teams := openTestDB(t, 4)
+	p := savedPlan(t, SingleElimination, teams)
+	semi1, semi2, final := p.Matches[0], p.Matches[1], p.Matches[2]
+
+	if err := FinalizeMatch(semi1, 2, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := FinalizeMatch(semi2, 0, 3); err != nil {
+		t.Fatal(err)
+	}
+	if semi1.Status != models.MatchFinished {
+		t.Errorf("Status %q, erwartet %q", semi1.Status, models.MatchFinished)
+	}
+
+	got := reload(t, final)
+	if got.Team1.ID != semi1.Team1.ID || got.Team2.ID != semi2.Team2.ID {
+		t.Errorf("Finale %d – %d, erwartet %d – %d", got.Team1.ID, got.Team2.ID, semi1.Team1.ID, semi2.Team2.ID)
+	}
+
+	if err := FinalizeMatch(final, 1, 1); err == nil {
+		t.Error("Unentschieden im K.-o.-Spiel akzeptiert")
+	}
+}
+
+func TestFinalizeMatchRollback(t *testing.T) {
+	teams := openTestDB(t, 4)
+	p := savedPlan(t, SingleElimination, teams)
+	semi, final := p.Matches[0], p.Matches[2]
+
+	if err := database.SetMatchStatus(final.ID, models.MatchAbandoned); err != nil {
+		t.Fatal(err)
+	}
+	if err := FinalizeMatch(semi, 2, 1); err == nil {
+		t.Fatal("Folgespiel abgesagt, trotzdem kein Fehler")
+	}
+
+	// weder Ergebnis noch Status des Halbfinales dürfen gespeichert sein
+	got := reload(t, semi)
+	if got.Status != models.MatchScheduled || got.HasResult() {
+		t.Errorf("Halbfinale: Status %q, Ergebnis %v, erwartet unverändert", got.Status, got.HasResult())
+	}
+	if semi.Status != models.MatchScheduled || semi.HasResult() {
+		t.Errorf("Spiel im Speicher verändert: Status %q", semi.Status)
+	}
+}
+
+func TestPlanSaveRollback(t *testing.T) {
+	teams := openTestDB(t, 4)
+	p, err := SingleElimination(teams, testOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// das letzte Spiel verweist auf ein unbekanntes Feld ohne Template
+	last := p.Matches[len(p.Matches)-1]
+	last.TemplateSettings, last.FieldID = nil, 999
+
+	if err := p.Save(); err == nil {
+		t.Fatal("Speichern trotz unbekanntem Feld erfolgreich")
+	}
+	matches, err := database.LoadMatches()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 0 {
+		t.Errorf("%d Spiele gespeichert, erwartet keines", len(matches))
+	}
+	for _, m := range p.Matches {
+		if m.ID != 0 {
+			t.Errorf("Spiel behält ID %d aus der zurückgerollten Transaktion", m.ID)
+		}
+	}
+}
+
+func TestBracketReset(t *testing.T) {
+	tests := []struct {
+		name       string
+		home, away int
+		wantStatus string
+		wantTeams  bool
+	}{
+		{"Winner-Bracket-Sieger gewinnt", 2, 0, models.MatchAbandoned, false},
+		{"Loser-Bracket-Sieger gewinnt", 0, 2, models.MatchScheduled, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			teams := openTestDB(t, 2)
+			p := savedPlan(t, DoubleElimination, teams)
+			if len(p.Matches) != 3 {
+				t.Fatalf("%d Spiele, erwartet 3", len(p.Matches))
+			}
+			first, final, reset := p.Matches[0], p.Matches[1], p.Matches[2]
+
+			if err := FinalizeMatch(first, 1, 0); err != nil {
+				t.Fatal(err)
+			}
+			final = reload(t, final)
+			if final.Team1.ID != teams[0].ID || final.Team2.ID != teams[1].ID {
+				t.Fatalf("Finale %d – %d, erwartet %d – %d", final.Team1.ID, final.Team2.ID, teams[0].ID, teams[1].ID)
+			}
+			if err := FinalizeMatch(final, tt.home, tt.away); err != nil {
+				t.Fatal(err)
+			}
+
+			got := reload(t, reset)
+			if got.Status != tt.wantStatus {
+				t.Errorf("Rückspiel %q, erwartet %q", got.Status, tt.wantStatus)
+			}
+			placed := got.Team1.ID == teams[0].ID && got.Team2.ID == teams[1].ID
+			if placed != tt.wantTeams {
+				t.Errorf("Rückspiel %d – %d, Teams eingetragen: %v, erwartet %v", got.Team1.ID, got.Team2.ID, placed, tt.wantTeams)
+			}
+		})
+	}
+}
+
+func TestSaveMatchFinishAdvances(t *testing.T) {
+	teams := openTestDB(t, 4)
+	p := savedPlan(t, SingleElimination, teams)
+	semi, final := reload(t, p.Matches[0]), p.Matches[2]
+
+	if err := SaveMatch(semi, models.MatchFinished, "", ""); err == nil {
+		t.Fatal("Spiel ohne Endstand beendet")
+	}
+	home, away := 3, 1
+	semi.ScoreHome, semi.ScoreAway = &home, &away
+	if err := SaveMatch(semi, models.MatchFinished, "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if got := reload(t, final); got.Team1.ID != semi.Team1.ID {
+		t.Errorf("Finale mit Team %d, erwartet Sieger %d", got.Team1.ID, semi.Team1.ID)
+	}
+
+	// Korrektur mit Begründung ersetzt den Sieger im Folgespiel
+	semi.ScoreHome, semi.ScoreAway = &away, &home
+	if err := SaveMatch(semi, "", "", ""); err == nil {
+		t.Error("beendetes Spiel ohne Begründung geändert")
+	}
+	if err := SaveMatch(semi, "", "Zahlendreher", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if got := reload(t, final); got.Team1.ID != semi.Team2.ID {
+		t.Errorf("Finale mit Team %d, erwartet neuen Sieger %d", got.Team1.ID, semi.Team2.ID)
+	}
+}
+
+// Eine Begründung umgeht die Statusprüfung nur bei beendeten Spielen
+func TestSaveMatchReasonKeepsTransitions(t *testing.T) {
+	teams := openTestDB(t, 2)
+	m := &models.Match{Sportart: "Fußball", Team1: teams[0], Team2: teams[1],
+		TemplateSettings: &models.TemplateSettings{ID: 1}, Status: models.MatchScheduled}
+	if err := database.SaveMatches(m); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SaveMatch(m, models.MatchAbandoned, "Regen", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveMatch(m, models.MatchScheduled, "doch gespielt", "test"); err == nil {
+		t.Error("abgesagtes Spiel trotz Begründung wieder angesetzt")
+	}
+	if got := reload(t, m); got.Status != models.MatchAbandoned {
+		t.Errorf("Status %q, erwartet %q", got.Status, models.MatchAbandoned)
+	}
+}
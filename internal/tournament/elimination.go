@@ -0,0 +1,211 @@
+// internal/tournament/elimination.go
+
+package tournament
+
+import (
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// source beschreibt, woher ein Team in einem Bracket-Spiel kommt:
+// fest gesetzt, als Sieger/Verlierer eines anderen Spiels oder gar nicht (Freilos)
+type source struct {
+	team  *models.Team
+	from  *node
+	loser bool
+	dead  bool
+}
+
+type node struct {
+	match   *models.Match
+	src     [2]source
+	removed bool
+	pass    source // bei entfernten Spielen: wer kampflos weiterkommt
+}
+
+type bracket struct {
+	opts  Options
+	nodes []*node
+}
+
+func (b *bracket) add(bracketName string, round int, home, away source) *node {
+	n := &node{
+		match: newMatch(b.opts, nil, nil),
+		src:   [2]source{home, away},
+	}
+	n.match.Bracket = bracketName
+	n.match.Round = round
+	b.nodes = append(b.nodes, n)
+	return n
+}
+
+// SingleElimination erzeugt ein K.-o.-Bracket. Die Teams werden in
+// Setzreihenfolge erwartet, die besten Teams erhalten ggf. Freilose.
+func SingleElimination(teams []*models.Team, opts Options) (*Plan, error) {
+	if err := opts.validate(teams); err != nil {
+		return nil, err
+	}
+	b := &bracket{opts: opts}
+	b.winners(teams)
+	return b.plan(), nil
+}
+
+// DoubleElimination erzeugt Winner- und Loser-Bracket sowie das große Finale.
+// Ein Team scheidet erst nach der zweiten Niederlage aus; gewinnt der Sieger
+// des Loser-Brackets das Finale, entscheidet ein zweites Finale (Bracket-Reset).
+func DoubleElimination(teams []*models.Team, opts Options) (*Plan, error) {
+	if err := opts.validate(teams); err != nil {
+		return nil, err
+	}
+	b := &bracket{opts: opts}
+	wb := b.winners(teams)
+	wbFinal := wb[len(wb)-1][0]
+
+	// Loser-Bracket: abwechselnd Einsteiger-Runden (Verlierer aus dem
+	// Winner-Bracket) und Runden, in denen sich die Überlebenden halbieren
+	lbChampion := source{from: wbFinal, loser: true}
+	if len(wb) > 1 {
+		round := 1
+		var prev []*node
+		for i := 0; i < len(wb[0])/2; i++ {
+			prev = append(prev, b.add(models.BracketLosers, round,
+				source{from: wb[0][2*i], loser: true},
+				source{from: wb[0][2*i+1], loser: true}))
+		}
+
+		for j := 1; j < len(wb); j++ {
+			round++
+			var dropIn []*node
+			for i, p := range prev {
+				// Verlierer in umgekehrter Reihenfolge, um frühe Rematches zu vermeiden
+				dropper := wb[j][len(wb[j])-1-i]
+				dropIn = append(dropIn, b.add(models.BracketLosers, round,
+					source{from: p},
+					source{from: dropper, loser: true}))
+			}
+			prev = dropIn
+
+			if j < len(wb)-1 {
+				round++
+				var next []*node
+				for i := 0; i < len(prev)/2; i++ {
+					next = append(next, b.add(models.BracketLosers, round,
+						source{from: prev[2*i]},
+						source{from: prev[2*i+1]}))
+				}
+				prev = next
+			}
+		}
+		lbChampion = source{from: prev[0]}
+	}
+
+	final := b.add(models.BracketFinal, 1, source{from: wbFinal}, lbChampion)
+	// Der Sieger des Winner-Brackets bleibt auch im Rückspiel Heimteam
+	b.add(models.BracketFinal, 2, source{from: final, loser: true}, source{from: final})
+	return b.plan(), nil
+}
+
+// winners baut das Winner-Bracket auf und liefert die Spiele je Runde
+func (b *bracket) winners(teams []*models.Team) [][]*node {
+	size := 2
+	for size < len(teams) {
+		size *= 2
+	}
+
+	seeds := seedOrder(size)
+	var rounds [][]*node
+	var first []*node
+	for i := 0; i < size/2; i++ {
+		first = append(first, b.add(models.BracketWinners, 1,
+			seedSource(teams, seeds[2*i]), seedSource(teams, seeds[2*i+1])))
+	}
+	rounds = append(rounds, first)
+
+	for prev := first; len(prev) > 1; {
+		var next []*node
+		for i := 0; i < len(prev)/2; i++ {
+			next = append(next, b.add(models.BracketWinners, len(rounds)+1,
+				source{from: prev[2*i]}, source{from: prev[2*i+1]}))
+		}
+		rounds = append(rounds, next)
+		prev = next
+	}
+	return rounds
+}
+
+func seedSource(teams []*models.Team, seed int) source {
+	if seed > len(teams) {
+		return source{dead: true}
+	}
+	return source{team: teams[seed-1]}
+}
+
+// seedOrder liefert die Setzpositionen, z.B. 1,8,4,5,2,7,3,6 bei 8 Plätzen,
+// sodass sich die besten Teams erst möglichst spät begegnen
+func seedOrder(size int) []int {
+	order := []int{1}
+	for len(order) < size {
+		n := len(order)*2 + 1
+		var next []int
+		for _, s := range order {
+			next = append(next, s, n-s)
+		}
+		order = next
+	}
+	return order
+}
+
+// plan löst Freilose auf und erzeugt die zu speichernden Spiele.
+// Die Knoten liegen in Erzeugungsreihenfolge vor, Zubringer also immer
+// vor ihren Folgespielen, daher reicht ein Durchlauf.
+func (b *bracket) plan() *Plan {
+	for _, n := range b.nodes {
+		for i := range n.src {
+			s := &n.src[i]
+			if s.from != nil && s.from.removed {
+				if s.loser {
+					*s = source{dead: true}
+				} else {
+					*s = s.from.pass
+				}
+			}
+		}
+
+		home, away := n.src[0], n.src[1]
+		switch {
+		case home.dead && away.dead:
+			n.removed = true
+			n.pass = source{dead: true}
+		case home.dead:
+			n.removed = true
+			n.pass = away
+		case away.dead:
+			n.removed = true
+			n.pass = home
+		}
+	}
+
+	plan := &Plan{}
+	for _, n := range b.nodes {
+		if n.removed {
+			continue
+		}
+		for i, s := range n.src {
+			slot := models.SlotHome
+			if i == 1 {
+				slot = models.SlotAway
+			}
+			switch {
+			case s.team != nil && slot == models.SlotHome:
+				n.match.Team1 = s.team
+			case s.team != nil:
+				n.match.Team2 = s.team
+			case s.from != nil:
+				plan.links = append(plan.links, link{from: s.from.match, to: n.match, slot: slot, loser: s.loser})
+			}
+		}
+		plan.Matches = append(plan.Matches, n.match)
+	}
+
+	schedule(plan, b.opts)
+	return plan
+}
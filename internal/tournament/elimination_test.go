@@ -0,0 +1,147 @@
+// internal/tournament/elimination_test.go
+
+package tournament
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+func testTeams(n int) []*models.Team {
+	var teams []*models.Team
+	for i := 1; i <= n; i++ {
+		teams = append(teams, &models.Team{ID: i, Name: fmt.Sprintf("Team %d", i)})
+	}
+	return teams
+}
+
+func testOptions() Options {
+	return Options{
+		Competition:  "Pokal",
+		Sportart:     "Fußball",
+		Template:     &models.TemplateSettings{ID: 1},
+		Start:        time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC),
+		SlotDuration: time.Hour,
+		Fields:       []*models.Field{{Name: "Feld 1"}, {Name: "Feld 2"}},
+	}
+}
+
+func TestSeedOrder(t *testing.T) {
+	tests := []struct {
+		size int
+		want []int
+	}{
+		{2, []int{1, 2}},
+		{4, []int{1, 4, 2, 3}},
+		{8, []int{1, 8, 4, 5, 2, 7, 3, 6}},
+	}
+	for _, tt := range tests {
+		if got := seedOrder(tt.size); !slices.Equal(got, tt.want) {
+			t.Errorf("seedOrder(%d) = %v, erwartet %v", tt.size, got, tt.want)
+		}
+	}
+}
+
+// firstRound liefert je Team die Runde, in der es fest gesetzt ist
+func firstRound(p *Plan) map[int]int {
+	rounds := map[int]int{}
+	for _, m := range p.Matches {
+		for _, team := range []*models.Team{m.Team1, m.Team2} {
+			if team.ID != 0 {
+				rounds[team.ID] = m.Round
+			}
+		}
+	}
+	return rounds
+}
+
+func TestSingleElimination(t *testing.T) {
+	for n := 2; n <= 9; n++ {
+		t.Run(fmt.Sprintf("%d Teams", n), func(t *testing.T) {
+			p, err := SingleElimination(testTeams(n), testOptions())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(p.Matches) != n-1 {
+				t.Errorf("%d Spiele, erwartet %d", len(p.Matches), n-1)
+			}
+
+			// Freilose gehen an die bestgesetzten Teams
+			size := 2
+			for size < n {
+				size *= 2
+			}
+			byes := size - n
+			rounds := firstRound(p)
+			for seed := 1; seed <= n; seed++ {
+				want := 1
+				if seed <= byes {
+					want = 2
+				}
+				if rounds[seed] != want {
+					t.Errorf("Team %d steigt in Runde %d ein, erwartet %d", seed, rounds[seed], want)
+				}
+			}
+		})
+	}
+}
+
+func TestDoubleElimination(t *testing.T) {
+	for n := 2; n <= 9; n++ {
+		t.Run(fmt.Sprintf("%d Teams", n), func(t *testing.T) {
+			p, err := DoubleElimination(testTeams(n), testOptions())
+			if err != nil {
+				t.Fatal(err)
+			}
+			// jedes Team außer dem Sieger verliert zweimal, dazu das Rückspiel
+			if len(p.Matches) != 2*n-1 {
+				t.Errorf("%d Spiele, erwartet %d", len(p.Matches), 2*n-1)
+			}
+
+			var final, reset *models.Match
+			for _, m := range p.Matches {
+				if m.Bracket == models.BracketFinal && m.Round == 1 {
+					final = m
+				}
+				if m.Bracket == models.BracketFinal && m.Round == 2 {
+					reset = m
+				}
+			}
+			if final == nil || reset == nil {
+				t.Fatal("Finale oder Rückspiel fehlt")
+			}
+			if !reset.GameTime.After(final.GameTime) {
+				t.Errorf("Rückspiel um %v, Finale erst um %v", reset.GameTime, final.GameTime)
+			}
+
+			slots := map[bool]string{}
+			for _, l := range p.links {
+				if l.from == final {
+					if l.to != reset {
+						t.Errorf("Finale verweist nicht auf das Rückspiel")
+					}
+					slots[l.loser] = l.slot
+				}
+			}
+			if slots[true] != models.SlotHome || slots[false] != models.SlotAway {
+				t.Errorf("Rückspiel: Verlierer %q, Sieger %q, erwartet home und away", slots[true], slots[false])
+			}
+		})
+	}
+}
+
+func TestScheduleFeedersFirst(t *testing.T) {
+	p, err := DoubleElimination(testTeams(8), testOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, l := range p.links {
+		if !l.to.GameTime.After(l.from.GameTime) {
+			t.Errorf("Spiel um %v liegt nicht nach seinem Zubringer um %v", l.to.GameTime, l.from.GameTime)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// internal/tournament/roundrobin.go
+
+package tournament
+
+import (
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// RoundRobin verteilt die Teams (in Setzreihenfolge) auf groups Gruppen
+// und erzeugt je Gruppe "jeder gegen jeden". Die Runden der Gruppen werden
+// abwechselnd eingeplant, damit alle Felder gleichmäßig belegt sind.
+func RoundRobin(teams []*models.Team, groups int, opts Options) (*Plan, error) {
+	if err := opts.validate(teams); err != nil {
+		return nil, err
+	}
+	if groups < 1 {
+		groups = 1
+	}
+	if len(teams) < groups*2 {
+		return nil, i18n.Errorf("error.too_few_teams_groups")
+	}
+	if groups > 26 {
+		return nil, i18n.Errorf("error.too_many_groups")
+	}
+
+	// Schlangenverteilung: 1-2-3-3-2-1 ..., so sind die Gruppen ausgeglichen
+	buckets := make([][]*models.Team, groups)
+	for i, t := range teams {
+		g := i % groups
+		if (i/groups)%2 == 1 {
+			g = groups - 1 - g
+		}
+		buckets[g] = append(buckets[g], t)
+	}
+
+	rounds := make([][][]*models.Match, groups)
+	maxRounds := 0
+	for g, bucket := range buckets {
+		name := ""
+		if groups > 1 {
+			name = string(rune('A' + g))
+		}
+		rounds[g] = circle(bucket, name, opts)
+		if len(rounds[g]) > maxRounds {
+			maxRounds = len(rounds[g])
+		}
+	}
+
+	plan := &Plan{}
+	for r := 0; r < maxRounds; r++ {
+		for g := range rounds {
+			if r < len(rounds[g]) {
+				plan.Matches = append(plan.Matches, rounds[g][r]...)
+			}
+		}
+	}
+	schedule(plan, opts)
+	return plan, nil
+}
+
+// circle erzeugt die Runden nach der Kreismethode (Berger-Tabelle).
+// Bei ungerader Teamzahl hat pro Runde ein Team spielfrei.
+func circle(teams []*models.Team, group string, opts Options) [][]*models.Match {
+	list := append([]*models.Team(nil), teams...)
+	if len(list)%2 == 1 {
+		list = append(list, nil)
+	}
+	n := len(list)
+
+	var rounds [][]*models.Match
+	for r := 0; r < n-1; r++ {
+		var round []*models.Match
+		for i := 0; i < n/2; i++ {
+			home, away := list[i], list[n-1-i]
+			if home == nil || away == nil {
+				continue
+			}
+			// Heimrecht abwechseln, sonst hätte das feste Team immer Heimspiel
+			if i == 0 && r%2 == 1 {
+				home, away = away, home
+			}
+			m := newMatch(opts, home, away)
+			m.Round = r + 1
+			m.Group = group
+			round = append(round, m)
+		}
+		rounds = append(rounds, round)
+
+		// alle außer dem ersten Team eine Position weiterdrehen
+		last := list[n-1]
+		copy(list[2:], list[1:n-1])
+		list[1] = last
+	}
+	return rounds
+}
@@ -0,0 +1,171 @@
+// internal/tournament/tournament.go
+
+package tournament
+
+import (
+	"time"
+
+	"github.com/KernTom/scoreboard-manager/internal/database"
+	"github.com/KernTom/scoreboard-manager/internal/i18n"
+	"github.com/KernTom/scoreboard-manager/internal/models"
+)
+
+// Options beschreibt Wettbewerb, Zeitplan und Spielfelder eines Turniers
+type Options struct {
+	Competition  string
+	Sportart     string
+	Template     *models.TemplateSettings
+	Start        time.Time
+	SlotDuration time.Duration   // Spielzeit inkl. Wechselpause
+	Fields       []*models.Field // Felder eines Spielorts oder ad hoc (ID 0), z.B. "Feld 1"
+}
+
+func (o Options) validate(teams []*models.Team) error {
+	if len(teams) < 2 {
+		return i18n.Errorf("error.too_few_teams")
+	}
+	if o.Template == nil {
+		// ohne Template braucht jedes Feld ein Standard-Template
+		for _, f := range o.Fields {
+			if f.TemplateID == 0 {
+				return i18n.Errorf("error.no_template")
+			}
+		}
+	}
+	if o.SlotDuration <= 0 {
+		return i18n.Errorf("error.slot_duration")
+	}
+	if len(o.Fields) == 0 {
+		return i18n.Errorf("error.no_fields")
+	}
+	seen := map[int]bool{}
+	for _, t := range teams {
+		if t == nil || t.ID == 0 {
+			return i18n.Errorf("error.teams_unsaved")
+		}
+		if seen[t.ID] {
+			return i18n.Errorf("error.team_duplicate", t.Name)
+		}
+		seen[t.ID] = true
+	}
+	return nil
+}
+
+// Plan ist ein generierter, noch nicht gespeicherter Spielplan
+type Plan struct {
+	Matches []*models.Match
+	links   []link
+}
+
+// link verbindet ein Spiel mit dem Folgespiel des Siegers bzw. Verlierers
+type link struct {
+	from  *models.Match
+	to    *models.Match
+	slot  string
+	loser bool
+}
+
+// Save speichert alle Spiele und verknüpft anschließend die Folgespiele.
+// Der Plan wird ganz oder gar nicht gespeichert.
+func (p *Plan) Save() error {
+	err := database.InTx(p.save)
+	if err != nil {
+		// IDs aus der zurückgerollten Transaktion verwerfen, damit ein
+		// erneutes Save die Spiele wieder anlegt
+		for _, m := range p.Matches {
+			m.ID = 0
+			m.NextMatchID, m.LoserNextMatchID = 0, 0
+		}
+	}
+	return err
+}
+
+func (p *Plan) save(tx *database.Tx) error {
+	for _, m := range p.Matches {
+		if err := tx.SaveMatches(m); err != nil {
+			return err
+		}
+	}
+
+	if len(p.links) == 0 {
+		return nil
+	}
+	for _, l := range p.links {
+		if l.loser {
+			l.from.LoserNextMatchID = l.to.ID
+			l.from.LoserNextMatchSlot = l.slot
+		} else {
+			l.from.NextMatchID = l.to.ID
+			l.from.NextMatchSlot = l.slot
+		}
+	}
+	for _, m := range p.Matches {
+		if m.NextMatchID == 0 && m.LoserNextMatchID == 0 {
+			continue
+		}
+		if err := tx.SaveMatches(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newMatch(opts Options, home, away *models.Team) *models.Match {
+	if home == nil {
+		home = &models.Team{}
+	}
+	if away == nil {
+		away = &models.Team{}
+	}
+	return &models.Match{
+		Team1:            home,
+		Team2:            away,
+		TemplateSettings: opts.Template,
+		Sportart:         opts.Sportart,
+		Competition:      opts.Competition,
+	}
+}
+
+// schedule verteilt die Spiele der Reihe nach auf Zeitslots und Felder.
+// Ein Team spielt nie zweimal im selben Slot und ein Spiel liegt immer
+// nach den Spielen, aus denen seine Teams kommen.
+func schedule(p *Plan, opts Options) {
+	feeders := map[*models.Match][]*models.Match{}
+	for _, l := range p.links {
+		feeders[l.to] = append(feeders[l.to], l.from)
+	}
+
+	slotOf := map[*models.Match]int{}
+	used := map[int]int{}
+	busy := map[int]map[int]bool{}
+
+	for _, m := range p.Matches {
+		slot := 0
+		for _, f := range feeders[m] {
+			if s := slotOf[f] + 1; s > slot {
+				slot = s
+			}
+		}
+		for ; ; slot++ {
+			if used[slot] >= len(opts.Fields) {
+				continue
+			}
+			if busy[slot] == nil {
+				busy[slot] = map[int]bool{}
+			}
+			if (m.Team1.ID != 0 && busy[slot][m.Team1.ID]) || (m.Team2.ID != 0 && busy[slot][m.Team2.ID]) {
+				continue
+			}
+			break
+		}
+
+		field := opts.Fields[used[slot]]
+		m.Field = field.Name
+		m.FieldID = field.ID
+		m.GameTime = opts.Start.Add(time.Duration(slot) * opts.SlotDuration)
+		used[slot]++
+		busy[slot][m.Team1.ID] = true
+		busy[slot][m.Team2.ID] = true
+		slotOf[m] = slot
+	}
+}
@@ -0,0 +1,59 @@
+//go:build windows
+
+package ui
+
+import (
+	"fmt"
+
+	"github.com/KernTom/scoreboard-manager/internal/models"
+
+	"github.com/lxn/walk"
+)
+
+// StandingsTableModel stellt eine berechnete Tabelle für TableViews bereit
+type StandingsTableModel struct {
+	walk.TableModelBase
+	Table *models.StandingsTable
+}
+
+func (m *StandingsTableModel) RowCount() int {
+	if m.Table == nil {
+		return 0
+	}
+	return len(m.Table.Rows)
+}
+
+func (m *StandingsTableModel) Value(row, col int) interface{} {
+	r := m.Table.Rows[row]
+	switch col {
+	case 0:
+		return r.Rank
+	case 1:
+		return r.Team.Name
+	case 2:
+		return r.Played
+	case 3:
+		return r.Won
+	case 4:
+		return r.Drawn
+	case 5:
+		return r.Lost
+	case 6:
+		return fmt.Sprintf("%d:%d", r.GoalsFor, r.GoalsAgainst)
+	case 7:
+		return r.GoalDifference()
+	case 8:
+		if m.Table.RankingMode == models.RankingWinPercentage {
+			return fmt.Sprintf("%.3f", r.WinPercentage)
+		}
+		return r.Points
+	default:
+		return ""
+	}
+}
+
+// SetTable tauscht die angezeigte Tabelle aus
+func (m *StandingsTableModel) SetTable(t *models.StandingsTable) {
+	m.Table = t
+	m.PublishRowsReset()
+}
@@ -0,0 +1,72 @@
+//go:build windows
+
+package ui
+
+import (
+	"github.com/KernTom/scoreboard-manager/internal/models"
+
+	"github.com/lxn/walk"
+)
+
+type VenueTableModel struct {
+	walk.TableModelBase
+	Venues []*models.Venue
+}
+
+func (m *VenueTableModel) RowCount() int {
+	return len(m.Venues)
+}
+
+func (m *VenueTableModel) Value(row, col int) interface{} {
+	venue := m.Venues[row]
+	switch col {
+	case 0:
+		return venue.Name
+	case 1:
+		return venue.Address
+	case 2:
+		return len(venue.Fields)
+	default:
+		return ""
+	}
+}
+
+func (m *VenueTableModel) GetVenue(index int) *models.Venue {
+	if index >= 0 && index < len(m.Venues) {
+		return m.Venues[index]
+	}
+	return nil
+}
+
+type FieldTableModel struct {
+	walk.TableModelBase
+	Fields []*models.Field
+	// TemplateName löst die Template-ID des Feldes für die Anzeige auf
+	TemplateName func(id int) string
+}
+
+func (m *FieldTableModel) RowCount() int {
+	return len(m.Fields)
+}
+
+func (m *FieldTableModel) Value(row, col int) interface{} {
+	field := m.Fields[row]
+	switch col {
+	case 0:
+		return field.Name
+	case 1:
+		if m.TemplateName != nil {
+			return m.TemplateName(field.TemplateID)
+		}
+		return field.TemplateID
+	default:
+		return ""
+	}
+}
+
+func (m *FieldTableModel) GetField(index int) *models.Field {
+	if index >= 0 && index < len(m.Fields) {
+		return m.Fields[index]
+	}
+	return nil
+}